@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_ceip" "ceip" {
+}
+
+output "ceip_status" {
+  value = data.vcf_ceip.ceip.status
+}
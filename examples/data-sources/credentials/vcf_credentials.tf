@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_credentials" "vcenter" {
+  resource_name   = "sfo-w01-vc01.sfo.rainpole.io"
+  resource_type   = "VCENTER"
+  credential_type = "SSO"
+}
+
+output "vcenter_username" {
+  value = data.vcf_credentials.vcenter.credentials[0].username
+}
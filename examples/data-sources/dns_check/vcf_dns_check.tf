@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_dns_check" "management" {
+  record {
+    fqdn       = "sfo-m01-vc01.sfo.rainpole.io"
+    ip_address = "10.0.0.6"
+  }
+  record {
+    fqdn       = "sfo-m01-nsx01.sfo.rainpole.io"
+    ip_address = "10.0.0.30"
+  }
+}
+
+output "dns_ready" {
+  value = data.vcf_dns_check.management.ready
+}
+
+output "dns_errors" {
+  value = data.vcf_dns_check.management.errors
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_domain_dependencies" "dependencies" {
+  domain_id = var.domain_id
+}
+
+output "domain_safe_to_destroy" {
+  value = data.vcf_domain_dependencies.dependencies.safe_to_destroy
+}
+
+output "domain_destroy_warnings" {
+  value = data.vcf_domain_dependencies.dependencies.warnings
+}
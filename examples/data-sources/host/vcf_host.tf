@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_host" "free_vsan_hosts" {
+  status       = "UNASSIGNED_USEABLE"
+  storage_type = "VSAN"
+}
+
+# The IDs of the free hosts can be consumed by the host blocks of vcf_cluster,
+# e.g. id = data.vcf_host.free_vsan_hosts.ids[0]
+output "free_vsan_host_ids" {
+  value = data.vcf_host.free_vsan_hosts.ids
+}
@@ -0,0 +1,28 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_license_key" "esxi" {
+  product_type = "ESXI"
+}
+
+data "vcf_license_key" "vsan" {
+  product_type = "VSAN"
+  description  = "vSAN license key of the workload domains"
+}
+
+# The keys are referenced by the host and vSAN specs of vcf_domain and vcf_cluster,
+# e.g. license_key = data.vcf_license_key.esxi.key
+output "vsan_license_key_status" {
+  value = data.vcf_license_key.vsan.status
+}
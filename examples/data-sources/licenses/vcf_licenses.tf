@@ -0,0 +1,29 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_licenses" "all" {
+}
+
+locals {
+  expired_license_keys      = [for l in data.vcf_licenses.all.license_keys : l.id if l.status == "EXPIRED"]
+  over_capacity_license_ids = [for l in data.vcf_licenses.all.license_keys : l.id if !l.is_unlimited && l.used > l.total]
+}
+
+output "expired_license_keys" {
+  value = local.expired_license_keys
+}
+
+output "over_capacity_license_keys" {
+  value = local.over_capacity_license_ids
+}
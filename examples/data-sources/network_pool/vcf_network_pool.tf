@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_network_pool" "pool" {
+  name = var.network_pool_name
+}
+
+output "network_pool_id" {
+  value = data.vcf_network_pool.pool.id
+}
+
+output "network_pool_free_ip_counts" {
+  value = { for network in data.vcf_network_pool.pool.network : network.type => network.free_ip_count }
+}
@@ -0,0 +1,19 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "network_pool_id" {
+  description = "ID of the network pool whose IP allocation is looked up"
+  default = ""
+}
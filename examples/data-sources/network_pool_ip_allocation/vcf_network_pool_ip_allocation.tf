@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_network_pool_ip_allocation" "vsan" {
+  network_pool_id = var.network_pool_id
+  network_type    = "VSAN"
+}
+
+output "vsan_next_free_ip" {
+  value = data.vcf_network_pool_ip_allocation.vsan.next_free_ip
+}
+
+output "vsan_free_ip_count" {
+  value = data.vcf_network_pool_ip_allocation.vsan.free_ip_count
+}
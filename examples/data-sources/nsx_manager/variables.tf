@@ -0,0 +1,30 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+
+variable "domain_id" {
+  description = "ID of the domain whose NSX Manager is looked up"
+  default = ""
+}
+
+variable "nsx_username" {
+  description = "Username used to authenticate against the NSX Manager"
+  default = "admin"
+}
+
+variable "nsx_password" {
+  description = "Password used to authenticate against the NSX Manager"
+  default = ""
+}
@@ -0,0 +1,30 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+    nsxt = {
+      source = "vmware/nsxt"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_nsx_manager" "nsx" {
+  domain_id = var.domain_id
+}
+
+provider "nsxt" {
+  host     = data.vcf_nsx_manager.nsx.url
+  username = var.nsx_username
+  password = var.nsx_password
+}
+
+output "nsx_manager_thumbprint" {
+  value = data.vcf_nsx_manager.nsx.thumbprint
+}
@@ -0,0 +1,23 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_personality" "image" {
+  name = "vsphere-8.0u1-image"
+}
+
+# The ID of the personality can be set as cluster_image_id of vcf_cluster or of the
+# cluster blocks of vcf_domain to create clusters managed by vSphere Lifecycle Manager images.
+output "cluster_image_id" {
+  value = data.vcf_personality.image.personality_id
+}
@@ -0,0 +1,36 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_resource_pool_template" "standard" {
+  name_prefix                   = "sfo-m01-cl01"
+  compute_cpu_reservation_mhz   = 1000
+  compute_memory_reservation_mb = 2048
+}
+
+# The generated resource pools can be consumed by the "resource_pool" blocks of the "cluster" of vcf_instance
+# dynamic "resource_pool" {
+#   for_each = data.vcf_resource_pool_template.standard.resource_pool
+#   content {
+#     name                  = resource_pool.value.name
+#     type                  = resource_pool.value.type
+#     cpu_shares_level      = resource_pool.value.cpu_shares_level
+#     cpu_reservation_mhz   = resource_pool.value.cpu_reservation_mhz
+#     memory_shares_level   = resource_pool.value.memory_shares_level
+#     memory_reservation_mb = resource_pool.value.memory_reservation_mb
+#   }
+# }
+
+output "resource_pools" {
+  value = data.vcf_resource_pool_template.standard.resource_pool
+}
@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_system_health" "health" {
+  domain_id     = var.domain_id
+  run_prechecks = true
+
+  lifecycle {
+    postcondition {
+      condition     = self.healthy
+      error_message = "The domain is not healthy, see the failed prechecks and the warnings."
+    }
+  }
+}
@@ -11,4 +11,9 @@ variable "sddc_manager_password" {
 variable "sddc_manager_host" {
   description = "Fully qualified domain name of an SDDC Manager instance"
   default = ""
-}
\ No newline at end of file
+}
+
+variable "domain_id" {
+  description = "The ID of the domain whose vCenter certificate is replaced"
+  default = ""
+}
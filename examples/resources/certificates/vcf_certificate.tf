@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+data "vcf_domain" "domain" {
+  domain_id = var.domain_id
+}
+
+resource "vcf_certificate" "vcenter" {
+  domain_id     = data.vcf_domain.domain.id
+  resource_type = "VCENTER"
+  resource_id   = data.vcf_domain.domain.vcenter_configuration[0].id
+  resource_fqdn = data.vcf_domain.domain.vcenter_configuration[0].fqdn
+  ca_type       = "OpenSSL"
+
+  csr {
+    country           = "BG"
+    state             = "Sofia-grad"
+    locality          = "Sofia"
+    organization      = "VMware"
+    organization_unit = "CIBG"
+    key_size          = "3072"
+  }
+}
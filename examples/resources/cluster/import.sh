@@ -0,0 +1,8 @@
+# Import a cluster by its ID. The hosts, the vSphere Distributed Switches with their portgroups and
+# the vSAN, VMFS or remote vSAN datastore configuration are read from SDDC Manager, NFS and vVol
+# datastores have to be added to the configuration. SDDC Manager does not return cluster_image_id,
+# evc_mode, high_availability_enabled, geneve_vlan_id, ip_address_pool and the failures_to_tolerate
+# and license_key of vsan_datastore, their values in the configuration are taken into the state by the
+# first apply after the import without being compared with the cluster. unread_attributes lists them
+# until then. Hosts added to the host list afterwards expand the cluster.
+terraform import vcf_cluster.cluster1 1a2b3c4d-5e6f-4a7b-8c9d-0e1f2a3b4c5d
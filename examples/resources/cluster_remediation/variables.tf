@@ -0,0 +1,29 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "cluster_id" {
+  description = "ID of the vSphere Lifecycle Manager image managed cluster to remediate"
+  default = ""
+}
+
+variable "bundle_id" {
+  description = "ID of the ESXi bundle used for the remediation"
+  default = ""
+}
+
+variable "personality_id" {
+  description = "ID of the personality the cluster is remediated against"
+  default = ""
+}
@@ -0,0 +1,22 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_cluster_remediation" "remediation" {
+  cluster_id        = var.cluster_id
+  bundle_id         = var.bundle_id
+  personality_id    = var.personality_id
+  rolling           = true
+  evacuation_policy = "EVACUATE"
+  enable_quickboot  = false
+}
@@ -0,0 +1,32 @@
+terraform {
+  required_providers {
+    vcf = {
+      source = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "time_rotating" "monthly" {
+  rotation_days = 30
+}
+
+resource "vcf_credentials_rotation" "esxi_root" {
+  resource_name  = var.esxi_host_fqdn
+  resource_type  = "ESXI"
+  operation_type = "ROTATE"
+
+  triggers = {
+    rotation = time_rotating.monthly.id
+  }
+
+  credentials {
+    credential_type = "SSH"
+    username        = "root"
+  }
+}
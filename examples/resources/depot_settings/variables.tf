@@ -0,0 +1,24 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "depot_username" {
+  description = "Username of the VMware Depot account"
+  default = ""
+}
+
+variable "depot_password" {
+  description = "Password of the VMware Depot account"
+  default = ""
+}
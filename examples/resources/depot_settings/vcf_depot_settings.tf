@@ -0,0 +1,20 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_depot_settings" "depot" {
+  vmware_account {
+    username = var.depot_username
+    password = var.depot_password
+  }
+}
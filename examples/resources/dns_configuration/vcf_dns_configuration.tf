@@ -0,0 +1,24 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_dns_configuration" "dns" {
+  dns_server {
+    ip_address = "172.16.11.4"
+    is_primary = true
+  }
+  dns_server {
+    ip_address = "172.16.11.5"
+    is_primary = false
+  }
+}
@@ -0,0 +1,3 @@
+# Import a workload domain by its ID. The vCenter root password and the NSX Manager passwords are
+# populated from the credentials managed by SDDC Manager. The management domain cannot be imported.
+terraform import vcf_domain.domain1 3a39c8b2-8f9e-4b12-9d3e-1c5b0b8a2e11
@@ -38,7 +38,7 @@ resource "vcf_instance" "sddc_1" {
   dns {
     domain = "vsphere.local"
     name_server = "10.0.0.250"
-    secondary_name_server = "10.0.0.250"
+    secondary_name_server = "10.0.0.251"
   }
   network {
     subnet = "10.0.0.0/22"
@@ -0,0 +1,26 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_depot_settings" "depot" {
+  vmware_account {
+    username = var.depot_username
+    password = var.depot_password
+  }
+}
+
+resource "vcf_lcm_bundle" "bundle" {
+  bundle_id = var.bundle_id
+
+  depends_on = [vcf_depot_settings.depot]
+}
@@ -0,0 +1,24 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "esxi_license_key" {
+  description = "ESXi license key to be added to SDDC Manager"
+  default = ""
+}
+
+variable "vsan_license_key" {
+  description = "vSAN license key to be added to SDDC Manager"
+  default = ""
+}
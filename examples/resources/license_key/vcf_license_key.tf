@@ -0,0 +1,25 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_license_key" "esxi" {
+  key          = var.esxi_license_key
+  product_type = "ESXI"
+  description  = "ESXi license key of the workload domains"
+}
+
+resource "vcf_license_key" "vsan" {
+  key          = var.vsan_license_key
+  product_type = "VSAN"
+  description  = "vSAN license key of the workload domains"
+}
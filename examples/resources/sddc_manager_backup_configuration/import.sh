@@ -0,0 +1,3 @@
+# Import the backup configuration of SDDC Manager. The VCF API does not return the password of the
+# backup server and the encryption passphrase, the first apply after the import sets them again.
+terraform import vcf_sddc_manager_backup_configuration.backup sddc-manager-backup-configuration
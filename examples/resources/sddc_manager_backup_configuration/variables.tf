@@ -0,0 +1,39 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "sftp_server" {
+  description = "FQDN of the SFTP server to which SDDC Manager is backed up"
+  default = ""
+}
+
+variable "sftp_username" {
+  description = "Username to authenticate to the SFTP server"
+  default = ""
+}
+
+variable "sftp_password" {
+  description = "Password to authenticate to the SFTP server"
+  default = ""
+}
+
+variable "sftp_ssh_fingerprint" {
+  description = "SSH fingerprint of the SFTP server"
+  default = ""
+}
+
+variable "backup_encryption_passphrase" {
+  description = "Passphrase the backup files are encrypted with"
+  default = ""
+}
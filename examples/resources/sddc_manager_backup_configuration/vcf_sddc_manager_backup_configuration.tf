@@ -0,0 +1,34 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_sddc_manager_backup_configuration" "backup" {
+  backup_location {
+    server          = var.sftp_server
+    directory_path  = "/backups/vcf"
+    username        = var.sftp_username
+    password        = var.sftp_password
+    ssh_fingerprint = var.sftp_ssh_fingerprint
+  }
+  encryption_passphrase = var.backup_encryption_passphrase
+  backup_schedule {
+    frequency      = "WEEKLY"
+    days_of_week   = ["SUNDAY", "WEDNESDAY"]
+    hour_of_day    = 2
+    minute_of_hour = 30
+    retention_policy {
+      number_of_most_recent_backups   = 10
+      number_of_days_of_daily_backups = 7
+    }
+  }
+}
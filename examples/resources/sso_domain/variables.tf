@@ -0,0 +1,29 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "ldap_username" {
+  description = "Username with which the Active Directory domain controllers are connected to"
+  default = ""
+}
+
+variable "ldap_password" {
+  description = "Password with which the Active Directory domain controllers are connected to"
+  default = ""
+}
+
+variable "ldap_cert_chain" {
+  description = "Certificate chain of the Active Directory domain controllers in base64 encoding"
+  default = ""
+}
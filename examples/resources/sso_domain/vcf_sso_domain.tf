@@ -0,0 +1,33 @@
+terraform {
+  required_providers {
+    vcf = {
+      source  = "vmware/vcf"
+    }
+  }
+}
+
+provider "vcf" {
+  sddc_manager_username = var.sddc_manager_username
+  sddc_manager_password = var.sddc_manager_password
+  sddc_manager_host     = var.sddc_manager_host
+}
+
+resource "vcf_sso_domain" "ad" {
+  name             = "example"
+  domain_name      = "example.com"
+  domain_alias     = "EXAMPLE"
+  type             = "ActiveDirectory"
+  username         = var.ldap_username
+  password         = var.ldap_password
+  users_base_dn    = "cn=Users,dc=example,dc=com"
+  groups_base_dn   = "dc=example,dc=com"
+  server_endpoints = ["ldaps://dc01.example.com:636", "ldaps://dc02.example.com:636"]
+  cert_chain       = [var.ldap_cert_chain]
+}
+
+resource "vcf_user" "vcf_admins" {
+  name      = "vcf-admins@example.com"
+  domain    = vcf_sso_domain.ad.domain_name
+  type      = "GROUP"
+  role_name = "ADMIN"
+}
@@ -0,0 +1,24 @@
+variable "sddc_manager_username" {
+  description = "Username used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_password" {
+  description = "Password used to authenticate against an SDDC Manager instance"
+  default = ""
+}
+
+variable "sddc_manager_host" {
+  description = "Fully qualified domain name of an SDDC Manager instance"
+  default = ""
+}
+
+variable "domain_id" {
+  description = "ID of the domain to upgrade"
+  default = ""
+}
+
+variable "target_version" {
+  description = "VCF version the domain is upgraded to"
+  default = ""
+}
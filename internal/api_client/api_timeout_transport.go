@@ -0,0 +1,51 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package api_client
+
+import (
+	"context"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"time"
+)
+
+// apiTimeoutTransport submits the operations of the VCF SDK with the API call timeout of the client it
+// belongs to, so that provider configurations with different timeouts do not affect each other.
+type apiTimeoutTransport struct {
+	runtime.ClientTransport
+	apiTimeout *time.Duration
+}
+
+func newApiTimeoutTransport(transport runtime.ClientTransport, apiTimeout *time.Duration) *apiTimeoutTransport {
+	return &apiTimeoutTransport{
+		ClientTransport: transport,
+		apiTimeout:      apiTimeout,
+	}
+}
+
+// Submit limits the operation to the API call timeout of the client, if it has one. The timeout is set
+// on the request after the operation parameters are written, as the parameters may be wrapped, e.g. to
+// add the page number, and then do not expose their timeout. The timeout of the request only applies
+// to operations without a context, so the context is limited as well.
+func (transport *apiTimeoutTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	apiTimeout := *transport.apiTimeout
+	if apiTimeout <= 0 {
+		return transport.ClientTransport.Submit(operation)
+	}
+	params := operation.Params
+	operation.Params = runtime.ClientRequestWriterFunc(func(request runtime.ClientRequest, registry strfmt.Registry) error {
+		if err := params.WriteToRequest(request, registry); err != nil {
+			return err
+		}
+		return request.SetTimeout(apiTimeout)
+	})
+	if operation.Context != nil {
+		ctx, cancel := context.WithTimeout(operation.Context, apiTimeout)
+		defer cancel()
+		operation.Context = ctx
+	}
+	return transport.ClientTransport.Submit(operation)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/go-openapi/strfmt"
 	vcfclient "github.com/vmware/vcf-sdk-go/client"
 	"net/http"
+	"time"
 )
 
 // CloudBuilderClient is an API client that can execute the APIs of the CloudBuilder appliance.
@@ -21,6 +22,7 @@ type CloudBuilderClient struct {
 	cloudBuilderUrl    string
 	ApiClient          *vcfclient.VcfClient
 	allowUnverifiedTls bool
+	apiTimeout         time.Duration
 }
 
 func NewCloudBuilderClient(username, password, url string, allowUnverifiedTls bool) *CloudBuilderClient {
@@ -34,28 +36,40 @@ func NewCloudBuilderClient(username, password, url string, allowUnverifiedTls bo
 	return result
 }
 
-func (cloudBuilderClient *CloudBuilderClient) init() {
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: cloudBuilderClient.allowUnverifiedTls}
+// SetApiTimeout overrides the timeout of every single API call to Cloud Builder, which is otherwise
+// the timeout set by the caller, e.g. constants.DefaultVcfApiCallTimeout.
+func (cloudBuilderClient *CloudBuilderClient) SetApiTimeout(timeout time.Duration) {
+	cloudBuilderClient.apiTimeout = timeout
+}
 
+func (cloudBuilderClient *CloudBuilderClient) init() {
 	cfg := vcfclient.DefaultTransportConfig()
 	openApiClient := openapiclient.New(cloudBuilderClient.cloudBuilderUrl, cfg.BasePath, cfg.Schemes)
 
 	openApiClient.Transport = cloudBuilderClient.newTransport()
 
 	// create the API client, with the transport
-	cloudBuilderOpenApiClient := vcfclient.New(openApiClient, strfmt.Default)
+	cloudBuilderOpenApiClient := vcfclient.New(newApiTimeoutTransport(openApiClient, &cloudBuilderClient.apiTimeout), strfmt.Default)
 	// save the client for later use
 	cloudBuilderClient.ApiClient = cloudBuilderOpenApiClient
 }
 
 func (cloudBuilderClient *CloudBuilderClient) newTransport() *cloudBuilderCustomHttpTransport {
 	return &cloudBuilderCustomHttpTransport{
-		originalTransport:  http.DefaultTransport,
+		originalTransport:  cloudBuilderClient.newHttpTransport(),
 		cloudBuilderClient: cloudBuilderClient,
 	}
 }
 
+// newHttpTransport clones the default transport, so that the TLS configuration applies only to the
+// connections to Cloud Builder.
+func (cloudBuilderClient *CloudBuilderClient) newHttpTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: cloudBuilderClient.allowUnverifiedTls}
+	return transport
+}
+
 type cloudBuilderCustomHttpTransport struct {
 	originalTransport  http.RoundTripper
 	cloudBuilderClient *CloudBuilderClient
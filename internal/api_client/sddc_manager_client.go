@@ -8,17 +8,28 @@ package api_client
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/credentials"
 	"github.com/vmware/vcf-sdk-go/client/tasks"
 	"github.com/vmware/vcf-sdk-go/client/tokens"
 	"github.com/vmware/vcf-sdk-go/models"
+	"golang.org/x/net/http/httpproxy"
 	"log"
 	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/go-openapi/runtime"
 	openapiclient "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
 	vcfclient "github.com/vmware/vcf-sdk-go/client"
@@ -28,13 +39,27 @@ import (
 type SddcManagerClient struct {
 	username           string
 	password           string
+	apiKey             string
 	sddcManagerUrl     string
 	accessToken        *string
+	refreshTokenId     string
 	ApiClient          *vcfclient.VcfClient
 	allowUnverifiedTls bool
 	lastRefreshTime    time.Time
+	tokenExpiry        time.Time
+	tokenRefreshMargin time.Duration
+	proxyUrl           *url.URL
+	caBundlePath       string
+	clientCertPath     string
+	clientKeyPath      string
+	tlsConfig          *tls.Config
+	connectTimeout     time.Duration
+	apiTimeout         time.Duration
+	basePath           string
+	maxRetries         int
+	retryBackoff       time.Duration
 	isRefreshing       bool
-	getTaskRetries     int
+	refreshLock        sync.Mutex
 }
 
 // NewSddcManagerClient constructs new Client instance with vcf credentials.
@@ -45,46 +70,164 @@ func NewSddcManagerClient(username, password, url string, allowUnverifiedTls boo
 		sddcManagerUrl:     url,
 		allowUnverifiedTls: allowUnverifiedTls,
 		lastRefreshTime:    time.Now(),
+		tokenRefreshMargin: DefaultTokenRefreshMargin,
 		isRefreshing:       false,
-		getTaskRetries:     0,
 	}
 }
 
-var accessToken *string
+// SetApiKey authenticates with the API key of a service user instead of a username and password.
+func (sddcManagerClient *SddcManagerClient) SetApiKey(apiKey string) {
+	sddcManagerClient.apiKey = apiKey
+}
+
+// SetAccessToken authenticates with a pre-issued access token instead of credentials. The access token
+// is renewed with the refresh token, if set, before it expires. If the access token is empty, Connect
+// obtains one with the refresh token.
+func (sddcManagerClient *SddcManagerClient) SetAccessToken(accessToken, refreshTokenId string) {
+	if len(accessToken) > 0 {
+		sddcManagerClient.accessToken = &accessToken
+	}
+	sddcManagerClient.refreshTokenId = refreshTokenId
+}
+
+// SetTokenRefreshMargin sets how long before its expiry the access token is refreshed.
+func (sddcManagerClient *SddcManagerClient) SetTokenRefreshMargin(margin time.Duration) {
+	sddcManagerClient.tokenRefreshMargin = margin
+}
+
+// SetProxyUrl sets the proxy through which SDDC Manager is reached. If not set, the
+// HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.
+func (sddcManagerClient *SddcManagerClient) SetProxyUrl(proxyUrl *url.URL) {
+	sddcManagerClient.proxyUrl = proxyUrl
+}
+
+// SetCaBundlePath sets the PEM file with the certificates of the CAs that are trusted, in addition to
+// the system trust store, to verify the certificate of SDDC Manager, e.g. of a private CA.
+func (sddcManagerClient *SddcManagerClient) SetCaBundlePath(caBundlePath string) {
+	sddcManagerClient.caBundlePath = caBundlePath
+}
+
+// SetClientCertificate sets the PEM files of the certificate and private key with which the client
+// authenticates to a SDDC Manager that is fronted by a proxy requiring mutual TLS.
+func (sddcManagerClient *SddcManagerClient) SetClientCertificate(certPath, keyPath string) {
+	sddcManagerClient.clientCertPath = certPath
+	sddcManagerClient.clientKeyPath = keyPath
+}
+
+// SetConnectTimeout sets for how long Connect retries to reach SDDC Manager, e.g. while it is
+// still starting after bring-up. Connect does not retry if the timeout is zero.
+func (sddcManagerClient *SddcManagerClient) SetConnectTimeout(timeout time.Duration) {
+	sddcManagerClient.connectTimeout = timeout
+}
+
+// SetApiTimeout overrides the timeout of every single API call to SDDC Manager, which is otherwise
+// the timeout set by the caller, e.g. constants.DefaultVcfApiCallTimeout.
+func (sddcManagerClient *SddcManagerClient) SetApiTimeout(timeout time.Duration) {
+	sddcManagerClient.apiTimeout = timeout
+}
+
+// SetBasePath overrides the base path of the SDDC Manager API, e.g. when SDDC Manager is reached
+// through a reverse proxy. If not set, the base path of the SDK is used.
+func (sddcManagerClient *SddcManagerClient) SetBasePath(basePath string) {
+	sddcManagerClient.basePath = basePath
+}
+
+// SetRetryPolicy sets how many times a failed read request to SDDC Manager is retried and the delay
+// before the first retry, doubled on every retry. Only GET and HEAD requests are retried, as retrying
+// requests that start tasks could start them twice.
+func (sddcManagerClient *SddcManagerClient) SetRetryPolicy(maxRetries int, retryBackoff time.Duration) {
+	sddcManagerClient.maxRetries = maxRetries
+	sddcManagerClient.retryBackoff = retryBackoff
+}
 
 const maxGetTaskRetries int = 10
+
+// getTaskRetryInterval is the delay between retries of getting a task. SDDC Manager is unreachable
+// while its services restart, e.g. after the certificate of SDDC Manager is replaced.
+var getTaskRetryInterval = 30 * time.Second
+
 const maxTaskRetries int = 6
 
+// taskPollInterval is the interval between polls of the status of a task.
+var taskPollInterval = 20 * time.Second
+
+// credentialsTaskPollInterval is the interval between polls of the status of a credentials task.
+var credentialsTaskPollInterval = 20 * time.Second
+
+// DefaultTokenRefreshMargin how long before its expiry the access token is refreshed by default.
+const DefaultTokenRefreshMargin = 5 * time.Minute
+
+// tokenRefreshInterval is used to refresh access tokens whose expiry cannot be determined.
+const tokenRefreshInterval = 20 * time.Minute
+
+// initialConnectRetryInterval is the delay before the first connect retry, doubled on every retry.
+var initialConnectRetryInterval = 5 * time.Second
+
+const maxConnectRetryInterval = time.Minute
+
 func (sddcManagerClient *SddcManagerClient) newTransport() *sddcManagerCustomHttpTransport {
 	return &sddcManagerCustomHttpTransport{
-		originalTransport: http.DefaultTransport,
+		originalTransport: sddcManagerClient.newHttpTransport(),
 		sddcManagerClient: sddcManagerClient,
 	}
 }
 
+// newHttpTransport clones the default transport, applies the TLS configuration and routes the requests
+// through the configured proxy.
+// Unlike http.ProxyFromEnvironment the proxy environment variables are evaluated on every request.
+func (sddcManagerClient *SddcManagerClient) newHttpTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if sddcManagerClient.tlsConfig != nil {
+		transport.TLSClientConfig = sddcManagerClient.tlsConfig
+	}
+	if sddcManagerClient.proxyUrl != nil {
+		transport.Proxy = http.ProxyURL(sddcManagerClient.proxyUrl)
+	} else {
+		transport.Proxy = func(r *http.Request) (*url.URL, error) {
+			return httpproxy.FromEnvironment().ProxyFunc()(r.URL)
+		}
+	}
+	return transport
+}
+
 type sddcManagerCustomHttpTransport struct {
 	originalTransport http.RoundTripper
 	sddcManagerClient *SddcManagerClient
 }
 
 func (c *sddcManagerCustomHttpTransport) RoundTrip(r *http.Request) (*http.Response, error) {
-	// Refresh the access token every 20 minutes so that SDK operations won't start to
+	// Refresh the access token before it expires so that SDK operations won't start to
 	// fail with 401, 403 because of token expiration, during long-running tasks
-	if time.Since(c.sddcManagerClient.lastRefreshTime) > 20*time.Minute &&
-		!c.sddcManagerClient.isRefreshing {
-		err := c.sddcManagerClient.Connect()
+	if c.sddcManagerClient.shouldRefreshToken() {
+		err := c.sddcManagerClient.refreshToken(r.Context())
 		if err != nil {
 			return nil, err
 		}
 	}
 
-	if accessToken != nil {
+	if accessToken := c.sddcManagerClient.getAccessToken(); accessToken != nil {
 		r.Header.Add("Authorization", fmt.Sprintf("Bearer %s", *accessToken))
 	}
 
 	r.Header.Add("Content-Type", "application/json")
 
 	resp, err := c.originalTransport.RoundTrip(r)
+	retryBackoff := c.sddcManagerClient.retryBackoff
+	for retry := 0; retry < c.sddcManagerClient.maxRetries && isRetryableRequest(r, resp, err); retry++ {
+		if err != nil {
+			tflog.Warn(r.Context(), fmt.Sprintf("%s %s failed, retrying in %s: %s", r.Method, r.URL.Path, retryBackoff, err))
+		} else {
+			tflog.Warn(r.Context(), fmt.Sprintf("%s %s returned %d, retrying in %s", r.Method, r.URL.Path, resp.StatusCode, retryBackoff))
+			_ = resp.Body.Close()
+		}
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-time.After(retryBackoff):
+		}
+		retryBackoff *= 2
+		resp, err = c.originalTransport.RoundTrip(r)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -92,40 +235,253 @@ func (c *sddcManagerCustomHttpTransport) RoundTrip(r *http.Request) (*http.Respo
 	return resp, nil
 }
 
-func (sddcManagerClient *SddcManagerClient) Connect() error {
+// isRetryableRequest reports whether a request failed transiently, e.g. because SDDC Manager is
+// overloaded or its services restart, and can be retried without side effects.
+func isRetryableRequest(r *http.Request, resp *http.Response, err error) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if err != nil {
+		return r.Context().Err() == nil
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (sddcManagerClient *SddcManagerClient) shouldRefreshToken() bool {
+	sddcManagerClient.refreshLock.Lock()
+	defer sddcManagerClient.refreshLock.Unlock()
+
+	if sddcManagerClient.isRefreshing || !sddcManagerClient.canRenewToken() {
+		return false
+	}
+	if sddcManagerClient.tokenExpiry.IsZero() {
+		return time.Since(sddcManagerClient.lastRefreshTime) > tokenRefreshInterval
+	}
+	return time.Until(sddcManagerClient.tokenExpiry) < sddcManagerClient.tokenRefreshMargin
+}
+
+// refreshToken creates a new access token, bound to the context of the operation that triggered the refresh.
+// Concurrent requests keep using the current token, which is still valid during the refresh margin.
+func (sddcManagerClient *SddcManagerClient) refreshToken(ctx context.Context) error {
+	sddcManagerClient.refreshLock.Lock()
+	if sddcManagerClient.isRefreshing {
+		sddcManagerClient.refreshLock.Unlock()
+		return nil
+	}
 	sddcManagerClient.isRefreshing = true
-	// Disable cert checks
-	http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{
-		InsecureSkipVerify: sddcManagerClient.allowUnverifiedTls}
+	sddcManagerClient.refreshLock.Unlock()
 
-	cfg := vcfclient.DefaultTransportConfig()
-	openApiClient := openapiclient.New(sddcManagerClient.sddcManagerUrl, cfg.BasePath, cfg.Schemes)
+	err := sddcManagerClient.renewToken(ctx)
 
-	openApiClient.Transport = sddcManagerClient.newTransport()
+	sddcManagerClient.refreshLock.Lock()
+	sddcManagerClient.isRefreshing = false
+	sddcManagerClient.refreshLock.Unlock()
+	return err
+}
 
-	// create the API client, with the transport
-	vcfClient := vcfclient.New(openApiClient, strfmt.Default)
-	// save the client for later use
-	sddcManagerClient.ApiClient = vcfClient
-	// Get access token
+// canRenewToken reports whether a new access token can be obtained, which is not the case if only
+// a pre-issued access token without a refresh token is configured.
+func (sddcManagerClient *SddcManagerClient) canRenewToken() bool {
+	return sddcManagerClient.hasCredentials() || len(sddcManagerClient.refreshTokenId) > 0
+}
+
+func (sddcManagerClient *SddcManagerClient) hasCredentials() bool {
+	return len(sddcManagerClient.username) > 0 || len(sddcManagerClient.apiKey) > 0
+}
+
+// renewToken creates a new access token with the configured credentials or, if only tokens are
+// configured, refreshes the access token with the refresh token.
+func (sddcManagerClient *SddcManagerClient) renewToken(ctx context.Context) error {
+	if sddcManagerClient.hasCredentials() {
+		return sddcManagerClient.createToken(ctx)
+	}
+	return sddcManagerClient.refreshAccessToken(ctx)
+}
+
+func (sddcManagerClient *SddcManagerClient) createToken(ctx context.Context) error {
 	tokenSpec := &models.TokenCreationSpec{
 		Username: sddcManagerClient.username,
 		Password: sddcManagerClient.password,
+		APIKey:   sddcManagerClient.apiKey,
 	}
-	params := tokens.NewCreateTokenParams().
+	params := tokens.NewCreateTokenParamsWithContext(ctx).
 		WithTokenCreationSpec(tokenSpec).WithTimeout(constants.DefaultVcfApiCallTimeout)
 
-	ok, _, err := vcfClient.Tokens.CreateToken(params)
+	ok, _, err := sddcManagerClient.ApiClient.Tokens.CreateToken(params)
 	if err != nil {
 		return err
 	}
 
-	accessToken = &ok.Payload.AccessToken
-	// save the access token for later use
+	sddcManagerClient.setAccessToken(ok.Payload.AccessToken)
+	return nil
+}
+
+func (sddcManagerClient *SddcManagerClient) refreshAccessToken(ctx context.Context) error {
+	params := tokens.NewRefreshAccessTokenParamsWithContext(ctx).
+		WithRefreshToken(sddcManagerClient.refreshTokenId).WithTimeout(constants.DefaultVcfApiCallTimeout)
+
+	ok, err := sddcManagerClient.ApiClient.Tokens.RefreshAccessToken(params)
+	if err != nil {
+		return err
+	}
+
+	sddcManagerClient.setAccessToken(ok.Payload)
+	return nil
+}
+
+// setAccessToken saves the access token of this client for later use.
+func (sddcManagerClient *SddcManagerClient) setAccessToken(token string) {
+	sddcManagerClient.refreshLock.Lock()
 	sddcManagerClient.lastRefreshTime = time.Now()
-	sddcManagerClient.accessToken = &ok.Payload.AccessToken
+	sddcManagerClient.tokenExpiry = getTokenExpiry(token)
+	sddcManagerClient.accessToken = &token
+	sddcManagerClient.refreshLock.Unlock()
+}
+
+func (sddcManagerClient *SddcManagerClient) getAccessToken() *string {
+	sddcManagerClient.refreshLock.Lock()
+	defer sddcManagerClient.refreshLock.Unlock()
+	return sddcManagerClient.accessToken
+}
+
+// connectWithRetry obtains an access token, retrying with backoff until the connect timeout
+// elapses while SDDC Manager is unreachable. Rejected credentials and tokens are not retried.
+// A pre-issued access token is used as is, unless it is due to be refreshed.
+func (sddcManagerClient *SddcManagerClient) connectWithRetry(ctx context.Context) error {
+	if !sddcManagerClient.hasCredentials() && sddcManagerClient.accessToken != nil {
+		sddcManagerClient.setAccessToken(*sddcManagerClient.accessToken)
+		tokenExpiry := sddcManagerClient.tokenExpiry
+		if len(sddcManagerClient.refreshTokenId) == 0 ||
+			tokenExpiry.IsZero() || time.Until(tokenExpiry) >= sddcManagerClient.tokenRefreshMargin {
+			return nil
+		}
+	}
+	if !sddcManagerClient.canRenewToken() {
+		return errors.New("credentials, an access token or a refresh token must be set to connect to SDDC Manager")
+	}
+	deadline := time.Now().Add(sddcManagerClient.connectTimeout)
+	retryInterval := initialConnectRetryInterval
+	for {
+		err := sddcManagerClient.renewToken(ctx)
+		if err == nil || !isRetryableConnectError(err) || time.Now().Add(retryInterval).After(deadline) {
+			return err
+		}
+		log.Printf("Connecting to SDDC Manager %s failed, retrying in %s: %s",
+			sddcManagerClient.sddcManagerUrl, retryInterval, err)
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("stopped connecting to SDDC Manager %s: %w", sddcManagerClient.sddcManagerUrl, ctx.Err())
+		case <-time.After(retryInterval):
+		}
+		retryInterval *= 2
+		if retryInterval > maxConnectRetryInterval {
+			retryInterval = maxConnectRetryInterval
+		}
+	}
+}
+
+func isRetryableConnectError(err error) bool {
+	var badRequest *tokens.CreateTokenBadRequest
+	if errors.As(err, &badRequest) {
+		return false
+	}
+	var refreshBadRequest *tokens.RefreshAccessTokenBadRequest
+	var refreshNotFound *tokens.RefreshAccessTokenNotFound
+	if errors.As(err, &refreshBadRequest) || errors.As(err, &refreshNotFound) {
+		return false
+	}
+	var apiError *runtime.APIError
+	if errors.As(err, &apiError) {
+		return apiError.Code != http.StatusUnauthorized && apiError.Code != http.StatusForbidden
+	}
+	return true
+}
+
+// getTokenExpiry returns the expiry time from the "exp" claim of a JWT access token,
+// or the zero time if the token cannot be parsed.
+func getTokenExpiry(token string) time.Time {
+	tokenParts := strings.Split(token, ".")
+	if len(tokenParts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(tokenParts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err = json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// newTlsConfig returns the TLS configuration of the connections to SDDC Manager, which trusts the
+// configured CA bundle and presents the configured client certificate.
+func (sddcManagerClient *SddcManagerClient) newTlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: sddcManagerClient.allowUnverifiedTls}
+	if sddcManagerClient.caBundlePath != "" {
+		caBundle, err := os.ReadFile(sddcManagerClient.caBundlePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		rootCAs, err := x509.SystemCertPool()
+		if err != nil {
+			rootCAs = x509.NewCertPool()
+		}
+		if !rootCAs.AppendCertsFromPEM(caBundle) {
+			return nil, fmt.Errorf("no PEM certificates found in CA bundle %s", sddcManagerClient.caBundlePath)
+		}
+		tlsConfig.RootCAs = rootCAs
+	}
+	if sddcManagerClient.clientCertPath != "" {
+		clientCert, err := tls.LoadX509KeyPair(sddcManagerClient.clientCertPath, sddcManagerClient.clientKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{clientCert}
+	}
+	return tlsConfig, nil
+}
+
+// Connect obtains an access token from SDDC Manager. Retries while it is unreachable stop when the
+// context is done, e.g. when Terraform is interrupted.
+func (sddcManagerClient *SddcManagerClient) Connect(ctx context.Context) error {
+	tlsConfig, err := sddcManagerClient.newTlsConfig()
+	if err != nil {
+		return err
+	}
+	sddcManagerClient.tlsConfig = tlsConfig
+
+	sddcManagerClient.refreshLock.Lock()
+	sddcManagerClient.isRefreshing = true
+	sddcManagerClient.refreshLock.Unlock()
+
+	cfg := vcfclient.DefaultTransportConfig()
+	if sddcManagerClient.basePath != "" {
+		cfg.BasePath = sddcManagerClient.basePath
+	}
+	openApiClient := openapiclient.New(sddcManagerClient.sddcManagerUrl, cfg.BasePath, cfg.Schemes)
+
+	openApiClient.Transport = sddcManagerClient.newTransport()
+
+	// create the API client, with the transport
+	vcfClient := vcfclient.New(newApiTimeoutTransport(openApiClient, &sddcManagerClient.apiTimeout), strfmt.Default)
+	// save the client for later use
+	sddcManagerClient.ApiClient = vcfClient
+	// Get access token
+	err = sddcManagerClient.connectWithRetry(ctx)
+
+	sddcManagerClient.refreshLock.Lock()
 	sddcManagerClient.isRefreshing = false
-	return nil
+	sddcManagerClient.refreshLock.Unlock()
+	return err
 }
 
 // WaitForTask Wait for a task to complete (waits for up to a minute).
@@ -134,7 +490,7 @@ func (sddcManagerClient *SddcManagerClient) WaitForTask(ctx context.Context, tas
 	taskStatusRetry := 10
 
 	for taskStatusRetry > 0 {
-		task, err := sddcManagerClient.getTask(ctx, taskId)
+		task, err := sddcManagerClient.GetTask(ctx, taskId)
 		if err != nil {
 			log.Println("error = ", err)
 			return err
@@ -161,16 +517,28 @@ func (sddcManagerClient *SddcManagerClient) WaitForTask(ctx context.Context, tas
 
 // WaitForTaskComplete Wait for task till it completes (either succeeds or fails).
 func (sddcManagerClient *SddcManagerClient) WaitForTaskComplete(ctx context.Context, taskId string, retry bool) error {
+	return sddcManagerClient.WaitForTaskCompleteWithProgress(ctx, taskId, retry, nil)
+}
+
+// WaitForTaskCompleteWithProgress Wait for task till it completes (either succeeds or fails), calling
+// reportProgress, if not nil, with every polled state of the task.
+func (sddcManagerClient *SddcManagerClient) WaitForTaskCompleteWithProgress(ctx context.Context, taskId string, retry bool,
+	reportProgress func(task *models.Task)) error {
 	log.Printf("Getting status of task %s", taskId)
 	currentTaskRetries := 0
 	for {
-		task, err := sddcManagerClient.getTask(ctx, taskId)
+		task, err := sddcManagerClient.GetTask(ctx, taskId)
 		if err != nil {
 			return err
 		}
+		if reportProgress != nil {
+			reportProgress(task)
+		}
 
 		if task.Status == "In Progress" || task.Status == "Pending" {
-			time.Sleep(20 * time.Second)
+			if err = waitForTaskPoll(ctx, taskId); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -189,7 +557,9 @@ func (sddcManagerClient *SddcManagerClient) WaitForTaskComplete(ctx context.Cont
 			} else {
 				return errors.New(errorMsg)
 			}
-			time.Sleep(20 * time.Second)
+			if err = waitForTaskPoll(ctx, taskId); err != nil {
+				return err
+			}
 			continue
 		}
 
@@ -198,8 +568,196 @@ func (sddcManagerClient *SddcManagerClient) WaitForTaskComplete(ctx context.Cont
 	}
 }
 
+// waitForTaskPoll waits for the next poll of the status of a task. If the wait is interrupted, the
+// task keeps running in SDDC Manager and can be resumed with ResumeTask.
+func waitForTaskPoll(ctx context.Context, taskId string) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for task %s, which keeps running in SDDC Manager: %w", taskId, ctx.Err())
+	case <-time.After(taskPollInterval):
+		return nil
+	}
+}
+
+// TaskFilter selects the tasks looked up by FindLatestTask. Empty fields match all tasks.
+type TaskFilter struct {
+	// TaskTypes the accepted task types, e.g. HOST_COMMISSION.
+	TaskTypes []string
+	// ResourceType and ResourceName the resource the task is associated with.
+	ResourceType string
+	ResourceName string
+	// DomainId the ID of the domain the task is associated with.
+	DomainId string
+	// CreatedAfter excludes the tasks created before.
+	CreatedAfter time.Time
+	// Statuses the accepted task statuses.
+	Statuses []string
+}
+
+func (filter TaskFilter) matches(task *models.Task) bool {
+	if len(filter.TaskTypes) > 0 && !containsFold(filter.TaskTypes, task.Type) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !containsFold(filter.Statuses, task.Status) {
+		return false
+	}
+	if !filter.CreatedAfter.IsZero() {
+		creationTimestamp, err := time.Parse(time.RFC3339, task.CreationTimestamp)
+		if err != nil || creationTimestamp.Before(filter.CreatedAfter) {
+			return false
+		}
+	}
+	return (filter.ResourceType == "" || isTaskAssociatedWith(task, func(resource *models.Resource) bool {
+		return strings.EqualFold(*resource.Type, filter.ResourceType) &&
+			(filter.ResourceName == "" || strings.EqualFold(resource.Name, filter.ResourceName))
+	})) && (filter.DomainId == "" || isTaskAssociatedWith(task, func(resource *models.Resource) bool {
+		return strings.EqualFold(*resource.Type, "Domain") && resource.ResourceID != nil &&
+			*resource.ResourceID == filter.DomainId
+	}))
+}
+
+func isTaskAssociatedWith(task *models.Task, isResource func(resource *models.Resource) bool) bool {
+	for _, resource := range task.Resources {
+		if resource != nil && resource.Type != nil && isResource(resource) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// FindLatestFailedTask returns the most recent failed task that matches the filter, or nil if there is none.
+func (sddcManagerClient *SddcManagerClient) FindLatestFailedTask(ctx context.Context, filter TaskFilter) (*models.Task, error) {
+	filter.Statuses = []string{"Failed"}
+	return sddcManagerClient.FindLatestTask(ctx, filter)
+}
+
+// FindLatestTask returns the most recent task that matches the filter, or nil if there is none.
+// All pages of the tasks are searched.
+func (sddcManagerClient *SddcManagerClient) FindLatestTask(ctx context.Context, filter TaskFilter) (*models.Task, error) {
+	var latestTask *models.Task
+	err := sddcManagerClient.forEachTask(ctx, func(task *models.Task) {
+		if filter.matches(task) && (latestTask == nil || task.CreationTimestamp > latestTask.CreationTimestamp) {
+			latestTask = task
+		}
+	})
+	return latestTask, err
+}
+
+// forEachTask calls the function for the tasks on all pages of the tasks API.
+func (sddcManagerClient *SddcManagerClient) forEachTask(ctx context.Context, taskFunc func(task *models.Task)) error {
+	apiClient := sddcManagerClient.ApiClient
+	var pageOptions []tasks.ClientOption
+	for pagesRead := 0; ; pagesRead++ {
+		getTasksParams := tasks.NewGetTasksParamsWithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithContext(ctx)
+
+		getTasksResult, err := apiClient.Tasks.GetTasks(getTasksParams, pageOptions...)
+		if err != nil {
+			return err
+		}
+		page := getTasksResult.Payload
+		for _, task := range page.Elements {
+			if task != nil {
+				taskFunc(task)
+			}
+		}
+		// The page numbers are not part of the GetTasksParams, they are added as query parameter.
+		if page.PageMetadata == nil || len(page.Elements) == 0 || pagesRead+1 >= int(page.PageMetadata.TotalPages) {
+			return nil
+		}
+		pageOptions = []tasks.ClientOption{withPageNumber(page.PageMetadata.PageNumber + 1)}
+	}
+}
+
+func withPageNumber(pageNumber int32) tasks.ClientOption {
+	return func(operation *runtime.ClientOperation) {
+		params := operation.Params
+		operation.Params = runtime.ClientRequestWriterFunc(func(request runtime.ClientRequest, registry strfmt.Registry) error {
+			if err := params.WriteToRequest(request, registry); err != nil {
+				return err
+			}
+			return request.SetQueryParam("pageNumber", strconv.Itoa(int(pageNumber)))
+		})
+	}
+}
+
+// ResumeTask resumes tracking the creation task that matches the filter, which was interrupted, e.g.
+// because Terraform was killed or SDDC Manager was unreachable. Terraform persists the state only once
+// a creation returns, so the task is looked up instead. Only the latest matching task is considered:
+// it is waited for while it runs and retried if it failed and retryFailed is set. The filter must
+// select the creation task type, so that no other task of a resource with the same name is resumed.
+// Returns the ID of the resumed task, or an empty string if there is no task to resume.
+func (sddcManagerClient *SddcManagerClient) ResumeTask(ctx context.Context, filter TaskFilter, retryFailed bool) (string, error) {
+	if len(filter.TaskTypes) == 0 {
+		return "", errors.New("the task type of the creation to resume is required")
+	}
+	filter.Statuses = nil
+	latestTask, err := sddcManagerClient.FindLatestTask(ctx, filter)
+	if err != nil || latestTask == nil {
+		return "", err
+	}
+	switch {
+	case containsFold([]string{"In Progress", "Pending"}, latestTask.Status):
+		tflog.Info(ctx, fmt.Sprintf("Resuming waiting for task %s of %s %q", latestTask.ID, filter.ResourceType, filter.ResourceName))
+		return latestTask.ID, sddcManagerClient.WaitForTaskComplete(ctx, latestTask.ID, true)
+	case retryFailed && strings.EqualFold(latestTask.Status, "Failed"):
+		tflog.Info(ctx, fmt.Sprintf("Retrying failed task %s of %s %q", latestTask.ID, filter.ResourceType, filter.ResourceName))
+		return latestTask.ID, sddcManagerClient.RetryTask(ctx, latestTask.ID)
+	}
+	return "", nil
+}
+
+// RetryTask retries a failed task and waits for it to complete.
+func (sddcManagerClient *SddcManagerClient) RetryTask(ctx context.Context, taskId string) error {
+	err := sddcManagerClient.retryTask(ctx, taskId)
+	if err != nil {
+		return err
+	}
+	return sddcManagerClient.WaitForTaskComplete(ctx, taskId, true)
+}
+
+// WaitForCredentialsTask Wait for a credentials task till it completes (either succeeds or fails).
+// Password operations are tracked by the credentials tasks API rather than the tasks API.
+func (sddcManagerClient *SddcManagerClient) WaitForCredentialsTask(ctx context.Context, taskId string) error {
+	apiClient := sddcManagerClient.ApiClient
+	for {
+		getCredentialsTaskParams := credentials.NewGetCredentialsTaskParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(taskId)
+		getCredentialsTaskResult, err := apiClient.Credentials.GetCredentialsTask(getCredentialsTaskParams)
+		if err != nil {
+			return err
+		}
+		task := getCredentialsTaskResult.Payload
+
+		switch strings.ToUpper(task.Status) {
+		case "SUCCESSFUL":
+			log.Printf("Credentials task with ID = %s is in state %s", taskId, task.Status)
+			return nil
+		case "IN_PROGRESS", "IN PROGRESS", "PENDING":
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(credentialsTaskPollInterval):
+			}
+		default:
+			errorMsg := fmt.Sprintf("Credentials task with ID = %s , Name: %q is in state %s", taskId, task.Name, task.Status)
+			tflog.Error(ctx, errorMsg)
+			return errors.New(errorMsg)
+		}
+	}
+}
+
 func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTask(ctx context.Context, taskId, resourceType string) (string, error) {
-	task, err := sddcManagerClient.getTask(ctx, taskId)
+	task, err := sddcManagerClient.GetTask(ctx, taskId)
 	if err != nil {
 		return "", err
 	}
@@ -214,25 +772,40 @@ func (sddcManagerClient *SddcManagerClient) GetResourceIdAssociatedWithTask(ctx
 	return "", fmt.Errorf("task %q did not contain resources of type %q", taskId, resourceType)
 }
 
-func (sddcManagerClient *SddcManagerClient) getTask(ctx context.Context, taskId string) (*models.Task, error) {
+// GetTask gets a task, retrying transient failures, e.g. while the services of SDDC Manager restart
+// after its certificate is replaced or during its upgrade.
+func (sddcManagerClient *SddcManagerClient) GetTask(ctx context.Context, taskId string) (*models.Task, error) {
 	apiClient := sddcManagerClient.ApiClient
-	getTaskParams := tasks.NewGetTaskParamsWithTimeout(constants.DefaultVcfApiCallTimeout).
-		WithContext(ctx)
-	getTaskParams.ID = taskId
-
-	getTaskResult, err := apiClient.Tasks.GetTask(getTaskParams)
-	if err != nil {
-		// retry the task up to maxGetTaskRetries
-		if sddcManagerClient.getTaskRetries < maxGetTaskRetries {
-			sddcManagerClient.getTaskRetries++
-			return sddcManagerClient.getTask(ctx, taskId)
+	for retries := 0; ; retries++ {
+		getTaskParams := tasks.NewGetTaskParamsWithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithContext(ctx)
+		getTaskParams.ID = taskId
+
+		getTaskResult, err := apiClient.Tasks.GetTask(getTaskParams)
+		if err == nil {
+			return getTaskResult.Payload, nil
 		}
-		log.Println("error = ", err)
-		return nil, err
+		if retries >= maxGetTaskRetries || ctx.Err() != nil || !isTransientError(err) {
+			log.Println("error = ", err)
+			return nil, err
+		}
+		tflog.Warn(ctx, fmt.Sprintf("Getting task %s failed, retrying in %s: %s", taskId, getTaskRetryInterval, err))
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(getTaskRetryInterval):
+		}
+	}
+}
+
+// isTransientError reports whether a failed API call can succeed when retried, which is the case for
+// network errors and server errors, but not for client errors such as an unknown ID.
+func isTransientError(err error) bool {
+	var response interface{ IsServerError() bool }
+	if errors.As(err, &response) {
+		return response.IsServerError()
 	}
-	// reset the counter
-	sddcManagerClient.getTaskRetries = 0
-	return getTaskResult.Payload, nil
+	return true
 }
 
 func (sddcManagerClient *SddcManagerClient) retryTask(ctx context.Context, taskId string) error {
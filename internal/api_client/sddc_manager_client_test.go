@@ -0,0 +1,730 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package api_client
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/strfmt"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setForTest sets a package variable, e.g. a poll interval, and restores it when the test completes.
+func setForTest[T any](t *testing.T, variable *T, value T) {
+	original := *variable
+	*variable = value
+	t.Cleanup(func() { *variable = original })
+}
+
+func TestGetTokenExpiry(t *testing.T) {
+	t.Run("Get token expiry", func(t *testing.T) {
+		encodeClaims := func(claims string) string {
+			return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+		}
+		var tokenExpiryTests = []struct {
+			token          string
+			expectedExpiry time.Time
+		}{
+			{encodeClaims(`{"sub":"admin@local","exp":1700000000}`), time.Unix(1700000000, 0)},
+			{encodeClaims(`{"sub":"admin@local"}`), time.Time{}},
+			{encodeClaims(`not json`), time.Time{}},
+			{"opaque-token", time.Time{}},
+		}
+
+		for _, tokenExpiryTest := range tokenExpiryTests {
+			expiry := getTokenExpiry(tokenExpiryTest.token)
+			if !expiry.Equal(tokenExpiryTest.expectedExpiry) {
+				t.Errorf("failed. Unexpected expiry for token %s : %s, expected %s",
+					tokenExpiryTest.token, expiry, tokenExpiryTest.expectedExpiry)
+			}
+		}
+	})
+}
+
+func TestShouldRefreshToken(t *testing.T) {
+	t.Run("Refresh token within margin", func(t *testing.T) {
+		client := NewSddcManagerClient("admin@local", "", "", false)
+		client.SetTokenRefreshMargin(5 * time.Minute)
+
+		client.tokenExpiry = time.Now().Add(10 * time.Minute)
+		if client.shouldRefreshToken() {
+			t.Errorf("failed. Expected no refresh for a token expiring outside of the refresh margin")
+		}
+
+		client.tokenExpiry = time.Now().Add(4 * time.Minute)
+		if !client.shouldRefreshToken() {
+			t.Errorf("failed. Expected refresh for a token expiring within the refresh margin")
+		}
+
+		client.isRefreshing = true
+		if client.shouldRefreshToken() {
+			t.Errorf("failed. Expected no refresh while a refresh is in progress")
+		}
+	})
+}
+
+func TestTransportProxy(t *testing.T) {
+	newRequest := func(host string) *http.Request {
+		request, _ := http.NewRequest(http.MethodGet, "https://"+host+"/v1/tokens", nil)
+		return request
+	}
+	proxyFor := func(client *SddcManagerClient, host string) string {
+		transport := client.newTransport().originalTransport.(*http.Transport)
+		proxyUrl, err := transport.Proxy(newRequest(host))
+		if err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		if proxyUrl == nil {
+			return ""
+		}
+		return proxyUrl.String()
+	}
+
+	t.Run("Honor proxy environment variables", func(t *testing.T) {
+		t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+		t.Setenv("NO_PROXY", "excluded.example.com")
+		client := NewSddcManagerClient("admin@local", "", "sddc-manager.example.com", false)
+
+		if proxy := proxyFor(client, "sddc-manager.example.com"); proxy != "http://env-proxy.example.com:3128" {
+			t.Errorf("failed. Unexpected proxy %q, expected the proxy from HTTPS_PROXY", proxy)
+		}
+		if proxy := proxyFor(client, "excluded.example.com"); proxy != "" {
+			t.Errorf("failed. Unexpected proxy %q for a host excluded by NO_PROXY", proxy)
+		}
+	})
+
+	t.Run("Prefer the configured proxy", func(t *testing.T) {
+		t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+		client := NewSddcManagerClient("admin@local", "", "sddc-manager.example.com", false)
+		proxyUrl, _ := url.Parse("http://proxy.example.com:8080")
+		client.SetProxyUrl(proxyUrl)
+
+		if proxy := proxyFor(client, "sddc-manager.example.com"); proxy != "http://proxy.example.com:8080" {
+			t.Errorf("failed. Unexpected proxy %q, expected the configured proxy", proxy)
+		}
+	})
+}
+
+func TestConnectRetry(t *testing.T) {
+	setForTest(t, &initialConnectRetryInterval, 10*time.Millisecond)
+	newServer := func(responses ...int) (*httptest.Server, *int) {
+		requests := 0
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			status := responses[len(responses)-1]
+			if requests < len(responses) {
+				status = responses[requests]
+			}
+			requests++
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+		}))
+		return server, &requests
+	}
+
+	t.Run("Retry until SDDC Manager is reachable", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK)
+		defer server.Close()
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetConnectTimeout(time.Minute)
+
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		if *requests != 3 {
+			t.Errorf("failed. Unexpected number of requests %d, expected 3", *requests)
+		}
+	})
+
+	t.Run("Do not retry rejected credentials", func(t *testing.T) {
+		server, requests := newServer(http.StatusUnauthorized)
+		defer server.Close()
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetConnectTimeout(time.Minute)
+
+		if err := client.Connect(context.Background()); err == nil {
+			t.Fatal("failed. Expected an error for rejected credentials")
+		}
+		if *requests != 1 {
+			t.Errorf("failed. Unexpected number of requests %d, expected 1", *requests)
+		}
+	})
+
+	t.Run("Stop retrying when the context is done", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable)
+		defer server.Close()
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetConnectTimeout(time.Hour)
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		if err := client.Connect(ctx); err == nil {
+			t.Fatal("failed. Expected an error for a cancelled connect")
+		}
+		if *requests > 5 {
+			t.Errorf("failed. Unexpected number of requests %d after the context was done", *requests)
+		}
+	})
+
+	t.Run("Do not retry without a connect timeout", func(t *testing.T) {
+		server, requests := newServer(http.StatusServiceUnavailable)
+		defer server.Close()
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+
+		if err := client.Connect(context.Background()); err == nil {
+			t.Fatal("failed. Expected an error for an unavailable SDDC Manager")
+		}
+		if *requests != 1 {
+			t.Errorf("failed. Unexpected number of requests %d, expected 1", *requests)
+		}
+	})
+}
+
+func TestConnectBasePath(t *testing.T) {
+	var requestPath string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+	}))
+	defer server.Close()
+	client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+	client.SetBasePath("/sddc-manager/")
+
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("failed. Unexpected error: %s", err)
+	}
+	if requestPath != "/sddc-manager/v1/tokens" {
+		t.Errorf("failed. Unexpected request path %q, expected /sddc-manager/v1/tokens", requestPath)
+	}
+}
+
+// testClientRequest records the timeout and the query parameters written to a request.
+type testClientRequest struct {
+	runtime.ClientRequest
+	timeout     time.Duration
+	queryParams url.Values
+}
+
+func (r *testClientRequest) SetTimeout(timeout time.Duration) error {
+	r.timeout = timeout
+	return nil
+}
+
+func (r *testClientRequest) SetQueryParam(name string, values ...string) error {
+	r.queryParams[name] = values
+	return nil
+}
+
+// testClientTransport writes the parameters of the submitted operation to request.
+type testClientTransport struct {
+	request *testClientRequest
+}
+
+func (transport *testClientTransport) Submit(operation *runtime.ClientOperation) (interface{}, error) {
+	return nil, operation.Params.WriteToRequest(transport.request, strfmt.Default)
+}
+
+func TestApiTimeoutPagedRequest(t *testing.T) {
+	request := &testClientRequest{queryParams: url.Values{}}
+	apiTimeout := 5 * time.Minute
+	transport := newApiTimeoutTransport(&testClientTransport{request: request}, &apiTimeout)
+
+	operation := &runtime.ClientOperation{
+		Params: tasks.NewGetTasksParamsWithTimeout(constants.DefaultVcfApiCallTimeout).WithContext(context.Background()),
+	}
+	withPageNumber(2)(operation)
+	if _, err := transport.Submit(operation); err != nil {
+		t.Fatalf("failed. Unexpected error: %s", err)
+	}
+	if request.timeout != apiTimeout {
+		t.Errorf("failed. Unexpected timeout %s of a paged request, expected the API timeout %s", request.timeout, apiTimeout)
+	}
+	if request.queryParams.Get("pageNumber") != "2" {
+		t.Errorf("failed. Unexpected query parameters %v, expected page 2", request.queryParams)
+	}
+}
+
+func TestConnectWithTokens(t *testing.T) {
+	encodeToken := func(expiry time.Time) string {
+		claims := fmt.Sprintf(`{"sub":"admin@local","exp":%d}`, expiry.Unix())
+		return "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".signature"
+	}
+	newServer := func() (*httptest.Server, *[]string) {
+		var requests []string
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			requests = append(requests, r.Method+" "+r.URL.Path+" "+strings.TrimSpace(string(body)))
+			w.Header().Set("Content-Type", "application/json")
+			switch r.URL.Path {
+			case "/v1/tokens/access-token/refresh":
+				_, _ = w.Write([]byte(`"refreshed-token"`))
+			default:
+				_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+			}
+		}))
+		return server, &requests
+	}
+
+	t.Run("Use a pre-issued access token", func(t *testing.T) {
+		server, requests := newServer()
+		defer server.Close()
+		client := NewSddcManagerClient("", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetAccessToken(encodeToken(time.Now().Add(time.Hour)), "")
+
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		if len(*requests) != 0 {
+			t.Errorf("failed. Unexpected requests %v for a valid access token", *requests)
+		}
+		client.tokenExpiry = time.Now().Add(time.Minute)
+		if client.shouldRefreshToken() {
+			t.Error("failed. Expected no refresh of an access token without a refresh token")
+		}
+	})
+
+	t.Run("Refresh an expiring access token", func(t *testing.T) {
+		server, requests := newServer()
+		defer server.Close()
+		client := NewSddcManagerClient("", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetAccessToken(encodeToken(time.Now().Add(time.Minute)), "refresh-token-id")
+
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		if len(*requests) != 1 || (*requests)[0] != http.MethodPatch+` /v1/tokens/access-token/refresh "refresh-token-id"` {
+			t.Errorf("failed. Unexpected requests %v, expected a refresh of the access token", *requests)
+		}
+		if *client.accessToken != "refreshed-token" {
+			t.Errorf("failed. Unexpected access token %q, expected the refreshed token", *client.accessToken)
+		}
+	})
+
+	t.Run("Create an access token with an API key", func(t *testing.T) {
+		server, requests := newServer()
+		defer server.Close()
+		client := NewSddcManagerClient("", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetApiKey("api-key")
+
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		if len(*requests) != 1 || !strings.Contains((*requests)[0], `"apiKey":"api-key"`) {
+			t.Errorf("failed. Unexpected requests %v, expected a token created with the API key", *requests)
+		}
+	})
+
+	t.Run("Fail without credentials or tokens", func(t *testing.T) {
+		client := NewSddcManagerClient("", "", "sddc-manager.example.com", true)
+		if err := client.Connect(context.Background()); err == nil {
+			t.Error("failed. Expected an error without credentials or tokens")
+		}
+	})
+}
+
+func TestAccessTokenPerClient(t *testing.T) {
+	var authorizations []string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorizations = append(authorizations, r.Header.Get("Authorization"))
+	}))
+	defer server.Close()
+
+	for _, token := range []string{"token-1", "token-2"} {
+		client := NewSddcManagerClient("", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetAccessToken(token, "")
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		transport := client.newTransport()
+		transport.originalTransport = server.Client().Transport
+		request, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/tasks", nil)
+		if _, err := transport.RoundTrip(request); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+	}
+	if len(authorizations) != 2 || authorizations[0] != "Bearer token-1" || authorizations[1] != "Bearer token-2" {
+		t.Errorf("failed. Unexpected authorizations %v, expected the access token of each client", authorizations)
+	}
+}
+
+func TestConnectCaBundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+	}))
+	defer server.Close()
+	caBundlePath := filepath.Join(t.TempDir(), "ca.pem")
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caBundlePath, caBundle, 0600); err != nil {
+		t.Fatalf("failed. Unexpected error: %s", err)
+	}
+
+	t.Run("Trust the CA bundle", func(t *testing.T) {
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), false)
+		client.SetCaBundlePath(caBundlePath)
+		if err := client.Connect(context.Background()); err != nil {
+			t.Errorf("failed. Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Reject an untrusted certificate", func(t *testing.T) {
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), false)
+		if err := client.Connect(context.Background()); err == nil {
+			t.Error("failed. Expected an error for an untrusted certificate")
+		}
+	})
+
+	t.Run("Fail for an invalid CA bundle", func(t *testing.T) {
+		invalidCaBundlePath := filepath.Join(t.TempDir(), "invalid.pem")
+		_ = os.WriteFile(invalidCaBundlePath, []byte("not a certificate"), 0600)
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), false)
+		client.SetCaBundlePath(invalidCaBundlePath)
+		if err := client.Connect(context.Background()); err == nil {
+			t.Error("failed. Expected an error for a CA bundle without certificates")
+		}
+	})
+}
+
+func TestWaitForCredentialsTask(t *testing.T) {
+	setForTest(t, &credentialsTaskPollInterval, time.Millisecond)
+	newClient := func(statuses ...string) (*SddcManagerClient, *httptest.Server) {
+		polls := 0
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/v1/tokens" {
+				_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+				return
+			}
+			status := statuses[len(statuses)-1]
+			if polls < len(statuses) {
+				status = statuses[polls]
+			}
+			polls++
+			_, _ = w.Write([]byte(`{"id":"task-1","status":"` + status + `"}`))
+		}))
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		return client, server
+	}
+
+	t.Run("Wait until the task succeeds", func(t *testing.T) {
+		client, server := newClient("IN_PROGRESS", "IN_PROGRESS", "SUCCESSFUL")
+		defer server.Close()
+		if err := client.WaitForCredentialsTask(context.Background(), "task-1"); err != nil {
+			t.Errorf("failed. Unexpected error: %s", err)
+		}
+	})
+
+	t.Run("Fail with the task", func(t *testing.T) {
+		client, server := newClient("IN_PROGRESS", "FAILED")
+		defer server.Close()
+		if err := client.WaitForCredentialsTask(context.Background(), "task-1"); err == nil {
+			t.Error("failed. Expected an error for a failed task")
+		}
+	})
+}
+
+func TestRoundTripRetry(t *testing.T) {
+	requestCount := 0
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestCount++
+		if requestCount <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewSddcManagerClient("admin", "password", server.URL, true)
+	client.SetRetryPolicy(3, time.Millisecond)
+	transport := client.newTransport()
+	transport.originalTransport = server.Client().Transport
+
+	request, _ := http.NewRequest(http.MethodGet, server.URL+"/v1/tasks", nil)
+	response, err := transport.RoundTrip(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		t.Errorf("expected status %d, got %d", http.StatusOK, response.StatusCode)
+	}
+	if requestCount != 3 {
+		t.Errorf("expected 3 requests, got %d", requestCount)
+	}
+
+	requestCount = 0
+	request, _ = http.NewRequest(http.MethodPost, server.URL+"/v1/domains", nil)
+	response, err = transport.RoundTrip(request)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if response.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected status %d, got %d", http.StatusServiceUnavailable, response.StatusCode)
+	}
+	if requestCount != 1 {
+		t.Errorf("expected POST not to be retried, got %d requests", requestCount)
+	}
+}
+
+func TestResumeTask(t *testing.T) {
+	setForTest(t, &taskPollInterval, time.Millisecond)
+	newClient := func(tasksResponse string) (*SddcManagerClient, *httptest.Server, *[]string) {
+		var requests []string
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			switch {
+			case r.URL.Path == "/v1/tokens":
+				_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+			case r.URL.Path == "/v1/tasks":
+				_, _ = w.Write([]byte(tasksResponse))
+			default:
+				_, _ = w.Write([]byte(`{"id":"task-2","status":"Successful"}`))
+			}
+		}))
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		return client, server, &requests
+	}
+	now := time.Now().UTC()
+	timestamp := func(age time.Duration) string {
+		return now.Add(-age).Format(time.RFC3339)
+	}
+	tasks := func(status string) string {
+		return fmt.Sprintf(`{"elements":[
+			{"id":"task-1","type":"DOMAIN_CREATION","status":"Successful","creationTimestamp":%q,"resources":[{"type":"Domain","name":"wld01","resourceId":"domain-1"}]},
+			{"id":"task-2","type":"DOMAIN_CREATION","status":%q,"creationTimestamp":%q,"resources":[{"type":"Domain","name":"wld01","resourceId":"domain-1"}]},
+			{"id":"task-3","type":"DOMAIN_DELETION","status":"In Progress","creationTimestamp":%q,"resources":[{"type":"Domain","name":"wld01","resourceId":"domain-1"}]},
+			{"id":"task-4","type":"DOMAIN_CREATION","status":"In Progress","creationTimestamp":%q,"resources":[{"type":"Domain","name":"wld02","resourceId":"domain-2"}]}]}`,
+			timestamp(3*time.Hour), status, timestamp(2*time.Hour), timestamp(time.Hour), timestamp(time.Hour))
+	}
+	domainCreation := TaskFilter{
+		TaskTypes:    []string{"DOMAIN_CREATION"},
+		ResourceType: "Domain",
+		ResourceName: "wld01",
+		CreatedAfter: now.Add(-24 * time.Hour),
+	}
+
+	t.Run("Resume waiting for a running task", func(t *testing.T) {
+		client, server, requests := newClient(tasks("In Progress"))
+		defer server.Close()
+		taskId, err := client.ResumeTask(context.Background(), domainCreation, false)
+		if err != nil || taskId != "task-2" {
+			t.Errorf("failed. Expected task-2, got %q, %v", taskId, err)
+		}
+		for _, request := range *requests {
+			if strings.HasPrefix(request, http.MethodPatch) {
+				t.Errorf("failed. Unexpected retry of a running task: %s", request)
+			}
+		}
+	})
+
+	t.Run("Retry a failed task", func(t *testing.T) {
+		client, server, requests := newClient(tasks("Failed"))
+		defer server.Close()
+		taskId, err := client.ResumeTask(context.Background(), domainCreation, true)
+		if err != nil || taskId != "task-2" {
+			t.Errorf("failed. Expected task-2, got %q, %v", taskId, err)
+		}
+		retried := false
+		for _, request := range *requests {
+			retried = retried || request == http.MethodPatch+" /v1/tasks/task-2"
+		}
+		if !retried {
+			t.Error("failed. Expected the failed task to be retried")
+		}
+	})
+
+	t.Run("Do not retry a failed task unless requested", func(t *testing.T) {
+		client, server, _ := newClient(tasks("Failed"))
+		defer server.Close()
+		taskId, err := client.ResumeTask(context.Background(), domainCreation, false)
+		if err != nil || taskId != "" {
+			t.Errorf("failed. Expected no task to resume, got %q, %v", taskId, err)
+		}
+	})
+
+	t.Run("Do not resume a completed creation", func(t *testing.T) {
+		client, server, _ := newClient(tasks("Successful"))
+		defer server.Close()
+		taskId, err := client.ResumeTask(context.Background(), domainCreation, true)
+		if err != nil || taskId != "" {
+			t.Errorf("failed. Expected no task to resume, got %q, %v", taskId, err)
+		}
+	})
+
+	t.Run("Do not resume an outdated creation", func(t *testing.T) {
+		client, server, _ := newClient(tasks("Failed"))
+		defer server.Close()
+		outdatedCreation := domainCreation
+		outdatedCreation.CreatedAfter = now.Add(-time.Hour)
+		taskId, err := client.ResumeTask(context.Background(), outdatedCreation, true)
+		if err != nil || taskId != "" {
+			t.Errorf("failed. Expected no task to resume, got %q, %v", taskId, err)
+		}
+	})
+}
+
+func TestFindLatestTask(t *testing.T) {
+	pages := []string{
+		`{"elements":[
+			{"id":"task-1","type":"HOST_COMMISSION","status":"Successful","creationTimestamp":"2023-10-01T10:00:00.000Z","resources":[{"type":"Host","name":"esxi-1","resourceId":"host-1"},{"type":"Domain","name":"wld01","resourceId":"domain-1"}]},
+			{"id":"task-2","type":"HOST_DECOMMISSION","status":"Successful","creationTimestamp":"2023-10-04T10:00:00.000Z","resources":[{"type":"Host","name":"esxi-1","resourceId":"host-1"}]}],
+			"pageMetadata":{"pageNumber":0,"pageSize":2,"totalElements":4,"totalPages":2}}`,
+		`{"elements":[
+			{"id":"task-3","type":"HOST_COMMISSION","status":"Failed","creationTimestamp":"2023-10-03T10:00:00.000Z","resources":[{"type":"Host","name":"esxi-1","resourceId":"host-1"},{"type":"Domain","name":"wld02","resourceId":"domain-2"}]},
+			{"id":"task-4","type":"HOST_COMMISSION","status":"Successful","creationTimestamp":"2023-10-02T10:00:00.000Z","resources":[{"type":"Host","name":"esxi-1","resourceId":"host-1"},{"type":"Domain","name":"wld01","resourceId":"domain-1"}]}],
+			"pageMetadata":{"pageNumber":1,"pageSize":2,"totalElements":4,"totalPages":2}}`,
+	}
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/tokens":
+			_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+		case "/v1/tasks":
+			pageNumber, _ := strconv.Atoi(r.URL.Query().Get("pageNumber"))
+			_, _ = w.Write([]byte(pages[pageNumber]))
+		}
+	}))
+	defer server.Close()
+	client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+	if err := client.Connect(context.Background()); err != nil {
+		t.Fatalf("failed. Unexpected error: %s", err)
+	}
+
+	var findTests = []struct {
+		name           string
+		filter         TaskFilter
+		expectedTaskId string
+	}{
+		{"Find the latest task of the resource on all pages",
+			TaskFilter{ResourceType: "Host", ResourceName: "esxi-1"}, "task-2"},
+		{"Filter by task type",
+			TaskFilter{TaskTypes: []string{"HOST_COMMISSION"}, ResourceType: "Host", ResourceName: "esxi-1"}, "task-3"},
+		{"Filter by domain",
+			TaskFilter{TaskTypes: []string{"HOST_COMMISSION"}, ResourceType: "Host", DomainId: "domain-1"}, "task-4"},
+		{"Filter by status",
+			TaskFilter{ResourceType: "Host", ResourceName: "esxi-1", Statuses: []string{"Failed"}}, "task-3"},
+		{"Filter by creation time",
+			TaskFilter{ResourceType: "Host", DomainId: "domain-1",
+				CreatedAfter: time.Date(2023, 10, 3, 0, 0, 0, 0, time.UTC)}, ""},
+	}
+
+	for _, findTest := range findTests {
+		t.Run(findTest.name, func(t *testing.T) {
+			task, err := client.FindLatestTask(context.Background(), findTest.filter)
+			if err != nil {
+				t.Fatalf("failed. Unexpected error: %s", err)
+			}
+			taskId := ""
+			if task != nil {
+				taskId = task.ID
+			}
+			if taskId != findTest.expectedTaskId {
+				t.Errorf("failed. Expected %q, got %q", findTest.expectedTaskId, taskId)
+			}
+		})
+	}
+}
+
+func TestGetTaskRetry(t *testing.T) {
+	setForTest(t, &getTaskRetryInterval, time.Millisecond)
+	newClient := func(responses ...int) (*SddcManagerClient, *httptest.Server, *int) {
+		requests := 0
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Path == "/v1/tokens" {
+				_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+				return
+			}
+			status := responses[len(responses)-1]
+			if requests < len(responses) {
+				status = responses[requests]
+			}
+			requests++
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(`{"id":"task-1","status":"Successful"}`))
+		}))
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		return client, server, &requests
+	}
+
+	t.Run("Retry server errors", func(t *testing.T) {
+		client, server, requests := newClient(http.StatusInternalServerError, http.StatusServiceUnavailable, http.StatusOK)
+		defer server.Close()
+		if _, err := client.GetTask(context.Background(), "task-1"); err != nil {
+			t.Errorf("failed. Unexpected error: %s", err)
+		}
+		if *requests != 3 {
+			t.Errorf("failed. Unexpected number of requests %d, expected 3", *requests)
+		}
+	})
+
+	t.Run("Do not retry an unknown task", func(t *testing.T) {
+		client, server, requests := newClient(http.StatusNotFound)
+		defer server.Close()
+		if _, err := client.GetTask(context.Background(), "task-1"); err == nil {
+			t.Error("failed. Expected an error for an unknown task")
+		}
+		if *requests != 1 {
+			t.Errorf("failed. Unexpected number of requests %d, expected 1", *requests)
+		}
+	})
+}
+
+func TestApiTimeout(t *testing.T) {
+	setForTest(t, &getTaskRetryInterval, time.Millisecond)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/v1/tokens" {
+			_, _ = w.Write([]byte(`{"accessToken":"opaque-token"}`))
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"id":"task-1","status":"Successful"}`))
+	}))
+	defer server.Close()
+	newClient := func(apiTimeout time.Duration) *SddcManagerClient {
+		client := NewSddcManagerClient("admin@local", "", strings.TrimPrefix(server.URL, "https://"), true)
+		client.SetApiTimeout(apiTimeout)
+		if err := client.Connect(context.Background()); err != nil {
+			t.Fatalf("failed. Unexpected error: %s", err)
+		}
+		return client
+	}
+
+	shortTimeoutClient := newClient(50 * time.Millisecond)
+	defaultTimeoutClient := newClient(0)
+	if _, err := shortTimeoutClient.GetTask(context.Background(), "task-1"); err == nil {
+		t.Error("failed. Expected an error for an API call exceeding the API timeout of the client")
+	}
+	if _, err := defaultTimeoutClient.GetTask(context.Background(), "task-1"); err != nil {
+		t.Errorf("failed. Unexpected error for a client without API timeout: %s", err)
+	}
+}
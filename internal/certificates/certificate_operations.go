@@ -0,0 +1,173 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/network"
+	"github.com/vmware/vcf-sdk-go/client"
+	certificates_api "github.com/vmware/vcf-sdk-go/client/certificates"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/models"
+	"strings"
+)
+
+const (
+	NsxManagerResourceType = "NSXT_MANAGER"
+	VcenterResourceType    = "VCENTER"
+	PscResourceType        = "PSC"
+)
+
+// ResourceTypes are the types of the resources whose certificates can be managed.
+var ResourceTypes = []string{SddcManagerResourceType, VcenterResourceType, NsxManagerResourceType, PscResourceType}
+
+// GetDomainName returns the name of a domain, which the certificate APIs take instead of its ID.
+func GetDomainName(ctx context.Context, domainId string, apiClient *client.VcfClient) (string, error) {
+	getDomainParams := domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getDomainParams.ID = domainId
+
+	domainResult, err := apiClient.Domains.GetDomain(getDomainParams)
+	if err != nil {
+		return "", err
+	}
+	return domainResult.Payload.Name, nil
+}
+
+// GetResourceCertificateSans returns the subject alternative names of the certificate of a resource.
+// Unless overridden by sans, these are derived from the resource, or are the FQDN of the resource
+// for resource types without a SAN lookup.
+func GetResourceCertificateSans(ctx context.Context, resourceType, resourceId, resourceFqdn string, sans []string,
+	apiClient *client.VcfClient) ([]string, error) {
+	switch resourceType {
+	case SddcManagerResourceType:
+		return GetSddcManagerCertificateSans(ctx, resourceId, sans, apiClient)
+	case NsxManagerResourceType:
+		return network.GetNsxCertificateSans(ctx, resourceId, sans, apiClient)
+	}
+	if len(sans) > 0 {
+		return sans, nil
+	}
+	return []string{resourceFqdn}, nil
+}
+
+// GenerateCsr starts the generation of a CSR for a resource and returns the ID of the task.
+func GenerateCsr(ctx context.Context, domainName string, csrGenerationSpec *models.CSRGenerationSpec,
+	resource *models.Resource, apiClient *client.VcfClient) (string, error) {
+	generateCsrsParams := certificates_api.NewGeneratesCSRsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDomainName(domainName).
+		WithCSRSGenerationSpec(&models.CSRSGenerationSpec{
+			CSRGenerationSpec: csrGenerationSpec,
+			Resources:         []*models.Resource{resource},
+		})
+
+	responseOk, responseAccepted, err := apiClient.Certificates.GeneratesCSRs(generateCsrsParams)
+	if err != nil {
+		return "", err
+	}
+	if responseOk != nil {
+		return responseOk.Payload.ID, nil
+	}
+	return responseAccepted.Payload.ID, nil
+}
+
+// GenerateCertificate starts the signing of the CSR of a resource by the configured certificate
+// authority of type caType and returns the ID of the task.
+func GenerateCertificate(ctx context.Context, domainName, caType string, resource *models.Resource,
+	apiClient *client.VcfClient) (string, error) {
+	generateCertificatesParams := certificates_api.NewGenerateCertificatesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDomainName(domainName).
+		WithCertificateGenerationSpec(&models.CertificatesGenerationSpec{
+			CaType:    &caType,
+			Resources: []*models.Resource{resource},
+		})
+
+	responseOk, responseAccepted, err := apiClient.Certificates.GenerateCertificates(generateCertificatesParams)
+	if err != nil {
+		return "", err
+	}
+	if responseOk != nil {
+		return responseOk.Payload.ID, nil
+	}
+	return responseAccepted.Payload.ID, nil
+}
+
+// InstallCertificate starts the installation of the certificate generated for a resource and
+// returns the ID of the task.
+func InstallCertificate(ctx context.Context, domainName string, resource *models.Resource,
+	apiClient *client.VcfClient) (string, error) {
+	operationType := "INSTALL"
+	replaceCertificatesParams := certificates_api.NewReplaceCertificatesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDomainName(domainName).
+		WithCertificateOperationSpec(&models.CertificateOperationSpec{
+			OperationType: &operationType,
+			Resources:     []*models.Resource{resource},
+		})
+
+	responseOk, responseAccepted, err := apiClient.Certificates.ReplaceCertificates(replaceCertificatesParams)
+	if err != nil {
+		return "", err
+	}
+	if responseOk != nil {
+		return responseOk.Payload.ID, nil
+	}
+	return responseAccepted.Payload.ID, nil
+}
+
+// InstallExternalCertificate starts the installation of an externally signed certificate on a
+// resource and returns the ID of the task.
+func InstallExternalCertificate(ctx context.Context, domainId string, resourceCertificateSpec *models.ResourceCertificateSpec,
+	apiClient *client.VcfClient) (string, error) {
+	replaceResourceCertificatesParams := certificates_api.NewReplaceResourceCertificatesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(domainId).
+		WithResourceCertificateSpecs([]*models.ResourceCertificateSpec{resourceCertificateSpec})
+
+	response, err := apiClient.Certificates.ReplaceResourceCertificates(replaceResourceCertificatesParams)
+	if err != nil {
+		return "", err
+	}
+	return response.Payload.ID, nil
+}
+
+// GetResourceCertificate returns the certificate installed on the resource with the FQDN resourceFqdn,
+// or nil if the domain has no such certificate.
+func GetResourceCertificate(ctx context.Context, domainName, resourceFqdn string, apiClient *client.VcfClient) (*models.Certificate, error) {
+	getCertificatesParams := certificates_api.NewGetCertificatesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDomainName(domainName)
+
+	certificatesResponse, err := apiClient.Certificates.GetCertificates(getCertificatesParams)
+	if err != nil {
+		return nil, err
+	}
+	for _, certificate := range certificatesResponse.Payload.Elements {
+		if certificate != nil && certificate.IssuedTo != nil && strings.EqualFold(*certificate.IssuedTo, resourceFqdn) {
+			return certificate, nil
+		}
+	}
+	return nil, nil
+}
+
+// GetResourceCsr returns the PEM encoded CSR last generated for the resource with the FQDN resourceFqdn.
+func GetResourceCsr(ctx context.Context, domainName, resourceFqdn string, apiClient *client.VcfClient) (string, error) {
+	getCsrsParams := certificates_api.NewGetCSRsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDomainName(domainName)
+
+	csrsResponse, err := apiClient.Certificates.GetCSRs(getCsrsParams)
+	if err != nil {
+		return "", err
+	}
+	for _, csr := range csrsResponse.Payload.Elements {
+		if csr == nil || csr.Resource == nil || csr.CSREncodedContent == nil {
+			continue
+		}
+		if strings.EqualFold(csr.Resource.Fqdn, resourceFqdn) {
+			return *csr.CSREncodedContent, nil
+		}
+	}
+	return "", fmt.Errorf("no CSR found for %s in domain %s", resourceFqdn, domainName)
+}
@@ -0,0 +1,48 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package certificates
+
+import (
+	"context"
+	"fmt"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/sddc_managers"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// SddcManagerResourceType is the resource type of SDDC Manager in certificate operations.
+const SddcManagerResourceType = "SDDC_MANAGER"
+
+// GetSddcManagerCertificateSans returns the subject alternative names of the certificate of an SDDC Manager.
+// Unless overridden by sans, these are the FQDN and the IP address of the appliance.
+func GetSddcManagerCertificateSans(ctx context.Context, sddcManagerId string, sans []string, apiClient *client.VcfClient) ([]string, error) {
+	if len(sans) > 0 {
+		return sans, nil
+	}
+	getSddcManagerParams := sddc_managers.NewGetSDDCManagerParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(sddcManagerId)
+
+	sddcManagerResponse, err := apiClient.SDDCManagers.GetSDDCManager(getSddcManagerParams)
+	if err != nil {
+		return nil, err
+	}
+	return getSddcManagerSans(sddcManagerResponse.Payload)
+}
+
+func getSddcManagerSans(sddcManager *models.SDDCManager) ([]string, error) {
+	var result []string
+	if sddcManager.Fqdn != "" {
+		result = append(result, sddcManager.Fqdn)
+	}
+	if sddcManager.IPAddress != "" {
+		result = append(result, sddcManager.IPAddress)
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("SDDC Manager %s has neither an FQDN nor an IP address to use as SANs", sddcManager.ID)
+	}
+	return result, nil
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/client/hosts"
 	"github.com/vmware/vcf-sdk-go/models"
 	"sort"
+	"strings"
 )
 
 func CreateClusterUpdateSpec(data *schema.ResourceData, markForDeletion bool) (*models.ClusterUpdateSpec, error) {
@@ -33,31 +34,70 @@ func CreateClusterUpdateSpec(data *schema.ResourceData, markForDeletion bool) (*
 		result.Name = data.Get("name").(string)
 	}
 
-	// TODO support vSAN stretch/unstretch operations by adding a "witness" attribute to vcf_cluster and checking for change on it.
+	// The preferred site of a stretched cluster cannot be set, ClusterStretchSpec in the VCF API has no such
+	// property and VCF makes the availability zone of the existing hosts the preferred fault domain.
+	if data.HasChange("stretch") {
+		if data.HasChange("host") {
+			return nil, fmt.Errorf("stretching or unstretching the cluster and adding or removing hosts is not supported in a single configuration change. Apply each change separately")
+		}
+		oldStretchValue, newStretchValue := data.GetChange("stretch")
+		if len(newStretchValue.([]interface{})) == 0 {
+			result.ClusterUnstretchSpec = CreateClusterUnstretchSpec()
+			return result, nil
+		}
+		if len(oldStretchValue.([]interface{})) > 0 {
+			return nil, fmt.Errorf("the stretch configuration of a stretched cluster cannot be changed. Remove the stretch block to unstretch the cluster first")
+		}
+		clusterStretchSpec, err := CreateClusterStretchSpec(data)
+		if err != nil {
+			return nil, err
+		}
+		result.ClusterStretchSpec = clusterStretchSpec
+		return result, nil
+	}
 	if data.HasChange("host") {
 		oldHostsValue, newHostsValue := data.GetChange("host")
-		resultUpdated, err := SetExpansionOrContractionSpec(result,
-			oldHostsValue.([]interface{}), newHostsValue.([]interface{}))
+		resultUpdated, err := SetExpansionOrContractionSpec(result, oldHostsValue.([]interface{}),
+			newHostsValue.([]interface{}), data.Get("vds").([]interface{}))
 		if err != nil {
 			return nil, err
 		}
+		if err = SetVsanNetworkSpecs(resultUpdated, data.Get("vsan_network").([]interface{})); err != nil {
+			return nil, err
+		}
 		return resultUpdated, nil
 	}
 
 	return result, nil
 }
 
+// CreateClusterStretchSpec converts the stretch block of a cluster to a ClusterStretchSpec.
+func CreateClusterStretchSpec(data *schema.ResourceData) (*models.ClusterStretchSpec, error) {
+	stretchList := data.Get("stretch").([]interface{})
+	if len(stretchList) == 0 || stretchList[0] == nil {
+		return nil, fmt.Errorf("cannot convert to ClusterStretchSpec, stretch is empty")
+	}
+	return TryConvertToClusterStretchSpec(stretchList[0].(map[string]interface{}), len(data.Get("host").([]interface{})))
+}
+
+// CreateClusterUnstretchSpec returns the ClusterUnstretchSpec that converts a stretched cluster
+// back to a standard vSAN cluster, it has no parameters.
+func CreateClusterUnstretchSpec() models.ClusterUnstretchSpec {
+	return map[string]interface{}{}
+}
+
 // SetExpansionOrContractionSpec sets ClusterExpansionSpec or ClusterContractionSpec to a provided
-// ClusterUpdateSpec depending on weather hosts are being added or removed.
+// ClusterUpdateSpec depending on weather hosts are being added or removed. The hosts are matched by their IDs,
+// so reordering the hosts or changing the configuration of hosts already in the cluster leaves the spec unchanged.
+// The vmnics of added hosts have to reference the VDS of the cluster in vdsList.
 func SetExpansionOrContractionSpec(updateSpec *models.ClusterUpdateSpec,
-	oldHostsList, newHostsList []interface{}) (*models.ClusterUpdateSpec, error) {
+	oldHostsList, newHostsList, vdsList []interface{}) (*models.ClusterUpdateSpec, error) {
 
-	if len(newHostsList) == len(oldHostsList) {
+	addedHosts, removedHosts := CalculateHostDelta(oldHostsList, newHostsList)
+	if len(addedHosts) > 0 && len(removedHosts) > 0 {
 		return nil, fmt.Errorf("adding and removing hosts is not supported in a single configuration change. Apply each change separately")
 	}
-
-	addedHosts, removedHosts := resource_utils.CalculateAddedRemovedResources(newHostsList, oldHostsList)
-	if len(removedHosts) == 0 {
+	if len(addedHosts) > 0 {
 		var hostSpecs []*models.HostSpec
 		for _, addedHostRaw := range addedHosts {
 			hostSpec, err := TryConvertToHostSpec(addedHostRaw)
@@ -66,12 +106,23 @@ func SetExpansionOrContractionSpec(updateSpec *models.ClusterUpdateSpec,
 			}
 			hostSpecs = append(hostSpecs, hostSpec)
 		}
+		var vdsSpecs []*models.VdsSpec
+		for _, vdsRaw := range vdsList {
+			vdsSpec, err := network.TryConvertToVdsSpec(vdsRaw.(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			vdsSpecs = append(vdsSpecs, vdsSpec)
+		}
+		if err := CheckVmNicVdsMapping(vdsSpecs, hostSpecs); err != nil {
+			return nil, err
+		}
 		clusterExpansionSpec := &models.ClusterExpansionSpec{
 			HostSpecs: hostSpecs,
 		}
 		updateSpec.ClusterExpansionSpec = clusterExpansionSpec
-		return updateSpec, nil
-	} else {
+	}
+	if len(removedHosts) > 0 {
 		var hostRefs []*models.HostReference
 		for _, removedHostRaw := range removedHosts {
 			hostRef := &models.HostReference{
@@ -83,8 +134,92 @@ func SetExpansionOrContractionSpec(updateSpec *models.ClusterUpdateSpec,
 			Hosts: hostRefs,
 		}
 		updateSpec.ClusterCompactionSpec = clusterContractionSpec
-		return updateSpec, nil
 	}
+	return updateSpec, nil
+}
+
+// CalculateHostDelta returns the hosts added to and removed from a cluster, matched by their IDs
+// regardless of their order in the old and new host lists.
+func CalculateHostDelta(oldHostsList, newHostsList []interface{}) (addedHosts, removedHosts []map[string]interface{}) {
+	oldHosts := resource_utils.CreateIdToObjectMap(oldHostsList)
+	newHosts := resource_utils.CreateIdToObjectMap(newHostsList)
+	for _, newHostRaw := range newHostsList {
+		newHost := newHostRaw.(map[string]interface{})
+		if _, ok := oldHosts[newHost["id"].(string)]; !ok {
+			addedHosts = append(addedHosts, newHost)
+		}
+	}
+	for _, oldHostRaw := range oldHostsList {
+		oldHost := oldHostRaw.(map[string]interface{})
+		if _, ok := newHosts[oldHost["id"].(string)]; !ok {
+			removedHosts = append(removedHosts, oldHost)
+		}
+	}
+	return addedHosts, removedHosts
+}
+
+// SetVsanNetworkSpecs sets the vSAN network with a dedicated gateway to the ClusterExpansionSpec
+// of a provided ClusterUpdateSpec, if hosts are being added. Otherwise, the gateway of the network pool is used.
+func SetVsanNetworkSpecs(updateSpec *models.ClusterUpdateSpec, vsanNetworkList []interface{}) error {
+	if updateSpec.ClusterExpansionSpec == nil || len(vsanNetworkList) == 0 {
+		return nil
+	}
+	for _, vsanNetworkRaw := range vsanNetworkList {
+		vsanNetworkSpec, err := network.TryConvertToVsanNetworkSpec(vsanNetworkRaw.(map[string]interface{}))
+		if err != nil {
+			return err
+		}
+		updateSpec.ClusterExpansionSpec.VSANNetworkSpecs = append(updateSpec.ClusterExpansionSpec.VSANNetworkSpecs, vsanNetworkSpec)
+	}
+	return nil
+}
+
+// ValidateStretchedClusterExpansion verifies that the hosts added to a stretched cluster are balanced across
+// both availability zones, and so is the whole cluster if every host declares its availability zone.
+func ValidateStretchedClusterExpansion(oldHostsList, newHostsList []interface{}) error {
+	addedHosts, _ := CalculateHostDelta(oldHostsList, newHostsList)
+	if len(addedHosts) == 0 {
+		return nil
+	}
+	addedHostsPerAz := make(map[string]int)
+	for _, addedHost := range addedHosts {
+		availabilityZoneName, _ := addedHost["availability_zone_name"].(string)
+		if len(availabilityZoneName) == 0 {
+			return fmt.Errorf("availability_zone_name is required for host %q added to a stretched cluster", addedHost["id"])
+		}
+		addedHostsPerAz[availabilityZoneName]++
+	}
+	if err := validateHostsBalancedAcrossAzs(addedHostsPerAz); err != nil {
+		return fmt.Errorf("hosts added to a stretched cluster have to be added in pairs, one per availability zone: %w", err)
+	}
+
+	// the layout of the whole cluster can be validated only if every host declares its availability zone
+	hostsPerAz := make(map[string]int)
+	for _, hostRaw := range newHostsList {
+		availabilityZoneName, _ := hostRaw.(map[string]interface{})["availability_zone_name"].(string)
+		if len(availabilityZoneName) == 0 {
+			return nil
+		}
+		hostsPerAz[availabilityZoneName]++
+	}
+	if err := validateHostsBalancedAcrossAzs(hostsPerAz); err != nil {
+		return fmt.Errorf("the expansion would leave the stretched cluster unbalanced: %w", err)
+	}
+	return nil
+}
+
+func validateHostsBalancedAcrossAzs(hostsPerAz map[string]int) error {
+	if len(hostsPerAz) != 2 {
+		return fmt.Errorf("expected hosts in 2 availability zones, got %d", len(hostsPerAz))
+	}
+	var hostCounts []int
+	for _, hostCount := range hostsPerAz {
+		hostCounts = append(hostCounts, hostCount)
+	}
+	if hostCounts[0] != hostCounts[1] {
+		return fmt.Errorf("availability zones have %d and %d hosts", hostCounts[0], hostCounts[1])
+	}
+	return nil
 }
 
 func ValidateClusterUpdateOperation(ctx context.Context, clusterId string,
@@ -124,6 +259,15 @@ func TryConvertResourceDataToClusterSpec(data *schema.ResourceData) (*models.Clu
 
 // TODO implement support for VxRailDetails.
 
+// TODO support a default VM storage policy for the cluster. Neither ClusterSpec nor any other
+// VCF API exposes storage policies, and the provider has no vcf_storage_policy resource to reference.
+
+// TODO support vSphere DRS overrides for infrastructure VMs. ClusterSpec in the VCF API has no DRS
+// settings and SDDC Manager does not proxy the cluster configuration of vCenter.
+
+// TODO support a cluster_policy block with the default vSAN maintenance mode and the HA restart
+// priority of the cluster. AdvancedOptions in the VCF API only cover the EVC mode and enabling HA.
+
 // TryConvertToClusterSpec is a convenience method that converts a map[string]interface{}
 // received from the Terraform SDK to an API struct, used in VCF API calls.
 func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec, error) {
@@ -145,6 +289,8 @@ func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec
 		}
 		result.AdvancedOptions.EvcMode = evcMode.(string)
 	}
+	// TODO support the vSphere HA datastore heartbeating policy. HighAvailability in the VCF API
+	// only has the "enabled" flag, heartbeat datastores have to be configured in vCenter.
 	if highAvailabilityEnabled, ok := object["high_availability_enabled"]; ok && !validationUtils.IsEmpty(highAvailabilityEnabled) {
 		if result.AdvancedOptions == nil {
 			result.AdvancedOptions = &models.AdvancedOptions{}
@@ -208,6 +354,9 @@ func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec
 	} else {
 		return nil, fmt.Errorf("cannot convert to ClusterSpec, vds list is not set")
 	}
+	if err := CheckVmNicVdsMapping(result.NetworkSpec.VdsSpecs, result.HostSpecs); err != nil {
+		return nil, err
+	}
 
 	datastoreSpec, err := tryConvertToClusterDatastoreSpec(object, name)
 	if err != nil {
@@ -219,6 +368,58 @@ func TryConvertToClusterSpec(object map[string]interface{}) (*models.ClusterSpec
 	return result, nil
 }
 
+// CheckVmNicVdsMapping verifies that the vmnics of the hosts reference the VDS of the cluster by name,
+// which is required once the cluster has more than one VDS, and that no uplink of a VDS is assigned
+// to more than one vmnic of a host.
+func CheckVmNicVdsMapping(vdsSpecs []*models.VdsSpec, hostSpecs []*models.HostSpec) error {
+	vdsNames := make(map[string]bool)
+	for _, vdsSpec := range vdsSpecs {
+		if vdsSpec == nil || vdsSpec.Name == nil {
+			continue
+		}
+		if vdsNames[*vdsSpec.Name] {
+			return fmt.Errorf("duplicate vds name %q", *vdsSpec.Name)
+		}
+		vdsNames[*vdsSpec.Name] = true
+	}
+	for _, hostSpec := range hostSpecs {
+		if hostSpec == nil || hostSpec.HostNetworkSpec == nil {
+			continue
+		}
+		hostId := ""
+		if hostSpec.ID != nil {
+			hostId = *hostSpec.ID
+		}
+		vdsUplinks := make(map[string]string)
+		for _, vmNic := range hostSpec.HostNetworkSpec.VMNics {
+			if vmNic == nil {
+				continue
+			}
+			if len(vmNic.VdsName) == 0 {
+				if len(vdsNames) > 1 {
+					return fmt.Errorf("vds_name is required for vmnic %q of host %q, the cluster has more than one vds",
+						vmNic.ID, hostId)
+				}
+				continue
+			}
+			if !vdsNames[vmNic.VdsName] {
+				return fmt.Errorf("vmnic %q of host %q references vds %q, which is not a vds of the cluster",
+					vmNic.ID, hostId, vmNic.VdsName)
+			}
+			if len(vmNic.Uplink) == 0 {
+				continue
+			}
+			vdsUplink := vmNic.VdsName + "/" + vmNic.Uplink
+			if otherVmNic, ok := vdsUplinks[vdsUplink]; ok {
+				return fmt.Errorf("uplink %q of vds %q is assigned to both vmnic %q and vmnic %q of host %q",
+					vmNic.Uplink, vmNic.VdsName, otherVmNic, vmNic.ID, hostId)
+			}
+			vdsUplinks[vdsUplink] = vmNic.ID
+		}
+	}
+	return nil
+}
+
 func tryConvertToClusterDatastoreSpec(object map[string]interface{}, clusterName string) (*models.DatastoreSpec, error) {
 	result := &models.DatastoreSpec{}
 	atLeastOneTypeOfDatastoreConfigured := false
@@ -256,6 +457,13 @@ func tryConvertToClusterDatastoreSpec(object map[string]interface{}, clusterName
 		if err != nil {
 			return nil, err
 		}
+		// a cluster mounting remote vSAN datastores is an HCI Mesh compute client without a local vSAN datastore
+		if result.VSANDatastoreSpec != nil {
+			return nil, fmt.Errorf("vsan_remote_datastore_cluster cannot be combined with vsan_datastore for cluster %q", clusterName)
+		}
+		// TODO validate at plan time that the cluster and the clusters of the remote datastores are compatible.
+		// The VCF API only validates HCI Mesh mounts on existing clusters, so the clusters of a domain or a new
+		// cluster are only checked by the validation of the domain or cluster creation spec before the creation.
 		atLeastOneTypeOfDatastoreConfigured = true
 		result.VSANRemoteDatastoreClusterSpec = vsanRemoteDatastoreClusterSpec
 	}
@@ -296,7 +504,10 @@ func tryConvertToClusterDatastoreSpec(object map[string]interface{}, clusterName
 	return result, nil
 }
 
-func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, apiClient *client.VcfClient) (*map[string]interface{}, error) {
+// FlattenCluster reads the configuration of a cluster that the VCF API returns: its hosts, vSphere
+// Distributed Switches and datastores, next to its Workload Management readiness.
+func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, readiness *WorkloadManagementReadiness,
+	apiClient *client.VcfClient) (*map[string]interface{}, error) {
 	result := make(map[string]interface{})
 	if clusterObj == nil {
 		return &result, nil
@@ -309,8 +520,19 @@ func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, apiClient *
 	result["is_default"] = clusterObj.IsDefault
 	result["is_stretched"] = clusterObj.IsStretched
 
-	flattenedVdsSpecs := getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs)
-	result["vds"] = flattenedVdsSpecs
+	// the VDS specs of the cluster lack the portgroups and the NIOC settings, unlike its VDS inventory
+	getVdsesParams := clusters.NewGetVdsesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithClusterID(clusterObj.ID)
+	vdsesResult, err := apiClient.Clusters.GetVdses(getVdsesParams)
+	if err != nil {
+		return nil, err
+	}
+	if len(vdsesResult.Payload) > 0 {
+		result["vds"] = getFlattenedVdses(vdsesResult.Payload)
+	} else {
+		result["vds"] = getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs)
+	}
 
 	flattenedHostSpecs, err := getFlattenedHostSpecsForRefs(ctx, clusterObj.Hosts, apiClient)
 	if err != nil {
@@ -318,9 +540,31 @@ func FlattenCluster(ctx context.Context, clusterObj *models.Cluster, apiClient *
 	}
 	result["host"] = flattenedHostSpecs
 
+	getClusterDatastoresParams := clusters.NewGetClusterDatastoresParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithClusterID(clusterObj.ID)
+	clusterDatastoresResult, err := apiClient.Clusters.GetClusterDatastores(getClusterDatastoresParams)
+	if err != nil {
+		return nil, err
+	}
+	// the datastores of the other types are read as absent
+	for _, attribute := range []string{"vsan_datastore", "vmfs_datastore", "vsan_remote_datastore_cluster"} {
+		result[attribute] = []map[string]interface{}{}
+	}
+	for attribute, value := range FlattenClusterDatastores(clusterObj.PrimaryDatastoreName,
+		clusterObj.PrimaryDatastoreType, clusterDatastoresResult.Payload) {
+		result[attribute] = value
+	}
+
+	if readiness != nil {
+		readiness.Set(ctx, result, clusterObj)
+	}
+
 	return &result, nil
 }
 
+// ImportCluster reads a cluster into the state of the cluster resource. The attributes the VCF API
+// does not return stay empty, see UnreadAttributes.
 func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient,
 	clusterId string) ([]*schema.ResourceData, error) {
 	getClusterParams := clusters.NewGetClusterParamsWithContext(ctx).
@@ -332,20 +576,16 @@ func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *cl
 	}
 	clusterObj := clusterResult.Payload
 
-	data.SetId(clusterObj.ID)
-	_ = data.Set("name", clusterObj.Name)
-	_ = data.Set("primary_datastore_name", clusterObj.PrimaryDatastoreName)
-	_ = data.Set("primary_datastore_type", clusterObj.PrimaryDatastoreType)
-	_ = data.Set("is_default", clusterObj.IsDefault)
-	_ = data.Set("is_stretched", clusterObj.IsStretched)
-	flattenedVdsSpecs := getFlattenedVdsSpecsForRefs(clusterObj.VdsSpecs)
-	_ = data.Set("vds", flattenedVdsSpecs)
-
-	flattenedHostSpecs, err := getFlattenedHostSpecsForRefs(ctx, clusterObj.Hosts, apiClient)
+	flattenedCluster, err := FlattenCluster(ctx, clusterObj, nil, apiClient)
 	if err != nil {
 		return nil, err
 	}
-	_ = data.Set("host", flattenedHostSpecs)
+	data.SetId(clusterObj.ID)
+	for attribute, value := range *flattenedCluster {
+		if attribute != "id" {
+			_ = data.Set(attribute, value)
+		}
+	}
 
 	//get all domains and find our cluster to set the "domain_id" attribute, because
 	// cluster API doesn't provide parent domain ID.
@@ -367,6 +607,33 @@ func ImportCluster(ctx context.Context, data *schema.ResourceData, apiClient *cl
 	return []*schema.ResourceData{data}, nil
 }
 
+// UnreadAttributes returns the paths of the attributes of an imported cluster that the VCF API does not
+// return, prefixed with the path of the cluster: the cluster image, EVC mode, vSphere HA, Geneve VLAN,
+// IP address pool, NFS and vVol datastores, the vSAN failures to tolerate, license key and deduplication
+// and the portgroups and NIOC allocations of VDSes that are only known from the VDS specs of the cluster.
+// The resources take them from the configuration on the next apply instead of rejecting their change.
+func UnreadAttributes(data *schema.ResourceData, prefix string) []string {
+	var result []string
+	for _, attribute := range []string{"cluster_image_id", "evc_mode", "high_availability_enabled",
+		"geneve_vlan_id", "ip_address_pool", "nfs_datastores", "vvol_datastores"} {
+		result = append(result, prefix+attribute)
+	}
+	if vsanDatastores := data.Get(prefix + "vsan_datastore").([]interface{}); len(vsanDatastores) > 0 {
+		for _, attribute := range []string{"license_key", "failures_to_tolerate", "dedup_and_compression_enabled"} {
+			result = append(result, prefix+"vsan_datastore.0."+attribute)
+		}
+	}
+	for i, vdsRaw := range data.Get(prefix + "vds").([]interface{}) {
+		vds, _ := vdsRaw.(map[string]interface{})
+		for _, attribute := range []string{"portgroup", "nioc_bandwidth_allocations"} {
+			if blocks, _ := vds[attribute].([]interface{}); len(blocks) == 0 {
+				result = append(result, fmt.Sprintf("%svds.%d.%s", prefix, i, attribute))
+			}
+		}
+	}
+	return result
+}
+
 // getFlattenedHostSpecsForRefs The HostRef is supposed to have all the relevant information,
 // but the backend returns everything as nil except the host ID which forces us to make a separate request
 // to get some useful info about the hosts in the cluster.
@@ -386,7 +653,12 @@ func getFlattenedHostSpecsForRefs(ctx context.Context, hostRefs []*models.HostRe
 			return nil, err
 		}
 		hostObj := getHostResult.Payload
-		flattenedHostSpecs = append(flattenedHostSpecs, *FlattenHost(hostObj))
+		flattenedHostSpec := *FlattenHost(hostObj)
+		// the availability zone is needed to expand a stretched cluster
+		if len(hostRef.AzName) > 0 {
+			flattenedHostSpec["availability_zone_name"] = hostRef.AzName
+		}
+		flattenedHostSpecs = append(flattenedHostSpecs, flattenedHostSpec)
 	}
 	return flattenedHostSpecs, nil
 }
@@ -403,3 +675,49 @@ func getFlattenedVdsSpecsForRefs(vdsSpecs []*models.VdsSpec) []map[string]interf
 	}
 	return flattenedVdsSpecs
 }
+
+func getFlattenedVdses(vdses []*models.Vds) []map[string]interface{} {
+	flattenedVdses := *new([]map[string]interface{})
+	for _, vds := range vdses {
+		if vds != nil && vds.Name != nil {
+			flattenedVdses = append(flattenedVdses, network.FlattenVds(vds))
+		}
+	}
+	// Sort for reproducibility
+	sort.SliceStable(flattenedVdses, func(i, j int) bool {
+		return flattenedVdses[i]["name"].(string) < flattenedVdses[j]["name"].(string)
+	})
+	return flattenedVdses
+}
+
+// FlattenClusterDatastores reconstructs the datastore configuration of the cluster from its primary
+// datastore and the datastores it mounts, keyed by the attribute of the resource it belongs to.
+// TODO reconstruct NFS and vVol datastores on import. The datastore inventory of the VCF API lacks
+// the NFS server and path and the VASA provider details these datastores are configured with.
+func FlattenClusterDatastores(primaryDatastoreName, primaryDatastoreType string,
+	clusterDatastores []*models.Datastore) map[string]interface{} {
+	result := make(map[string]interface{})
+	switch primaryDatastoreType {
+	case "VSAN":
+		result["vsan_datastore"] = []map[string]interface{}{{"datastore_name": primaryDatastoreName}}
+	case "VMFS_FC":
+		datastoreNames := []string{primaryDatastoreName}
+		for _, datastore := range clusterDatastores {
+			if datastore != nil && strings.HasPrefix(datastore.DatastoreType, "VMFS") && datastore.Name != primaryDatastoreName {
+				datastoreNames = append(datastoreNames, datastore.Name)
+			}
+		}
+		result["vmfs_datastore"] = []map[string]interface{}{{"datastore_names": datastoreNames}}
+	case "VSAN_REMOTE":
+		var datastoreUuids []string
+		for _, datastore := range clusterDatastores {
+			if datastore != nil && strings.HasPrefix(datastore.DatastoreType, "VSAN") && len(datastore.ID) > 0 {
+				datastoreUuids = append(datastoreUuids, datastore.ID)
+			}
+		}
+		if len(datastoreUuids) > 0 {
+			result["vsan_remote_datastore_cluster"] = []map[string]interface{}{{"datastore_uuids": datastoreUuids}}
+		}
+	}
+	return result
+}
@@ -16,6 +16,8 @@ import (
 
 // HostSpecSchema this helper function extracts the Host
 // schema, so that it's made available for both workload domain and cluster creation.
+// TODO support designating the seed host of the vSAN cluster. HostSpec and ClusterSpec in the VCF API
+// have no seed host setting, VCF selects the host that bootstraps vSAN itself.
 func HostSpecSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -25,22 +27,27 @@ func HostSpecSchema() *schema.Resource {
 				Description: "ID of the ESXi host in the free pool",
 			},
 			"host_name": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "Host name of the ESXi host",
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Host name of the ESXi host",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationutils.SuppressFqdnDiff,
 			},
 			"availability_zone_name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Availability Zone Name. This is required while performing a stretched cluster expand operation",
+				Computed:     true,
+				Description:  "Availability Zone Name. This is required while performing a stretched cluster expand operation. Hosts are added to a stretched cluster in pairs, one per availability zone",
 				ValidateFunc: validation.NoZeroValues,
 			},
 			"ip_address": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "IPv4 address of the ESXi host",
-				ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "IPv4 address of the ESXi host",
+				ValidateFunc:     validationutils.ValidateIPv4AddressSchema,
+				DiffSuppressFunc: validationutils.SuppressIpAddressDiff,
 			},
 			"license_key": {
 				Type:      schema.TypeString,
@@ -48,7 +55,8 @@ func HostSpecSchema() *schema.Resource {
 				Sensitive: true,
 				Description: "License key for an ESXi host in the free pool. This is required except in cases where the " +
 					"ESXi host has already been licensed outside of the VMware Cloud Foundation system",
-				ValidateFunc: validation.NoZeroValues,
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationutils.SuppressLicenseKeyDiff,
 			},
 			"username": {
 				Type:         schema.TypeString,
@@ -113,6 +121,10 @@ func FlattenHost(host *models.Host) *map[string]interface{} {
 	return &result
 }
 
+// TODO support scratch and swap datastore placement for hosts. Neither HostSpec nor ClusterSpec
+// in the VCF API expose these settings, they have to be configured in vSphere directly.
+// TODO validate the BIOS power policy and hyperthreading state of the hosts before cluster creation.
+// The host inventory of the VCF API reports only the CPU cores, frequency and model, not these settings.
 func TryConvertToHostSpec(object map[string]interface{}) (*models.HostSpec, error) {
 	result := &models.HostSpec{}
 	if object == nil {
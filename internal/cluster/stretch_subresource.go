@@ -0,0 +1,144 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package cluster
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/network"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// StretchSchema this helper function extracts the ClusterStretchSpec schema, which
+// stretches a vSAN cluster across two availability zones.
+// TODO support a dedicated VLAN for the witness traffic. ClusterStretchSpec in the VCF API only allows
+// sharing the witness traffic with the vSAN traffic, the witness traffic VMkernel adapter has to be configured in vSphere.
+func StretchSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"witness_host": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "vSAN witness host of the stretched cluster",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fqdn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Fully qualified domain name of the witness host",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"vsan_ip": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "IP address of the vSAN VMkernel adapter of the witness host",
+							ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+						},
+						"vsan_cidr": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "CIDR of the vSAN subnet of the witness host, e.g. 172.18.96.0/24",
+							ValidateFunc: validation.IsCIDR,
+						},
+					},
+				},
+			},
+			"secondary_az_host": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "ESXi hosts from the free pool added to the secondary availability zone. As many as the hosts of the cluster, all with the same availability_zone_name",
+				Elem:        HostSpecSchema(),
+			},
+			"secondary_az_overlay_vlan_id": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "VLAN ID of the host overlay (Geneve) network of the secondary availability zone",
+				ValidateFunc: validation.IntBetween(0, 4094),
+			},
+			"witness_traffic_shared_with_vsan_traffic": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the witness traffic is shared with the vSAN traffic, default true",
+			},
+			"is_edge_cluster_configured_for_multi_az": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the NSX Edge cluster of the cluster is configured for both availability zones, default false",
+			},
+			"vsan_network": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "vSAN networks with a dedicated gateway for the hosts of the secondary availability zone",
+				Elem:        network.VsanNetworkSchema(),
+			},
+		},
+	}
+}
+
+// TryConvertToClusterStretchSpec converts a stretch block to a ClusterStretchSpec. The hosts of the
+// secondary availability zone have to be as many as the clusterHostCount hosts of the cluster.
+func TryConvertToClusterStretchSpec(object map[string]interface{}, clusterHostCount int) (*models.ClusterStretchSpec, error) {
+	if object == nil {
+		return nil, fmt.Errorf("cannot convert to ClusterStretchSpec, object is nil")
+	}
+	result := &models.ClusterStretchSpec{
+		WitnessTrafficSharedWithVSANTraffic: object["witness_traffic_shared_with_vsan_traffic"].(bool),
+		IsEdgeClusterConfiguredForMultiAZ:   object["is_edge_cluster_configured_for_multi_az"].(bool),
+	}
+	secondaryAzOverlayVlanId := int32(object["secondary_az_overlay_vlan_id"].(int))
+	result.SecondaryAzOverlayVlanID = &secondaryAzOverlayVlanId
+
+	witnessHostList := object["witness_host"].([]interface{})
+	if len(witnessHostList) == 0 || witnessHostList[0] == nil {
+		return nil, fmt.Errorf("cannot convert to ClusterStretchSpec, witness_host is required")
+	}
+	witnessHost := witnessHostList[0].(map[string]interface{})
+	fqdn := witnessHost["fqdn"].(string)
+	vsanIp := witnessHost["vsan_ip"].(string)
+	vsanCidr := witnessHost["vsan_cidr"].(string)
+	result.WitnessSpec = &models.WitnessSpec{
+		Fqdn:     &fqdn,
+		VSANIP:   &vsanIp,
+		VSANCidr: &vsanCidr,
+	}
+
+	secondaryAzName := ""
+	for _, hostRaw := range object["secondary_az_host"].([]interface{}) {
+		hostSpec, err := TryConvertToHostSpec(hostRaw.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		if len(hostSpec.AzName) == 0 {
+			return nil, fmt.Errorf("availability_zone_name is required for host %q of the secondary availability zone", *hostSpec.ID)
+		}
+		if len(secondaryAzName) > 0 && hostSpec.AzName != secondaryAzName {
+			return nil, fmt.Errorf("hosts of the secondary availability zone have to be in a single availability zone, got %q and %q",
+				secondaryAzName, hostSpec.AzName)
+		}
+		secondaryAzName = hostSpec.AzName
+		result.HostSpecs = append(result.HostSpecs, hostSpec)
+	}
+	if len(result.HostSpecs) != clusterHostCount {
+		return nil, fmt.Errorf("the secondary availability zone has to have as many hosts as the cluster, got %d and %d",
+			len(result.HostSpecs), clusterHostCount)
+	}
+
+	for _, vsanNetworkRaw := range object["vsan_network"].([]interface{}) {
+		vsanNetworkSpec, err := network.TryConvertToVsanNetworkSpec(vsanNetworkRaw.(map[string]interface{}))
+		if err != nil {
+			return nil, err
+		}
+		result.VSANNetworkSpecs = append(result.VSANNetworkSpecs, vsanNetworkSpec)
+	}
+
+	return result, nil
+}
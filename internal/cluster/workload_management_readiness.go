@@ -0,0 +1,103 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"github.com/vmware/vcf-sdk-go/client/nsxt_edge_clusters"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// minWorkloadManagementHosts is the minimum number of hosts of a cluster to enable Workload Management on.
+const minWorkloadManagementHosts = 3
+
+// WorkloadManagementReadiness checks clusters for the Workload Management (vSphere with Tanzu) prerequisites.
+// The WCP license keys, which are not specific to a cluster, are looked up once for all checked clusters.
+type WorkloadManagementReadiness struct {
+	apiClient     *client.VcfClient
+	hasWcpLicense *bool
+}
+
+func NewWorkloadManagementReadiness(apiClient *client.VcfClient) *WorkloadManagementReadiness {
+	return &WorkloadManagementReadiness{apiClient: apiClient}
+}
+
+// GetBlockers returns the Workload Management prerequisites that the cluster does not meet, an empty
+// result means the cluster is ready.
+// TODO check for a storage policy for the control plane VMs once the VCF API exposes storage policies.
+func (readiness *WorkloadManagementReadiness) GetBlockers(ctx context.Context, clusterObj *models.Cluster) ([]string, error) {
+	blockers := []string{}
+	if clusterObj == nil {
+		return blockers, nil
+	}
+
+	if len(clusterObj.Hosts) < minWorkloadManagementHosts {
+		blockers = append(blockers, fmt.Sprintf("the cluster has %d hosts, at least %d are required",
+			len(clusterObj.Hosts), minWorkloadManagementHosts))
+	}
+
+	getEdgeClustersParams := nsxt_edge_clusters.NewGetEdgeClustersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithClusterID(&clusterObj.ID)
+	edgeClustersResult, err := readiness.apiClient.NSXTEdgeClusters.GetEdgeClusters(getEdgeClustersParams)
+	if err != nil {
+		return nil, err
+	}
+	hasNsxEdgeCluster := false
+	for _, edgeCluster := range edgeClustersResult.Payload.Elements {
+		if edgeCluster != nil && edgeCluster.NSXTCluster != nil {
+			hasNsxEdgeCluster = true
+			break
+		}
+	}
+	if !hasNsxEdgeCluster {
+		blockers = append(blockers, "no NSX Edge cluster is deployed for the cluster")
+	}
+
+	hasWcpLicense, err := readiness.getHasWcpLicense(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !hasWcpLicense {
+		blockers = append(blockers, "no WCP license key is present in SDDC Manager")
+	}
+
+	return blockers, nil
+}
+
+func (readiness *WorkloadManagementReadiness) getHasWcpLicense(ctx context.Context) (bool, error) {
+	if readiness.hasWcpLicense != nil {
+		return *readiness.hasWcpLicense, nil
+	}
+	getLicenseKeysParams := license_keys.NewGetLicenseKeysParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getLicenseKeysParams.ProductType = []string{"WCP"}
+	licenseKeysResult, err := readiness.apiClient.LicenseKeys.GetLicenseKeys(getLicenseKeysParams)
+	if err != nil {
+		return false, err
+	}
+	hasWcpLicense := len(licenseKeysResult.Payload.Elements) > 0
+	readiness.hasWcpLicense = &hasWcpLicense
+	return hasWcpLicense, nil
+}
+
+// Set sets the Workload Management readiness attributes of the flattened cluster, or logs a warning
+// if the readiness cannot be checked.
+func (readiness *WorkloadManagementReadiness) Set(ctx context.Context, flattenedCluster map[string]interface{},
+	clusterObj *models.Cluster) {
+	blockers, err := readiness.GetBlockers(ctx, clusterObj)
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to check the Workload Management readiness of cluster %q: %s",
+			clusterObj.Name, err))
+		return
+	}
+	flattenedCluster["workload_management_ready"] = len(blockers) == 0
+	flattenedCluster["workload_management_blockers"] = blockers
+}
@@ -5,9 +5,21 @@ package constants
 
 import "time"
 
+// DefaultVcfApiCallTimeout the timeout of a single VCF API call, unless the api_timeout setting of the
+// provider overrides it for the API client.
+const DefaultVcfApiCallTimeout = 2 * time.Minute
+
 const (
-	DefaultVcfApiCallTimeout = 2 * time.Minute
+	// DomainCreationTaskType the type of the SDDC Manager tasks that create workload domains.
+	DomainCreationTaskType = "DOMAIN_CREATION"
+	// ClusterCreationTaskType the type of the SDDC Manager tasks that create clusters.
+	ClusterCreationTaskType = "CLUSTER_CREATION"
+	// ResumableCreationMaxAge the age up to which an interrupted or failed creation task is resumed,
+	// older tasks are not resumed.
+	ResumableCreationMaxAge = 24 * time.Hour
+)
 
+const (
 	// VcfTestUrl URL of a VCF instance, used for Acceptance tests.
 	VcfTestUrl = "VCF_TEST_URL"
 	// VcfTestUsername username of SSO user, used for Acceptance tests.
@@ -102,6 +114,10 @@ const (
 	// VcfTestVcenterLicenseKey license key for vCenter required for bringup acceptance tests.
 	VcfTestVcenterLicenseKey = "VCF_TEST_VCENTER_LICENSE_KEY"
 
+	// VcfTestUnregisteredLicenseKey ESXi license key, that has not been added to the SDDC Manager,
+	// used in vcf_license_key acceptance tests.
+	VcfTestUnregisteredLicenseKey = "VCF_TEST_UNREGISTERED_LICENSE_KEY"
+
 	// VcfTestDomainDataSourceId id of a workload domain used in workload domain data source acceptance test.
 	// Typically, the id of management domain is used as it is already created during bringup.
 	VcfTestDomainDataSourceId = "VCF_DOMAIN_DATA_SOURCE_ID"
@@ -122,6 +138,13 @@ const (
 
 	// VcfTestMsftCaSecret used in vcf_certificate_authority tests.
 	VcfTestMsftCaSecret = "VCF_TEST_MSFT_CA_SECRET"
+
+	// VcfTestRemediationBundleId id of an ESXi bundle used in vcf_cluster_remediation acceptance tests.
+	VcfTestRemediationBundleId = "VCF_TEST_REMEDIATION_BUNDLE_ID"
+
+	// VcfTestRemediationPersonalityId id of a personality used in vcf_cluster_remediation acceptance tests.
+	// The cluster identified by VCF_CLUSTER_DATA_SOURCE_ID is remediated against it.
+	VcfTestRemediationPersonalityId = "VCF_TEST_REMEDIATION_PERSONALITY_ID"
 )
 
 func GetIso3166CountryCodes() []string {
@@ -0,0 +1,51 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package credentials
+
+import (
+	"context"
+	"github.com/vmware/vcf-sdk-go/client"
+	credentials_api "github.com/vmware/vcf-sdk-go/client/credentials"
+	"github.com/vmware/vcf-sdk-go/models"
+)
+
+// GetCredential returns the credential with the given credential type and username among the credentials
+// SDDC Manager returns for the query parameters, or nil if SDDC Manager does not manage such a credential.
+func GetCredential(ctx context.Context, getCredentialsParams *credentials_api.GetCredentialsParams,
+	credentialType, username string, apiClient *client.VcfClient) (*models.Credential, error) {
+	getCredentialsResponse, err := apiClient.Credentials.GetCredentials(getCredentialsParams.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return FindCredential(getCredentialsResponse.Payload.Elements, credentialType, username), nil
+}
+
+// FindCredential returns the first credential in the list with the given credential type and username,
+// or nil if there is none. An empty credential type or username matches all credentials.
+func FindCredential(credentialList []*models.Credential, credentialType, username string) *models.Credential {
+	matchingCredentials := FilterCredentials(credentialList, credentialType, username)
+	if len(matchingCredentials) == 0 {
+		return nil
+	}
+	return matchingCredentials[0]
+}
+
+// FilterCredentials returns the credentials in the list with the given credential type and username.
+// An empty credential type or username matches all credentials. Credentials without a credential type
+// or username are skipped.
+func FilterCredentials(credentialList []*models.Credential, credentialType, username string) []*models.Credential {
+	var matchingCredentials []*models.Credential
+	for _, credential := range credentialList {
+		if credential == nil || credential.CredentialType == nil || credential.Username == nil {
+			continue
+		}
+		if (credentialType == "" || *credential.CredentialType == credentialType) &&
+			(username == "" || *credential.Username == username) {
+			matchingCredentials = append(matchingCredentials, credential)
+		}
+	}
+	return matchingCredentials
+}
@@ -0,0 +1,52 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package credentials
+
+import (
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestFindCredential(t *testing.T) {
+	newCredential := func(id, credentialType, username string) *models.Credential {
+		return &models.Credential{
+			ID:             resource_utils.ToStringPointer(id),
+			CredentialType: resource_utils.ToStringPointer(credentialType),
+			Username:       resource_utils.ToStringPointer(username),
+		}
+	}
+	credentialList := []*models.Credential{
+		nil,
+		{ID: resource_utils.ToStringPointer("incomplete"), CredentialType: resource_utils.ToStringPointer("SSO")},
+		newCredential("ssh-root", "SSH", "root"),
+		newCredential("sso-admin", "SSO", "administrator@vsphere.local"),
+		newCredential("api-admin", "API", "admin"),
+	}
+
+	var findTests = []struct {
+		credentialType string
+		username       string
+		expectedId     string
+	}{
+		{"SSO", "", "sso-admin"},
+		{"API", "admin", "api-admin"},
+		{"", "root", "ssh-root"},
+		{"SSH", "admin", ""},
+	}
+
+	for _, findTest := range findTests {
+		credential := FindCredential(credentialList, findTest.credentialType, findTest.username)
+		credentialId := ""
+		if credential != nil {
+			credentialId = *credential.ID
+		}
+		if credentialId != findTest.expectedId {
+			t.Errorf("failed. Expected %q for %q and %q, got %q", findTest.expectedId,
+				findTest.credentialType, findTest.username, credentialId)
+		}
+	}
+}
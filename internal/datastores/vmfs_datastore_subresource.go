@@ -6,6 +6,8 @@ package datastores
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	"github.com/vmware/vcf-sdk-go/models"
 )
 
@@ -17,8 +19,12 @@ func VmfsDatastoreSchema() *schema.Resource {
 			"datastore_names": {
 				Type:        schema.TypeList,
 				Required:    true,
+				MinItems:    1,
 				Description: "VMFS datastore names used for VMFS on FC for cluster creation",
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
 			},
 		},
 	}
@@ -28,15 +34,14 @@ func TryConvertToVmfsDatastoreSpec(object map[string]interface{}) (*models.VmfsD
 	if object == nil {
 		return nil, fmt.Errorf("cannot convert to VmfsDatastoreSpec, object is nil")
 	}
-	datastoreNames := object["datastore_names"].([]string)
+	datastoreNames := object["datastore_names"].([]interface{})
 	if len(datastoreNames) == 0 {
 		return nil, fmt.Errorf("cannot convert to VmfsDatastoreSpec, datastore_names is required")
 	}
 	result := &models.VmfsDatastoreSpec{}
 	result.FcSpec = []*models.FcSpec{}
 	for _, datastoreName := range datastoreNames {
-		datastoreNameRef := &datastoreName
-		result.FcSpec = append(result.FcSpec, &models.FcSpec{DatastoreName: datastoreNameRef})
+		result.FcSpec = append(result.FcSpec, &models.FcSpec{DatastoreName: resource_utils.ToStringPointer(datastoreName)})
 	}
 	return result, nil
 }
@@ -13,6 +13,8 @@ import (
 
 // VsanDatastoreSchema this helper function extracts the vSAN Datastore schema, so that
 // it's made available for both workload domain and cluster creation.
+// TODO support vSAN Express Storage Architecture (ESA) with an esa_enabled attribute, validated at diff
+// time against the VCF version of SDDC Manager. VSANDatastoreSpec in the VCF API has no ESA setting.
 func VsanDatastoreSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -23,11 +25,12 @@ func VsanDatastoreSchema() *schema.Resource {
 				ValidateFunc: validation.NoZeroValues,
 			},
 			"license_key": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Sensitive:    true,
-				Description:  "vSAN license key to be used",
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Optional:         true,
+				Sensitive:        true,
+				Description:      "vSAN license key to be used",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationutils.SuppressLicenseKeyDiff,
 			},
 			"failures_to_tolerate": {
 				Type:         schema.TypeInt,
@@ -38,12 +41,16 @@ func VsanDatastoreSchema() *schema.Resource {
 			"dedup_and_compression_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Enable vSAN deduplication and compression",
+				Description: "Enable vSAN deduplication and compression. Requires all-flash hosts",
 			},
 		},
 	}
 }
 
+// TODO support setting and reading the vSAN on-disk format version. VSANDatastoreSpec in the VCF API
+// has no such setting and the version is not returned by the cluster or datastore inventory.
+// TODO support TRIM/UNMAP and large scale cluster support. These are vSAN cluster advanced options
+// that neither VSANDatastoreSpec nor ClusterSpec in the VCF API can set.
 func TryConvertToVsanDatastoreSpec(object map[string]interface{}) (*models.VSANDatastoreSpec, error) {
 	if object == nil {
 		return nil, fmt.Errorf("cannot convert to VSANDatastoreSpec, object is nil")
@@ -8,6 +8,8 @@ package datastores
 import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	"github.com/vmware/vcf-sdk-go/models"
 )
 
@@ -19,8 +21,12 @@ func VsanRemoteDatastoreClusterSchema() *schema.Resource {
 			"datastore_uuids": {
 				Type:        schema.TypeList,
 				Required:    true,
-				Description: "vSAN HCI Mesh remote datastore UUIDs",
-				Elem:        &schema.Schema{Type: schema.TypeString},
+				MinItems:    1,
+				Description: "UUIDs of the vSAN datastores of other clusters that are mounted remotely",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
 			},
 		},
 	}
@@ -30,16 +36,20 @@ func TryConvertToVSANRemoteDatastoreClusterSpec(object map[string]interface{}) (
 	if object == nil {
 		return nil, fmt.Errorf("cannot convert to VSANRemoteDatastoreClusterSpec, object is nil")
 	}
-	datastoreUuids := object["datastore_uuids"].([]string)
+	datastoreUuids := object["datastore_uuids"].([]interface{})
 	if len(datastoreUuids) == 0 {
 		return nil, fmt.Errorf("cannot convert to VSANRemoteDatastoreClusterSpec, datastore_uuids is required")
 	}
 	result := &models.VSANRemoteDatastoreClusterSpec{}
 	result.VSANRemoteDatastoreSpec = []*models.VSANRemoteDatastoreSpec{}
+	seenDatastoreUuids := make(map[string]bool)
 	for _, datastoreUuid := range datastoreUuids {
-		datastoreUuidRef := &datastoreUuid
+		if seenDatastoreUuids[datastoreUuid.(string)] {
+			return nil, fmt.Errorf("cannot convert to VSANRemoteDatastoreClusterSpec, duplicate datastore UUID %q", datastoreUuid)
+		}
+		seenDatastoreUuids[datastoreUuid.(string)] = true
 		result.VSANRemoteDatastoreSpec = append(result.VSANRemoteDatastoreSpec,
-			&models.VSANRemoteDatastoreSpec{DatastoreUUID: datastoreUuidRef})
+			&models.VSANRemoteDatastoreSpec{DatastoreUUID: resource_utils.ToStringPointer(datastoreUuid)})
 	}
 	return result, nil
 }
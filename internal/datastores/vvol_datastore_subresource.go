@@ -73,7 +73,8 @@ func TryConvertToVvolDatastoreSpec(object map[string]interface{}) (*models.VvolD
 	}
 	result.VasaProviderSpec.StorageContainerID = &storageContainerId
 
-	storageContainerProtocolType := object["storage_protocol_type"].(string)
+	// the protocol type is accepted in any case, the VCF API only accepts it in upper case
+	storageContainerProtocolType := strings.ToUpper(object["storage_protocol_type"].(string))
 	if len(storageContainerProtocolType) == 0 {
 		return nil, fmt.Errorf("cannot convert to VvolDatastoreSpec, storage_protocol_type is required")
 	}
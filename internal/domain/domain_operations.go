@@ -7,20 +7,31 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/terraform-provider-vcf/internal/cluster"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
 	"github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/clusters"
+	credentials_api "github.com/vmware/vcf-sdk-go/client/credentials"
 	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/client/vcenters"
 	"github.com/vmware/vcf-sdk-go/models"
 	"sort"
 )
 
+// TODO support the ESXi certificates mode of workload domains. Unlike SDDCSpec for bring-up,
+// DomainCreationSpec in the VCF API has no SecuritySpec, hosts of workload domains use VMCA certificates.
+// TODO support the NTP and DNS servers of workload domains. Unlike SDDCSpec for bring-up, DomainCreationSpec
+// has no NTP or DNS settings, domains use the system-wide ones managed by vcf_ntp_configuration and vcf_dns_configuration.
 func CreateDomainCreationSpec(data *schema.ResourceData) (*models.DomainCreationSpec, error) {
 	result := new(models.DomainCreationSpec)
 	domainName := data.Get("name").(string)
@@ -54,6 +65,10 @@ func CreateDomainCreationSpec(data *schema.ResourceData) (*models.DomainCreation
 	return result, nil
 }
 
+// ReadAndSetClustersDataToDomainResource refreshes the clusters of the domain in the state with their
+// configuration read from the VCF API, so that out-of-band changes show up in a plan. The clusters keep
+// their order in the state. Clusters that are no longer in the domain are dropped, clusters added to the
+// domain outside of the resource, e.g. by vcf_cluster, are not adopted.
 func ReadAndSetClustersDataToDomainResource(domainClusterRefs []*models.ClusterReference,
 	ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) error {
 	clusterIdsInTheCurrentDomain := make(map[string]bool, len(domainClusterRefs))
@@ -68,9 +83,10 @@ func ReadAndSetClustersDataToDomainResource(domainClusterRefs []*models.ClusterR
 	if err != nil {
 		return err
 	}
-	domainClusterData := data.Get("cluster")
-	domainClusterDataList := domainClusterData.([]interface{})
+	domainClusterDataList := data.Get("cluster").([]interface{})
 	allClusters := clustersResult.Payload.Elements
+	readiness := cluster.NewWorkloadManagementReadiness(apiClient)
+	readClusters := make([]interface{}, 0, len(domainClusterDataList))
 	for _, domainClusterRaw := range domainClusterDataList {
 		domainCluster := domainClusterRaw.(map[string]interface{})
 		for _, clusterObj := range allClusters {
@@ -79,16 +95,31 @@ func ReadAndSetClustersDataToDomainResource(domainClusterRefs []*models.ClusterR
 			if !ok {
 				continue
 			}
-			if domainCluster["name"] == clusterObj.Name {
-				domainCluster["id"] = clusterObj.ID
-				domainCluster["primary_datastore_name"] = clusterObj.PrimaryDatastoreName
-				domainCluster["primary_datastore_type"] = clusterObj.PrimaryDatastoreType
-				domainCluster["is_default"] = clusterObj.IsDefault
-				domainCluster["is_stretched"] = clusterObj.IsStretched
+			// the clusters of a domain that is being created have no ID yet
+			if domainCluster["id"] == clusterObj.ID || (domainCluster["id"] == "" && domainCluster["name"] == clusterObj.Name) {
+				flattenedCluster, err := cluster.FlattenCluster(ctx, clusterObj, readiness, apiClient)
+				if err != nil {
+					return err
+				}
+				readClusters = append(readClusters, resource_utils.MergeReadValue(domainCluster, *flattenedCluster))
+				break
 			}
 		}
 	}
-	_ = data.Set("cluster", domainClusterData)
+	_ = data.Set("cluster", readClusters)
+
+	return nil
+}
+
+// ReadAndSetNsxDataToDomainResource refreshes the NSX Manager cluster of the domain in the state with its
+// configuration read from the VCF API, so that out-of-band changes show up in a plan.
+func ReadAndSetNsxDataToDomainResource(nsxtClusterRef *models.NsxTClusterReference,
+	ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) error {
+	flattenedNsxClusterRef, err := network.FlattenNsxClusterRef(ctx, nsxtClusterRef, apiClient)
+	if err != nil {
+		return err
+	}
+	_ = data.Set("nsx_configuration", resource_utils.MergeReadValue(data.Get("nsx_configuration"), *flattenedNsxClusterRef))
 
 	return nil
 }
@@ -111,6 +142,7 @@ func SetBasicDomainAttributes(ctx context.Context, domainId string, data *schema
 	_ = data.Set("sso_id", domain.SSOID)
 	_ = data.Set("sso_name", domain.SSOName)
 	_ = data.Set("is_management_sso_domain", domain.IsManagementSSODomain)
+	setSsoAdminCredentials(ctx, domain, data, apiClient)
 	if len(domain.VCENTERS) < 1 {
 		return nil, fmt.Errorf("no vCenter Server instance found for domain %q", domainId)
 	}
@@ -125,11 +157,67 @@ func SetBasicDomainAttributes(ctx context.Context, domainId string, data *schema
 	vcenterConfig := vcenterConfigRaw[0].(map[string]interface{})
 	vcenterConfig["id"] = domain.VCENTERS[0].ID
 	vcenterConfig["fqdn"] = domain.VCENTERS[0].Fqdn
+
+	getVcenterParams := vcenters.NewGetVcenterParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getVcenterParams.ID = *domain.VCENTERS[0].ID
+	vcenterResult, err := apiClient.VCenters.GetVcenter(getVcenterParams)
+	if err != nil {
+		return nil, err
+	}
+	vcenterConfig["version"] = vcenterResult.Payload.Version
+	vcenterConfig["ip_address"] = vcenterResult.Payload.IPAddress
 	_ = data.Set("vcenter_configuration", vcenterConfigRaw)
 
 	return domain, nil
 }
 
+// setSsoAdminCredentials sets the SSO administrator credentials of the PSC of the domain, or of the
+// management domain for a workload domain joined to its SSO domain.
+func setSsoAdminCredentials(ctx context.Context, domain *models.Domain, data *schema.ResourceData,
+	apiClient *client.VcfClient) {
+	ssoCredential, err := getSsoAdminCredential(ctx, domain.Name, apiClient)
+	if err == nil && ssoCredential == nil && domain.IsManagementSSODomain && domain.Type != "MANAGEMENT" {
+		var managementDomainName string
+		managementDomainName, err = getManagementDomainName(ctx, apiClient)
+		if err == nil {
+			ssoCredential, err = getSsoAdminCredential(ctx, managementDomainName, apiClient)
+		}
+	}
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Failed to look up the SSO administrator credentials of domain %q: %s", domain.Name, err))
+		return
+	}
+	if ssoCredential != nil {
+		_ = data.Set("sso_admin_username", *ssoCredential.Username)
+		_ = data.Set("sso_admin_password", ssoCredential.Password)
+	}
+}
+
+func getSsoAdminCredential(ctx context.Context, domainName string, apiClient *client.VcfClient) (*models.Credential, error) {
+	getCredentialsParams := credentials_api.NewGetCredentialsParams().
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceType(resource_utils.ToStringPointer("PSC")).
+		WithDomainName(&domainName)
+	return credentials.GetCredential(ctx, getCredentialsParams, "SSO", "", apiClient)
+}
+
+func getManagementDomainName(ctx context.Context, apiClient *client.VcfClient) (string, error) {
+	getDomainsParams := domains.NewGetDomainsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithType(resource_utils.ToStringPointer("MANAGEMENT"))
+	domainsResult, err := apiClient.Domains.GetDomains(getDomainsParams)
+	if err != nil {
+		return "", err
+	}
+	for _, domain := range domainsResult.Payload.Elements {
+		if domain != nil && domain.Type == "MANAGEMENT" {
+			return domain.Name, nil
+		}
+	}
+	return "", errors.New("no management domain found")
+}
+
 func CreateDomainUpdateSpec(data *schema.ResourceData, markForDeletion bool) *models.DomainUpdateSpec {
 	result := new(models.DomainUpdateSpec)
 	if markForDeletion {
@@ -174,6 +262,91 @@ func ImportDomain(ctx context.Context, data *schema.ResourceData, apiClient *cli
 	return []*schema.ResourceData{data}, nil
 }
 
+// GetDomainIdByName returns the ID of the domain with the given name.
+func GetDomainIdByName(ctx context.Context, name string, apiClient *client.VcfClient) (string, error) {
+	getDomainsParams := domains.NewGetDomainsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	domainsResult, err := apiClient.Domains.GetDomains(getDomainsParams)
+	if err != nil {
+		return "", err
+	}
+	for _, domain := range domainsResult.Payload.Elements {
+		if domain != nil && domain.Name == name {
+			return domain.ID, nil
+		}
+	}
+	return "", fmt.Errorf("no domain with name %q found", name)
+}
+
+// SetDomainHostIds sets the IDs of all hosts of the domain, across its clusters.
+func SetDomainHostIds(ctx context.Context, domainId string, data *schema.ResourceData, apiClient *client.VcfClient) error {
+	getHostsParams := hosts.NewGetHostsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getHostsParams.DomainID = &domainId
+	hostsResult, err := apiClient.Hosts.GetHosts(getHostsParams)
+	if err != nil {
+		return err
+	}
+	var hostIds []string
+	for _, host := range hostsResult.Payload.Elements {
+		if host != nil {
+			hostIds = append(hostIds, host.ID)
+		}
+	}
+	// Sort for reproducibility, the backend API returns hosts in random order
+	sort.Strings(hostIds)
+	_ = data.Set("host_ids", hostIds)
+	return nil
+}
+
+// SetImportedPasswords sets the vCenter root password and the NSX Manager admin and audit passwords
+// of an imported domain from the credentials managed by SDDC Manager, as the inventory does not report them.
+func SetImportedPasswords(ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) error {
+	vcenterConfigRaw := data.Get("vcenter_configuration").([]interface{})
+	if len(vcenterConfigRaw) > 0 && vcenterConfigRaw[0] != nil {
+		vcenterConfig := vcenterConfigRaw[0].(map[string]interface{})
+		rootPassword, err := getCredentialPassword(ctx, apiClient, "VCENTER", vcenterConfig["fqdn"].(string), "SSH", "root")
+		if err != nil {
+			return err
+		}
+		vcenterConfig["root_password"] = rootPassword
+		_ = data.Set("vcenter_configuration", vcenterConfigRaw)
+	}
+
+	nsxConfigRaw := data.Get("nsx_configuration").([]interface{})
+	if len(nsxConfigRaw) > 0 && nsxConfigRaw[0] != nil {
+		nsxConfig := nsxConfigRaw[0].(map[string]interface{})
+		vipFqdn := nsxConfig["vip_fqdn"].(string)
+		adminPassword, err := getCredentialPassword(ctx, apiClient, "NSXT_MANAGER", vipFqdn, "API", "admin")
+		if err != nil {
+			return err
+		}
+		nsxConfig["nsx_manager_admin_password"] = adminPassword
+		auditPassword, err := getCredentialPassword(ctx, apiClient, "NSXT_MANAGER", vipFqdn, "AUDIT", "audit")
+		if err != nil {
+			return err
+		}
+		nsxConfig["nsx_manager_audit_password"] = auditPassword
+		_ = data.Set("nsx_configuration", nsxConfigRaw)
+	}
+	return nil
+}
+
+// getCredentialPassword returns the password of the credential of the resource with the given type and username,
+// or an empty string if SDDC Manager does not manage such a credential.
+func getCredentialPassword(ctx context.Context, apiClient *client.VcfClient,
+	resourceType, resourceName, credentialType, username string) (string, error) {
+	getCredentialsParams := credentials_api.NewGetCredentialsParams().
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceType(&resourceType).
+		WithResourceName(&resourceName)
+	credential, err := credentials.GetCredential(ctx, getCredentialsParams, credentialType, username, apiClient)
+	if err != nil || credential == nil {
+		return "", err
+	}
+	return credential.Password, nil
+}
+
 func setClustersDataToDomainDataSource(domainClusterRefs []*models.ClusterReference, ctx context.Context, data *schema.ResourceData, apiClient *client.VcfClient) error {
 	clusterIds := make([]string, len(domainClusterRefs))
 	for i, clusterReference := range domainClusterRefs {
@@ -183,6 +356,7 @@ func setClustersDataToDomainDataSource(domainClusterRefs []*models.ClusterRefere
 	sort.Strings(clusterIds)
 
 	flattenedClusters := make([]map[string]interface{}, len(domainClusterRefs))
+	readiness := cluster.NewWorkloadManagementReadiness(apiClient)
 	for i, clusterId := range clusterIds {
 		getClusterParams := clusters.GetClusterParams{ID: clusterId}
 		getClusterParams.WithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
@@ -191,7 +365,7 @@ func setClustersDataToDomainDataSource(domainClusterRefs []*models.ClusterRefere
 			return err
 		}
 		clusterRef := clusterResult.Payload
-		flattenedCluster, err := cluster.FlattenCluster(ctx, clusterRef, apiClient)
+		flattenedCluster, err := cluster.FlattenCluster(ctx, clusterRef, readiness, apiClient)
 		if err != nil {
 			return err
 		}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/vcf-sdk-go/models"
+	"net/netip"
 )
 
 // IpAddressPoolSchema this helper function extracts the IpAddressPoolSpec schema, which
@@ -127,6 +128,13 @@ func getIpAddressPoolSubnetSpecFromSchema(object map[string]interface{}) (*model
 	if len(gateway) == 0 {
 		return nil, fmt.Errorf("cannot convert to IPAddressPoolSubnetSpec, gateway is required")
 	}
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to IPAddressPoolSubnetSpec, invalid cidr %q: %w", cidr, err)
+	}
+	if gatewayAddress, err := netip.ParseAddr(gateway); err != nil || !prefix.Contains(gatewayAddress) {
+		return nil, fmt.Errorf("gateway %s of IP address pool subnet is not within %s", gateway, cidr)
+	}
 	result.Cidr = &cidr
 	result.Gateway = &gateway
 	if ipAddressPoolRangeRaw, ok := object["ip_address_pool_range"]; ok {
@@ -138,6 +146,9 @@ func getIpAddressPoolSubnetSpecFromSchema(object map[string]interface{}) (*model
 				ipAddressPoolRangeMap := ipAddressPoolRangeEntry.(map[string]interface{})
 				start := ipAddressPoolRangeMap["start"].(string)
 				end := ipAddressPoolRangeMap["end"].(string)
+				if err = validateIpAddressPoolRange(prefix, start, end); err != nil {
+					return nil, err
+				}
 				ipAddressPoolSubnetSpec.Start = &start
 				ipAddressPoolSubnetSpec.End = &end
 				result.IPAddressPoolRanges = append(result.IPAddressPoolRanges, &ipAddressPoolSubnetSpec)
@@ -147,3 +158,20 @@ func getIpAddressPoolSubnetSpecFromSchema(object map[string]interface{}) (*model
 
 	return result, nil
 }
+
+// validateIpAddressPoolRange checks that the IP address range is within the subnet and that its
+// start address is not after its end address.
+func validateIpAddressPoolRange(prefix netip.Prefix, start, end string) error {
+	startAddress, err := netip.ParseAddr(start)
+	if err != nil || !prefix.Contains(startAddress) {
+		return fmt.Errorf("start %s of IP address pool range is not within %s", start, prefix)
+	}
+	endAddress, err := netip.ParseAddr(end)
+	if err != nil || !prefix.Contains(endAddress) {
+		return fmt.Errorf("end %s of IP address pool range is not within %s", end, prefix)
+	}
+	if startAddress.Compare(endAddress) > 0 {
+		return fmt.Errorf("start %s of IP address pool range is after its end %s", start, end)
+	}
+	return nil
+}
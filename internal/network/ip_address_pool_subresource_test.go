@@ -0,0 +1,64 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package network
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetIpAddressPoolSpecFromSchema(t *testing.T) {
+	var ipAddressPoolTests = []struct {
+		name        string
+		gateway     string
+		start       string
+		end         string
+		expectedErr string
+	}{
+		{"valid pool", "10.0.11.250", "10.0.11.50", "10.0.11.70", ""},
+		{"gateway outside subnet", "10.0.12.1", "10.0.11.50", "10.0.11.70", "gateway 10.0.12.1 of IP address pool subnet is not within 10.0.11.0/24"},
+		{"start after end", "10.0.11.250", "10.0.11.70", "10.0.11.50", "start 10.0.11.70 of IP address pool range is after its end 10.0.11.50"},
+		{"range outside subnet", "10.0.11.250", "10.0.12.50", "10.0.12.70", "start 10.0.12.50 of IP address pool range is not within 10.0.11.0/24"},
+		{"range end outside subnet", "10.0.11.250", "10.0.11.50", "10.0.12.70", "end 10.0.12.70 of IP address pool range is not within 10.0.11.0/24"},
+	}
+
+	for _, poolTest := range ipAddressPoolTests {
+		t.Run(poolTest.name, func(t *testing.T) {
+			object := map[string]interface{}{
+				"name": "static-ip-pool-01",
+				"subnet": []interface{}{
+					map[string]interface{}{
+						"cidr":    "10.0.11.0/24",
+						"gateway": poolTest.gateway,
+						"ip_address_pool_range": []interface{}{
+							map[string]interface{}{
+								"start": poolTest.start,
+								"end":   poolTest.end,
+							},
+						},
+					},
+				},
+			}
+
+			spec, err := GetIpAddressPoolSpecFromSchema(object)
+			if poolTest.expectedErr == "" {
+				if err != nil {
+					t.Fatalf("failed. unexpected error: %s", err)
+				}
+				if len(spec.Subnets) != 1 || len(spec.Subnets[0].IPAddressPoolRanges) != 1 {
+					t.Errorf("failed. expected one subnet with one range, got %+v", spec)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("failed. expected error %q, but got none", poolTest.expectedErr)
+			}
+			if !strings.Contains(err.Error(), poolTest.expectedErr) {
+				t.Errorf("failed. unexpected error: %s, expected %s", err.Error(), poolTest.expectedErr)
+			}
+		})
+	}
+}
@@ -25,16 +25,18 @@ func NsxManagerNodeSchema() *schema.Resource {
 				ValidateFunc: validation.NoZeroValues,
 			},
 			"ip_address": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "IPv4 address of the NSX Manager appliance",
-				ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "IPv4 address of the NSX Manager appliance",
+				ValidateFunc:     validationutils.ValidateIPv4AddressSchema,
+				DiffSuppressFunc: validationutils.SuppressIpAddressDiff,
 			},
 			"fqdn": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Fully qualified domain name of the NSX Manager appliance, e.g., sfo-w01-nsx01a.sfo.rainpole.io",
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Fully qualified domain name of the NSX Manager appliance, e.g., sfo-w01-nsx01a.sfo.rainpole.io",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationutils.SuppressFqdnDiff,
 			},
 			"subnet_mask": {
 				Type:         schema.TypeString,
@@ -52,6 +54,8 @@ func NsxManagerNodeSchema() *schema.Resource {
 	}
 }
 
+// TODO support anti-affinity or target hosts for the NSX Manager nodes. NsxManagerSpec in the VCF API
+// has no placement settings, VCF decides on the placement of the appliances itself.
 func TryConvertToNsxManagerNodeSpec(object map[string]interface{}) (models.NsxManagerSpec, error) {
 	result := models.NsxManagerSpec{}
 	if object == nil {
@@ -29,24 +29,32 @@ func NsxSchema() *schema.Resource {
 				Computed:    true,
 				Description: "ID of the NSX Manager cluster",
 			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the deployed NSX Manager cluster",
+			},
 			"vip": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Virtual IP (VIP) for the NSX Manager cluster",
-				ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Virtual IP (VIP) for the NSX Manager cluster",
+				ValidateFunc:     validationutils.ValidateIPv4AddressSchema,
+				DiffSuppressFunc: validationutils.SuppressIpAddressDiff,
 			},
 			"vip_fqdn": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Fully qualified domain name of the NSX Manager cluster VIP",
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Fully qualified domain name of the NSX Manager cluster VIP",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationutils.SuppressFqdnDiff,
 			},
 			"license_key": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Sensitive:    true,
-				Description:  "NSX license to be used",
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Required:         true,
+				Sensitive:        true,
+				Description:      "NSX license to be used",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationutils.SuppressLicenseKeyDiff,
 			},
 			"form_factor": {
 				Type:        schema.TypeString,
@@ -60,30 +68,62 @@ func NsxSchema() *schema.Resource {
 				},
 			},
 			"nsx_manager_admin_password": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Sensitive:    true,
-				Description:  "NSX Manager admin user password",
-				ValidateFunc: validationutils.ValidatePassword,
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+				Description: "NSX Manager admin user password. Once the NSX Manager cluster is deployed, changes are " +
+					"only applied together with a change of password_version",
+				ValidateFunc:     validationutils.ValidatePassword,
+				DiffSuppressFunc: suppressUnversionedPasswordDiff,
 			},
 			"nsx_manager_audit_password": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Sensitive:    true,
-				Description:  "NSX Manager audit user password",
-				ValidateFunc: validationutils.ValidatePassword,
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "NSX Manager audit user password. Once the NSX Manager cluster is deployed, changes are " +
+					"only applied together with a change of password_version",
+				ValidateFunc:     validationutils.ValidatePassword,
+				DiffSuppressFunc: suppressUnversionedPasswordDiff,
+			},
+			"password_version": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				Description: "Version of the NSX Manager admin and audit passwords. Increase it to set the changed passwords " +
+					"on the deployed NSX Manager cluster, changes of the passwords alone are ignored",
+				ValidateFunc: validation.IntAtLeast(0),
 			},
+			// TODO support a separate root (CLI) password of the NSX Manager nodes of workload domains.
+			// Unlike SDDCNSXTSpec for bring-up, NsxTSpec in the VCF API only accepts the admin and audit passwords.
 			"nsx_manager_node": {
 				Type:        schema.TypeList,
 				Required:    true,
 				Description: "Specification details of the NSX Manager virtual machines. 3 of these are required for the first workload domain",
 				Elem:        NsxManagerNodeSchema(),
 			},
+			"ip_address_pool": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "Contains the parameters required to create or reuse an IP address pool for the TEP addresses of the hosts. " +
+					"Omit for DHCP, provide name only to reuse existing IP Pool, if subnets are provided a new IP Pool will be created",
+				Elem: IpAddressPoolSchema(),
+			},
 		},
 	}
 }
 
-// TODO support IpPoolSpecs.
+// suppressUnversionedPasswordDiff ignores changes of the passwords of a deployed NSX Manager cluster,
+// unless they come with a change of password_version, so that the secrets themselves are not diffed.
+func suppressUnversionedPasswordDiff(k, _, _ string, d *schema.ResourceData) bool {
+	if d.Id() == "" {
+		return false
+	}
+	return !d.HasChange(k[:strings.LastIndex(k, ".")+1] + "password_version")
+}
+
+// TODO support a target datastore for the NSX Manager appliances. NsxTSpec and NsxManagerSpec in the
+// VCF API have no datastore setting, the appliances are placed on the datastore of the cluster.
 
 // TryConvertToNsxSpec is a convenience method that converts a map[string]interface{}
 // // received from the Terraform SDK to an API struct, used in VCF API calls.
@@ -140,6 +180,17 @@ func TryConvertToNsxSpec(object map[string]interface{}) (*models.NsxTSpec, error
 	}
 	result.NsxManagerSpecs = nsxManagerSpecs
 
+	if ipAddressPoolRaw, ok := object["ip_address_pool"]; ok && !validationutils.IsEmpty(ipAddressPoolRaw) {
+		ipAddressPoolList := ipAddressPoolRaw.([]interface{})
+		if !validationutils.IsEmpty(ipAddressPoolList[0]) {
+			ipAddressPoolSpec, err := GetIpAddressPoolSpecFromSchema(ipAddressPoolList[0].(map[string]interface{}))
+			if err != nil {
+				return nil, err
+			}
+			result.IPAddressPoolSpec = ipAddressPoolSpec
+		}
+	}
+
 	return result, nil
 }
 
@@ -161,6 +212,7 @@ func FlattenNsxClusterRef(ctx context.Context, nsxtClusterRef *models.NsxTCluste
 		return nil, err
 	}
 	nsxtCluster := nsxtClusterResponse.Payload
+	flattenedNsxCluster["version"] = nsxtCluster.Version
 	nsxtManagerNodes := nsxtCluster.Nodes
 	// Since backend API returns objects in random order sort nsxtManagerNodes list to ensure
 	// import is reproducible
@@ -182,3 +234,40 @@ func FlattenNsxClusterRef(ctx context.Context, nsxtClusterRef *models.NsxTCluste
 
 	return &result, nil
 }
+
+// GetNsxCertificateSans returns the subject alternative names of the certificate of an NSX Manager
+// cluster. Unless overridden by sans, these are the FQDNs of the cluster VIP and of every manager node.
+func GetNsxCertificateSans(ctx context.Context, nsxtClusterId string, sans []string, apiClient *client.VcfClient) ([]string, error) {
+	if len(sans) > 0 {
+		return sans, nil
+	}
+	getNsxTClusterParams := nsxt_clusters.NewGetNSXTClusterParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(nsxtClusterId)
+
+	nsxtClusterResponse, err := apiClient.NSXTClusters.GetNSXTCluster(getNsxTClusterParams)
+	if err != nil {
+		return nil, err
+	}
+	return getNsxClusterFqdns(nsxtClusterResponse.Payload)
+}
+
+func getNsxClusterFqdns(nsxtCluster *models.NsxTCluster) ([]string, error) {
+	var fqdns []string
+	seenFqdns := make(map[string]bool)
+	addFqdn := func(fqdn string) {
+		if fqdn != "" && !seenFqdns[strings.ToLower(fqdn)] {
+			seenFqdns[strings.ToLower(fqdn)] = true
+			fqdns = append(fqdns, fqdn)
+		}
+	}
+	addFqdn(nsxtCluster.VipFqdn)
+	for _, node := range nsxtCluster.Nodes {
+		if node != nil {
+			addFqdn(node.Fqdn)
+		}
+	}
+	if len(fqdns) == 0 {
+		return nil, fmt.Errorf("NSX Manager cluster %s has neither a VIP FQDN nor node FQDNs to use as SANs", nsxtCluster.ID)
+	}
+	return fqdns, nil
+}
@@ -16,6 +16,8 @@ import (
 // VdsSchema this helper function extracts the VDS Schema, so that
 // it's made available for both workload domain and cluster creation.
 // This specification contains vSphere distributed switch configurations.
+// TODO support the MTU and the transport zones of the vSphere Distributed Switch. Unlike DvsSpec of
+// the bring-up, VdsSpec in the VCF API has no MTU and no transport zone attributes.
 func VdsSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -39,6 +41,7 @@ func VdsSchema() *schema.Resource {
 			"nioc_bandwidth_allocations": {
 				Type:     schema.TypeList,
 				Optional: true,
+				Computed: true,
 				Description: "List of Network I/O Control Bandwidth Allocations for System Traffic based on" +
 					" shares, reservation, and limit, you can configure Network I/O Control to allocate certain amount" +
 					" of bandwidth for traffic generated by vSphere Fault Tolerance, iSCSI storage, vSphere vMotion, and so on." +
@@ -50,6 +53,8 @@ func VdsSchema() *schema.Resource {
 	}
 }
 
+// TODO support referencing an existing vSphere Distributed Switch shared across clusters. VdsSpec
+// in the VCF API always describes a new switch and has no attribute referencing an existing one.
 func TryConvertToVdsSpec(object map[string]interface{}) (*models.VdsSpec, error) {
 	result := &models.VdsSpec{}
 	if object == nil {
@@ -122,3 +127,42 @@ func FlattenVdsSpec(vdsSpec *models.VdsSpec) map[string]interface{} {
 
 	return result
 }
+
+// FlattenVds flattens a vSphere Distributed Switch of the cluster inventory like a VdsSpec, so that
+// the switches of an imported cluster match the vds configuration of the resource.
+func FlattenVds(vds *models.Vds) map[string]interface{} {
+	if vds == nil || vds.Name == nil {
+		return make(map[string]interface{})
+	}
+	vdsSpec := &models.VdsSpec{
+		Name:         vds.Name,
+		IsUsedByNSXT: vds.IsUsedByNSXT,
+	}
+	for _, niocBandwidthAllocation := range vds.NiocBandwidthAllocations {
+		// incomplete allocations cannot be set through the VCF API either
+		if niocBandwidthAllocation == nil || !isNiocTrafficResourceAllocationComplete(niocBandwidthAllocation.NiocTrafficResourceAllocation) {
+			continue
+		}
+		niocBandwidthAllocationType := niocBandwidthAllocation.Type
+		vdsSpec.NiocBandwidthAllocationSpecs = append(vdsSpec.NiocBandwidthAllocationSpecs, &models.NiocBandwidthAllocationSpec{
+			Type:                          &niocBandwidthAllocationType,
+			NiocTrafficResourceAllocation: niocBandwidthAllocation.NiocTrafficResourceAllocation,
+		})
+	}
+	for _, portgroup := range vds.PortGroups {
+		if portgroup == nil || portgroup.Name == nil || portgroup.TransportType == nil {
+			continue
+		}
+		vdsSpec.PortGroupSpecs = append(vdsSpec.PortGroupSpecs, &models.PortgroupSpec{
+			Name:          portgroup.Name,
+			TransportType: portgroup.TransportType,
+			ActiveUplinks: portgroup.ActiveUplinks,
+		})
+	}
+
+	return FlattenVdsSpec(vdsSpec)
+}
+
+func isNiocTrafficResourceAllocationComplete(allocation *models.NiocTrafficResourceAllocation) bool {
+	return allocation != nil && allocation.Limit != nil && allocation.Reservation != nil && allocation.SharesInfo != nil
+}
@@ -33,13 +33,15 @@ func VMNicSchema() *schema.Resource {
 			"vds_name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "Name of the VDS to associate with the ESXi host",
+				Description:  "Name of the VDS to associate the vmnic with. Required when the cluster has more than one VDS",
 				ValidateFunc: validation.NoZeroValues,
 			},
 		},
 	}
 }
 
+// TODO validate the MTU of the physical NICs against the MTU of the transport networks. The host
+// inventory of the VCF API only reports the device name, MAC address and speed of a PhysicalNic.
 func TryConvertToVmNic(object map[string]interface{}) (*models.VMNic, error) {
 	if object == nil {
 		return nil, fmt.Errorf("cannot convert to VMNic, object is nil")
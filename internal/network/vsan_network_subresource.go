@@ -0,0 +1,61 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package network
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+	"net/netip"
+)
+
+// VsanNetworkSchema this helper function extracts the VsanNetworkSpec schema, which
+// overrides the gateway of the vSAN VMkernel adapters otherwise derived from the network pool.
+func VsanNetworkSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"vsan_cidr": {
+				Type:         schema.TypeString,
+				Description:  "CIDR of the vSAN transport subnet, e.g. 172.18.93.0/24",
+				Required:     true,
+				ValidateFunc: validation.IsCIDR,
+			},
+			"vsan_gateway_ip": {
+				Type:         schema.TypeString,
+				Description:  "Dedicated gateway of the vSAN VMkernel adapters. Must be within vsan_cidr",
+				Required:     true,
+				ValidateFunc: validationutils.ValidateIPv4AddressSchema,
+			},
+		},
+	}
+}
+
+func TryConvertToVsanNetworkSpec(object map[string]interface{}) (*models.VSANNetworkSpec, error) {
+	if object == nil {
+		return nil, fmt.Errorf("cannot convert to VSANNetworkSpec, object is nil")
+	}
+	vsanCidr := object["vsan_cidr"].(string)
+	vsanGatewayIp := object["vsan_gateway_ip"].(string)
+
+	prefix, err := netip.ParsePrefix(vsanCidr)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to VSANNetworkSpec, invalid vsan_cidr %q: %w", vsanCidr, err)
+	}
+	gateway, err := netip.ParseAddr(vsanGatewayIp)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert to VSANNetworkSpec, invalid vsan_gateway_ip %q: %w", vsanGatewayIp, err)
+	}
+	if !prefix.Contains(gateway) {
+		return nil, fmt.Errorf("vSAN gateway %s is not within the vSAN transport subnet %s", vsanGatewayIp, vsanCidr)
+	}
+
+	return &models.VSANNetworkSpec{
+		VSANCidr:      vsanCidr,
+		VSANGatewayIP: vsanGatewayIp,
+	}, nil
+}
@@ -0,0 +1,54 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/ceip"
+	"time"
+)
+
+func DataSourceCeip() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCeipRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "CEIP status. One among: ENABLED, DISABLED, ENABLING, DISABLING, ENABLING_FAILED, DISABLING_FAILED",
+			},
+			"instance_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the VCF instance reported to the CEIP",
+			},
+		},
+	}
+}
+
+func dataSourceCeipRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getCeipStatusParams := ceip.NewGetCEIPStatusParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	ceipResult, err := apiClient.CEIP.GetCEIPStatus(getCeipStatusParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("status", ceipResult.Payload.Status)
+	_ = data.Set("instance_id", ceipResult.Payload.InstanceID)
+	data.SetId(ceipResult.Payload.InstanceID)
+
+	return nil
+}
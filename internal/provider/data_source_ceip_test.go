@@ -0,0 +1,33 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceVcfCeip(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfCeipDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_ceip.ceip", "status"),
+					resource.TestCheckResourceAttrSet("data.vcf_ceip.ceip", "instance_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfCeipDataSourceConfig() string {
+	return `
+	data "vcf_ceip" "ceip" {
+	}`
+}
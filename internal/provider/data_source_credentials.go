@@ -0,0 +1,220 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	credentials_api "github.com/vmware/vcf-sdk-go/client/credentials"
+	"github.com/vmware/vcf-sdk-go/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+var credentialsFilters = []string{"resource_name", "resource_ip", "resource_type", "domain_name"}
+
+func DataSourceCredentials() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceCredentialsRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Name of the resource whose credentials are read, e.g. the FQDN of an ESXi host or a vCenter",
+				ValidateFunc: validation.NoZeroValues,
+				AtLeastOneOf: credentialsFilters,
+			},
+			"resource_ip": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "IP address of the resource whose credentials are read",
+				ValidateFunc: validation.IsIPAddress,
+				AtLeastOneOf: credentialsFilters,
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Type of the resource whose credentials are read. One among: ESXI, VCENTER, PSC, NSXT_MANAGER, NSXT_EDGE, VXRAIL_MANAGER, BACKUP",
+				ValidateFunc: validation.StringInSlice([]string{"ESXI", "VCENTER", "PSC", "NSXT_MANAGER", "NSXT_EDGE", "VXRAIL_MANAGER", "BACKUP"}, false),
+				AtLeastOneOf: credentialsFilters,
+			},
+			"domain_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Name of the domain of the resources whose credentials are read",
+				ValidateFunc: validation.NoZeroValues,
+				AtLeastOneOf: credentialsFilters,
+			},
+			"account_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Type of the accounts whose credentials are read. One among: USER, SYSTEM, SERVICE",
+				ValidateFunc: validation.StringInSlice([]string{"USER", "SYSTEM", "SERVICE"}, false),
+			},
+			"credential_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Type of the credentials that are read. One among: SSO, SSH, API, FTP, AUDIT",
+				ValidateFunc: validation.StringInSlice([]string{"SSO", "SSH", "API", "FTP", "AUDIT"}, false),
+			},
+			"credentials": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Credentials managed by SDDC Manager that match the filters, ordered by resource name, credential type and username",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the credential",
+						},
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the resource of the credential",
+						},
+						"resource_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "IP address of the resource of the credential",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the resource of the credential",
+						},
+						"domain_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the domain of the resource of the credential",
+						},
+						"account_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Account type of the credential",
+						},
+						"credential_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Credential type",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Username of the credential",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+							Description: "Current password of the credential",
+						},
+						"modification_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the last change of the credential",
+						},
+						"expiry_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiry date of the password",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceCredentialsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getCredentialsParams := credentials_api.NewGetCredentialsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	var idParts []string
+	if resourceName, ok := data.GetOk("resource_name"); ok {
+		getCredentialsParams.ResourceName = resource_utils.ToStringPointer(resourceName)
+		idParts = append(idParts, resourceName.(string))
+	}
+	if resourceIp, ok := data.GetOk("resource_ip"); ok {
+		getCredentialsParams.ResourceIP = resource_utils.ToStringPointer(resourceIp)
+		idParts = append(idParts, resourceIp.(string))
+	}
+	if resourceType, ok := data.GetOk("resource_type"); ok {
+		getCredentialsParams.ResourceType = resource_utils.ToStringPointer(resourceType)
+		idParts = append(idParts, resourceType.(string))
+	}
+	if domainName, ok := data.GetOk("domain_name"); ok {
+		getCredentialsParams.DomainName = resource_utils.ToStringPointer(domainName)
+		idParts = append(idParts, domainName.(string))
+	}
+	if accountType, ok := data.GetOk("account_type"); ok {
+		getCredentialsParams.AccountType = resource_utils.ToStringPointer(accountType)
+		idParts = append(idParts, accountType.(string))
+	}
+
+	getCredentialsResponse, err := apiClient.Credentials.GetCredentials(getCredentialsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	credentialType := data.Get("credential_type").(string)
+	if len(credentialType) > 0 {
+		idParts = append(idParts, credentialType)
+	}
+	_ = data.Set("credentials", flattenCredentials(getCredentialsResponse.Payload.Elements, credentialType))
+	data.SetId("credentials:" + strings.Join(idParts, ":"))
+
+	return nil
+}
+
+// flattenCredentials flattens the credentials of the given credential type, or all credentials if it
+// is empty, sorted for reproducibility as the backend API returns credentials in random order.
+func flattenCredentials(credentialList []*models.Credential, credentialType string) []map[string]interface{} {
+	flattenedCredentials := *new([]map[string]interface{})
+	for _, credential := range credentials.FilterCredentials(credentialList, credentialType, "") {
+		flattenedCredential := map[string]interface{}{
+			"id":                     credential.ID,
+			"account_type":           credential.AccountType,
+			"credential_type":        *credential.CredentialType,
+			"username":               *credential.Username,
+			"password":               credential.Password,
+			"modification_timestamp": credential.ModificationTimestamp,
+			"resource_name":          "",
+		}
+		if credential.Resource != nil {
+			if credential.Resource.ResourceName != nil {
+				flattenedCredential["resource_name"] = *credential.Resource.ResourceName
+			}
+			flattenedCredential["resource_ip"] = credential.Resource.ResourceIP
+			flattenedCredential["resource_type"] = credential.Resource.ResourceType
+			flattenedCredential["domain_name"] = credential.Resource.DomainName
+		}
+		if credential.Expiry != nil {
+			flattenedCredential["expiry_date"] = credential.Expiry.ExpiryDate
+		}
+		flattenedCredentials = append(flattenedCredentials, flattenedCredential)
+	}
+	sort.SliceStable(flattenedCredentials, func(i, j int) bool {
+		for _, key := range []string{"resource_name", "credential_type", "username"} {
+			if flattenedCredentials[i][key] != flattenedCredentials[j][key] {
+				return flattenedCredentials[i][key].(string) < flattenedCredentials[j][key].(string)
+			}
+		}
+		return false
+	})
+	return flattenedCredentials
+}
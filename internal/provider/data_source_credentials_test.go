@@ -0,0 +1,65 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestAccDataSourceVcfCredentials(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfCredentialsDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.vcf_credentials.vcenter", "credentials.0.username", "root"),
+					resource.TestCheckResourceAttrSet("data.vcf_credentials.vcenter", "credentials.0.password"),
+					resource.TestCheckResourceAttrSet("data.vcf_credentials.vcenter", "credentials.0.resource_name"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfCredentialsDataSourceConfig() string {
+	return `
+	data "vcf_credentials" "vcenter" {
+		resource_type   = "VCENTER"
+		credential_type = "SSH"
+	}`
+}
+
+func TestFlattenCredentials(t *testing.T) {
+	newCredential := func(resourceName, credentialType, username string) *models.Credential {
+		return &models.Credential{
+			CredentialType: resource_utils.ToStringPointer(credentialType),
+			Username:       resource_utils.ToStringPointer(username),
+			Password:       "VMware1!VMware1!",
+			Resource:       &models.AuthenticatedResource{ResourceName: resource_utils.ToStringPointer(resourceName)},
+		}
+	}
+	credentialList := []*models.Credential{
+		newCredential("sfo-w01-nsx01.sfo.rainpole.io", "AUDIT", "audit"),
+		newCredential("sfo-w01-nsx01.sfo.rainpole.io", "API", "admin"),
+		newCredential("sfo-w01-nsx01.sfo.rainpole.io", "SSH", "root"),
+		{CredentialType: resource_utils.ToStringPointer("SSH")},
+	}
+
+	flattenedCredentials := flattenCredentials(credentialList, "")
+	if len(flattenedCredentials) != 3 || flattenedCredentials[0]["username"] != "admin" ||
+		flattenedCredentials[1]["username"] != "audit" || flattenedCredentials[2]["username"] != "root" {
+		t.Errorf("unexpected credentials %v", flattenedCredentials)
+	}
+	flattenedCredentials = flattenCredentials(credentialList, "SSH")
+	if len(flattenedCredentials) != 1 || flattenedCredentials[0]["resource_name"] != "sfo-w01-nsx01.sfo.rainpole.io" {
+		t.Errorf("unexpected SSH credentials %v", flattenedCredentials)
+	}
+}
@@ -0,0 +1,177 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net"
+	"strings"
+	"time"
+)
+
+// dnsResolver resolves the DNS records checked by vcf_dns_check, replaced in unit tests.
+var dnsResolver interface {
+	LookupHost(ctx context.Context, host string) ([]string, error)
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+} = net.DefaultResolver
+
+func DataSourceDnsCheck() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDnsCheckRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"record": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "FQDN and IP address pairs whose forward and reverse DNS records are checked",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fqdn": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Fully qualified domain name, e.g. sfo-m01-vc01.sfo.rainpole.io",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"ip_address": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "IP address the FQDN is expected to resolve to",
+							ValidateFunc: validation.IsIPAddress,
+						},
+					},
+				},
+			},
+			"result": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Result of the DNS check of every record",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Fully qualified domain name of the record",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expected IP address of the record",
+						},
+						"forward_ok": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the FQDN resolves to the expected IP address",
+						},
+						"reverse_ok": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the IP address resolves back to the FQDN",
+						},
+						"resolved_ip_addresses": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "IP addresses the FQDN resolves to",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"resolved_fqdns": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Names the IP address resolves to",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"errors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Missing or mismatched DNS records",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ready": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the forward and reverse DNS records of all records are in place",
+			},
+		},
+	}
+}
+
+func dataSourceDnsCheckRead(ctx context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	var results []map[string]interface{}
+	var dnsErrors []string
+	var fqdns []string
+
+	for _, record := range data.Get("record").([]interface{}) {
+		recordData := record.(map[string]interface{})
+		fqdn := strings.TrimSuffix(recordData["fqdn"].(string), ".")
+		ipAddress := recordData["ip_address"].(string)
+		fqdns = append(fqdns, fqdn)
+
+		result := map[string]interface{}{
+			"fqdn":       fqdn,
+			"ip_address": ipAddress,
+		}
+
+		resolvedIpAddresses, err := dnsResolver.LookupHost(ctx, fqdn)
+		if err != nil {
+			dnsErrors = append(dnsErrors, fmt.Sprintf("forward lookup of %s failed: %s", fqdn, err))
+		}
+		result["resolved_ip_addresses"] = resolvedIpAddresses
+		result["forward_ok"] = false
+		for _, resolvedIpAddress := range resolvedIpAddresses {
+			if net.ParseIP(resolvedIpAddress).Equal(net.ParseIP(ipAddress)) {
+				result["forward_ok"] = true
+				break
+			}
+		}
+		if err == nil && !result["forward_ok"].(bool) {
+			dnsErrors = append(dnsErrors, fmt.Sprintf("%s resolves to %v instead of %s",
+				fqdn, resolvedIpAddresses, ipAddress))
+		}
+
+		var resolvedFqdns []string
+		resolvedNames, err := dnsResolver.LookupAddr(ctx, ipAddress)
+		if err != nil {
+			dnsErrors = append(dnsErrors, fmt.Sprintf("reverse lookup of %s failed: %s", ipAddress, err))
+		}
+		result["reverse_ok"] = false
+		for _, resolvedName := range resolvedNames {
+			resolvedFqdn := strings.TrimSuffix(resolvedName, ".")
+			resolvedFqdns = append(resolvedFqdns, resolvedFqdn)
+			if strings.EqualFold(resolvedFqdn, fqdn) {
+				result["reverse_ok"] = true
+			}
+		}
+		result["resolved_fqdns"] = resolvedFqdns
+		if err == nil && !result["reverse_ok"].(bool) {
+			dnsErrors = append(dnsErrors, fmt.Sprintf("%s resolves to %v instead of %s",
+				ipAddress, resolvedFqdns, fqdn))
+		}
+
+		results = append(results, result)
+	}
+
+	_ = data.Set("result", results)
+	_ = data.Set("errors", dnsErrors)
+	_ = data.Set("ready", len(dnsErrors) == 0)
+
+	data.SetId("dns-check:" + strings.Join(fqdns, ","))
+	return nil
+}
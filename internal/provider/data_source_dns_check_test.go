@@ -0,0 +1,66 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testDnsResolver struct {
+	hosts map[string][]string
+	addrs map[string][]string
+}
+
+func (r testDnsResolver) LookupHost(_ context.Context, host string) ([]string, error) {
+	if addresses, ok := r.hosts[host]; ok {
+		return addresses, nil
+	}
+	return nil, fmt.Errorf("no such host")
+}
+
+func (r testDnsResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	if names, ok := r.addrs[addr]; ok {
+		return names, nil
+	}
+	return nil, fmt.Errorf("no such host")
+}
+
+func TestDnsCheckRead(t *testing.T) {
+	originalResolver := dnsResolver
+	defer func() { dnsResolver = originalResolver }()
+	dnsResolver = testDnsResolver{
+		hosts: map[string][]string{
+			"vc01.sfo.rainpole.io":  {"10.0.0.6"},
+			"nsx01.sfo.rainpole.io": {"10.0.0.31"},
+		},
+		addrs: map[string][]string{
+			"10.0.0.6":  {"VC01.sfo.rainpole.io."},
+			"10.0.0.30": {"nsx-vip.sfo.rainpole.io."},
+		},
+	}
+
+	input := map[string]interface{}{
+		"record": []interface{}{
+			map[string]interface{}{"fqdn": "vc01.sfo.rainpole.io", "ip_address": "10.0.0.6"},
+			map[string]interface{}{"fqdn": "nsx01.sfo.rainpole.io", "ip_address": "10.0.0.30"},
+		},
+	}
+	testResourceData := schema.TestResourceDataRaw(t, DataSourceDnsCheck().Schema, input)
+
+	diags := dataSourceDnsCheckRead(context.Background(), testResourceData, nil)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, true, testResourceData.Get("result.0.forward_ok"))
+	assert.Equal(t, true, testResourceData.Get("result.0.reverse_ok"))
+	assert.Equal(t, false, testResourceData.Get("result.1.forward_ok"))
+	assert.Equal(t, false, testResourceData.Get("result.1.reverse_ok"))
+	assert.Equal(t, "nsx-vip.sfo.rainpole.io", testResourceData.Get("result.1.resolved_fqdns.0"))
+	assert.Equal(t, 2, testResourceData.Get("errors.#"))
+	assert.Equal(t, false, testResourceData.Get("ready"))
+}
@@ -26,14 +26,19 @@ func DataSourceDomain() *schema.Resource {
 		Schema: map[string]*schema.Schema{
 			"domain_id": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.NoZeroValues,
-				Description:  "The ID of the Domain to be used as data source",
+				ExactlyOneOf: []string{"domain_id", "name"},
+				Description:  "The ID of the Domain to be used as data source. Either \"domain_id\" or \"name\" has to be provided",
 			},
 			"name": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Name of the domain",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.NoZeroValues,
+				ExactlyOneOf: []string{"domain_id", "name"},
+				Description:  "Name of the domain. Either \"domain_id\" or \"name\" has to be provided",
 			},
 			"cluster": {
 				Type:        schema.TypeList,
@@ -73,6 +78,25 @@ func DataSourceDomain() *schema.Resource {
 				Computed:    true,
 				Description: "Name of the SSO domain associated with the workload domain",
 			},
+			"sso_admin_username": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Username of the SSO administrator of the SSO domain of the workload domain, which is the one of the management domain if the workload domain joined it. Unset if SDDC Manager does not manage the credentials",
+			},
+			"sso_admin_password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Current password of the SSO administrator of the SSO domain of the workload domain, which is the one of the management domain if the workload domain joined it. Unset if SDDC Manager does not manage the credentials",
+			},
+			"host_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the hosts of all clusters of the domain",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"is_management_sso_domain": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -86,10 +110,21 @@ func dataSourceDomainRead(ctx context.Context, data *schema.ResourceData, meta i
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 	domainId := data.Get("domain_id").(string)
+	if domainId == "" {
+		var err error
+		domainId, err = domain.GetDomainIdByName(ctx, data.Get("name").(string), apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
 
 	_, err := domain.ImportDomain(ctx, data, apiClient, domainId, true)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	_ = data.Set("domain_id", domainId)
+	if err = domain.SetDomainHostIds(ctx, domainId, data, apiClient); err != nil {
+		return diag.FromErr(err)
+	}
 	return nil
 }
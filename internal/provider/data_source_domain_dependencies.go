@@ -0,0 +1,201 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/client/nsxt_clusters"
+	"sort"
+	"time"
+)
+
+func DataSourceDomainDependencies() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceDomainDependenciesRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the domain whose dependent resources are reported",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"cluster_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the clusters that are removed together with the domain",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"host": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Hosts that are released from the domain and returned to their network pools",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the host",
+						},
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Fully qualified domain name of the host",
+						},
+						"cluster_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the cluster the host belongs to",
+						},
+						"network_pool_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the network pool the host is associated with",
+						},
+					},
+				},
+			},
+			"network_pool_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the network pools referenced by the hosts of the domain",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"nsx_cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the NSX Manager cluster of the domain",
+			},
+			"nsx_shared_domain_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the other domains that share the NSX Manager cluster of the domain",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"safe_to_destroy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the domain can be destroyed without affecting resources outside of it",
+			},
+			"warnings": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Reasons for which destroying the domain affects resources outside of it",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDomainDependenciesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+	domainId := data.Get("domain_id").(string)
+
+	getDomainParams := domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getDomainParams.ID = domainId
+	domainResult, err := apiClient.Domains.GetDomain(getDomainParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	domain := domainResult.Payload
+
+	var warnings []string
+	if domain.Type == "MANAGEMENT" {
+		warnings = append(warnings, "the management domain cannot be destroyed")
+	}
+
+	var clusterIds []string
+	for _, clusterReference := range domain.Clusters {
+		if clusterReference.ID != nil {
+			clusterIds = append(clusterIds, *clusterReference.ID)
+		}
+	}
+	_ = data.Set("cluster_ids", clusterIds)
+
+	getHostsParams := hosts.NewGetHostsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getHostsParams.DomainID = &domainId
+	hostsResult, err := apiClient.Hosts.GetHosts(getHostsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	domainHosts := hostsResult.Payload.Elements
+	// Sort for reproducibility, the backend API returns hosts in random order
+	sort.SliceStable(domainHosts, func(i, j int) bool {
+		return domainHosts[i].Fqdn < domainHosts[j].Fqdn
+	})
+	flattenedHosts := *new([]map[string]interface{})
+	var networkPoolIds []string
+	seenNetworkPoolIds := make(map[string]bool)
+	for _, host := range domainHosts {
+		flattenedHost := map[string]interface{}{
+			"id":   host.ID,
+			"fqdn": host.Fqdn,
+		}
+		if host.Cluster != nil && host.Cluster.ID != nil {
+			flattenedHost["cluster_id"] = *host.Cluster.ID
+		}
+		if host.Networkpool != nil && host.Networkpool.ID != nil {
+			flattenedHost["network_pool_id"] = *host.Networkpool.ID
+			if !seenNetworkPoolIds[*host.Networkpool.ID] {
+				seenNetworkPoolIds[*host.Networkpool.ID] = true
+				networkPoolIds = append(networkPoolIds, *host.Networkpool.ID)
+			}
+		}
+		flattenedHosts = append(flattenedHosts, flattenedHost)
+	}
+	_ = data.Set("host", flattenedHosts)
+	sort.Strings(networkPoolIds)
+	_ = data.Set("network_pool_ids", networkPoolIds)
+
+	var nsxSharedDomainIds []string
+	if domain.NSXTCluster != nil {
+		_ = data.Set("nsx_cluster_id", domain.NSXTCluster.ID)
+
+		getNsxTClusterParams := nsxt_clusters.NewGetNSXTClusterParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(domain.NSXTCluster.ID)
+		nsxtClusterResult, err := apiClient.NSXTClusters.GetNSXTCluster(getNsxTClusterParams)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		for _, domainReference := range nsxtClusterResult.Payload.Domains {
+			if domainReference.ID != nil && *domainReference.ID != domainId {
+				nsxSharedDomainIds = append(nsxSharedDomainIds, *domainReference.ID)
+			}
+		}
+		sort.Strings(nsxSharedDomainIds)
+	}
+	_ = data.Set("nsx_shared_domain_ids", nsxSharedDomainIds)
+	if len(nsxSharedDomainIds) > 0 {
+		warnings = append(warnings, fmt.Sprintf("the NSX Manager cluster %s is shared with domains %v",
+			domain.NSXTCluster.ID, nsxSharedDomainIds))
+	}
+
+	_ = data.Set("warnings", warnings)
+	_ = data.Set("safe_to_destroy", len(warnings) == 0)
+
+	data.SetId("domain-dependencies:" + domainId)
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"os"
+	"testing"
+)
+
+func TestAccDataSourceVcfDomainDependencies(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfDomainDependenciesDataSourceConfig(
+					os.Getenv(constants.VcfTestDomainDataSourceId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_domain_dependencies.dependencies", "id"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain_dependencies.dependencies", "cluster_ids.0"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain_dependencies.dependencies", "host.0.fqdn"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain_dependencies.dependencies", "network_pool_ids.0"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain_dependencies.dependencies", "nsx_cluster_id"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain_dependencies.dependencies", "safe_to_destroy"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfDomainDependenciesDataSourceConfig(domainId string) string {
+	return fmt.Sprintf(`
+	data "vcf_domain_dependencies" "dependencies" {
+		domain_id = %q
+	}`, domainId)
+}
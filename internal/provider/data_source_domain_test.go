@@ -24,6 +24,7 @@ func TestAccDataSourceVcfDomain(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "vcenter_configuration.0.id"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "vcenter_configuration.0.fqdn"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "vcenter_configuration.0.version"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "status"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "type"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "sso_id"),
@@ -41,9 +42,21 @@ func TestAccDataSourceVcfDomain(t *testing.T) {
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.id"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.vip"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.vip_fqdn"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.version"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.nsx_manager_node.0.name"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.nsx_manager_node.0.ip_address"),
 					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "nsx_configuration.0.nsx_manager_node.0.fqdn"),
+					resource.TestCheckResourceAttrSet("data.vcf_domain.domain1", "host_ids.0"),
+				),
+			},
+			{
+				Config: testAccVcfDomainDataSourceByNameConfig(
+					os.Getenv(constants.VcfTestDomainDataSourceId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vcf_domain.domain2", "domain_id",
+						"data.vcf_domain.domain1", "domain_id"),
+					resource.TestCheckResourceAttrPair("data.vcf_domain.domain2", "vcenter_configuration.0.id",
+						"data.vcf_domain.domain1", "vcenter_configuration.0.id"),
 				),
 			},
 		},
@@ -56,3 +69,14 @@ func testAccVcfDomainDataSourceConfig(domainId string) string {
 		domain_id = %q
 	}`, domainId)
 }
+
+func testAccVcfDomainDataSourceByNameConfig(domainId string) string {
+	return fmt.Sprintf(`
+	data "vcf_domain" "domain1" {
+		domain_id = %q
+	}
+
+	data "vcf_domain" "domain2" {
+		name = data.vcf_domain.domain1.name
+	}`, domainId)
+}
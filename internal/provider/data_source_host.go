@@ -0,0 +1,264 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+var hostStatuses = []string{"ASSIGNED", "UNASSIGNED_USEABLE", "UNASSIGNED_UNUSEABLE"}
+
+func DataSourceHost() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHostRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"fqdn": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only the host with the given fully qualified domain name",
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only hosts with the given status. One among: ASSIGNED, UNASSIGNED_USEABLE, UNASSIGNED_UNUSEABLE",
+				ValidateFunc: validation.StringInSlice(hostStatuses, false),
+			},
+			"storage_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Return only hosts commissioned with the given storage type. One among: VSAN, VSAN_REMOTE, NFS, VMFS_FC, VVOL",
+				ValidateFunc: validation.StringInSlice(hostStorageTypes, false),
+			},
+			"network_pool_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only hosts associated with the given network pool",
+			},
+			"domain_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Return only hosts assigned to the given workload domain",
+			},
+			"ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "IDs of the matching hosts, sorted by FQDN",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"hosts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Matching hosts, sorted by FQDN",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the host",
+						},
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Fully qualified domain name of the host",
+						},
+						"ip_address": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Management IP address of the host",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Assignable status of the host",
+						},
+						"storage_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Storage type the host is compatible with",
+						},
+						"esxi_version": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Version of ESXi running on the host",
+						},
+						"hardware_vendor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hardware vendor of the host",
+						},
+						"hardware_model": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Hardware model of the host",
+						},
+						"cpu_cores": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of CPU cores of the host",
+						},
+						"memory_mb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Total memory of the host in MB",
+						},
+						"storage_mb": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Total capacity of the local disks of the host in MB",
+						},
+						"physical_nics": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Names of the physical NICs of the host, e.g. vmnic0",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"network_pool_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the network pool the host is associated with",
+						},
+						"network_pool_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the network pool the host is associated with",
+						},
+						"domain_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the workload domain the host is assigned to",
+						},
+						"cluster_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the cluster the host is assigned to",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHostRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getHostsParams := hosts.NewGetHostsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	var filters []string
+	if status, ok := data.GetOk("status"); ok {
+		getHostsParams.Status = resource_utils.ToStringPointer(status)
+		filters = append(filters, "status="+status.(string))
+	}
+	if storageType, ok := data.GetOk("storage_type"); ok {
+		getHostsParams.StorageType = resource_utils.ToStringPointer(storageType)
+		filters = append(filters, "storage_type="+storageType.(string))
+	}
+	if networkPoolId, ok := data.GetOk("network_pool_id"); ok {
+		getHostsParams.NetworkpoolID = resource_utils.ToStringPointer(networkPoolId)
+		filters = append(filters, "network_pool_id="+networkPoolId.(string))
+	}
+	if domainId, ok := data.GetOk("domain_id"); ok {
+		getHostsParams.DomainID = resource_utils.ToStringPointer(domainId)
+		filters = append(filters, "domain_id="+domainId.(string))
+	}
+
+	hostsResult, err := apiClient.Hosts.GetHosts(getHostsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	fqdn := data.Get("fqdn").(string)
+	if fqdn != "" {
+		filters = append(filters, "fqdn="+fqdn)
+	}
+	var matchingHosts []*models.Host
+	for _, host := range hostsResult.Payload.Elements {
+		if host == nil || (fqdn != "" && !strings.EqualFold(host.Fqdn, fqdn)) {
+			continue
+		}
+		matchingHosts = append(matchingHosts, host)
+	}
+	sort.SliceStable(matchingHosts, func(i, j int) bool {
+		return matchingHosts[i].Fqdn < matchingHosts[j].Fqdn
+	})
+
+	ids := *new([]string)
+	flattenedHosts := *new([]map[string]interface{})
+	for _, host := range matchingHosts {
+		ids = append(ids, host.ID)
+		flattenedHosts = append(flattenedHosts, flattenHost(host))
+	}
+	_ = data.Set("ids", ids)
+	_ = data.Set("hosts", flattenedHosts)
+
+	data.SetId("hosts:" + strings.Join(filters, ","))
+	return nil
+}
+
+func flattenHost(host *models.Host) map[string]interface{} {
+	result := map[string]interface{}{
+		"id":              host.ID,
+		"fqdn":            host.Fqdn,
+		"status":          host.Status,
+		"storage_type":    host.CompatibleStorageType,
+		"esxi_version":    host.EsxiVersion,
+		"hardware_vendor": host.HardwareVendor,
+		"hardware_model":  host.HardwareModel,
+	}
+	for _, ipAddress := range host.IPAddresses {
+		if ipAddress != nil && strings.EqualFold(ipAddress.Type, "MANAGEMENT") {
+			result["ip_address"] = ipAddress.IPAddress
+		}
+	}
+	if host.CPU != nil {
+		result["cpu_cores"] = int(host.CPU.Cores)
+	}
+	if host.Memory != nil {
+		result["memory_mb"] = int(host.Memory.TotalCapacityMB)
+	}
+	if host.Storage != nil {
+		result["storage_mb"] = int(host.Storage.TotalCapacityMB)
+	}
+	var physicalNics []string
+	for _, physicalNic := range host.PhysicalNics {
+		if physicalNic != nil {
+			physicalNics = append(physicalNics, physicalNic.DeviceName)
+		}
+	}
+	result["physical_nics"] = physicalNics
+	if host.Networkpool != nil {
+		if host.Networkpool.ID != nil {
+			result["network_pool_id"] = *host.Networkpool.ID
+		}
+		result["network_pool_name"] = host.Networkpool.Name
+	}
+	if host.Domain != nil && host.Domain.ID != nil {
+		result["domain_id"] = *host.Domain.ID
+	}
+	if host.Cluster != nil && host.Cluster.ID != nil {
+		result["cluster_id"] = *host.Cluster.ID
+	}
+
+	return result
+}
@@ -0,0 +1,71 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestAccDataSourceVcfHost(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfHostDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_host.assigned_hosts", "ids.0"),
+					resource.TestCheckResourceAttr("data.vcf_host.assigned_hosts", "hosts.0.status", "ASSIGNED"),
+					resource.TestCheckResourceAttrSet("data.vcf_host.assigned_hosts", "hosts.0.fqdn"),
+					resource.TestCheckResourceAttrSet("data.vcf_host.assigned_hosts", "hosts.0.network_pool_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfHostDataSourceConfig() string {
+	return `
+	data "vcf_host" "assigned_hosts" {
+		status = "ASSIGNED"
+	}`
+}
+
+func TestFlattenHost(t *testing.T) {
+	networkPoolId := "pool-1"
+	host := &models.Host{
+		ID:     "host-1",
+		Fqdn:   "esxi-1.vrack.vsphere.local",
+		Status: "UNASSIGNED_USEABLE",
+		IPAddresses: []*models.IPAddress{
+			{IPAddress: "10.0.0.100", Type: "VSAN"},
+			{IPAddress: "10.0.0.1", Type: "MANAGEMENT"},
+		},
+		CPU:          &models.CPU{Cores: 16},
+		Memory:       &models.Memory{TotalCapacityMB: 262144},
+		PhysicalNics: []*models.PhysicalNic{{DeviceName: "vmnic0"}, {DeviceName: "vmnic1"}},
+		Networkpool:  &models.NetworkPoolReference{ID: &networkPoolId, Name: "pool"},
+	}
+
+	flattenedHost := flattenHost(host)
+	if flattenedHost["ip_address"] != "10.0.0.1" {
+		t.Errorf("expected the management IP address, got %v", flattenedHost["ip_address"])
+	}
+	if flattenedHost["cpu_cores"] != 16 || flattenedHost["memory_mb"] != 262144 {
+		t.Errorf("unexpected hardware info %v and %v", flattenedHost["cpu_cores"], flattenedHost["memory_mb"])
+	}
+	if physicalNics := flattenedHost["physical_nics"].([]string); len(physicalNics) != 2 || physicalNics[1] != "vmnic1" {
+		t.Errorf("unexpected physical NICs %v", physicalNics)
+	}
+	if flattenedHost["network_pool_id"] != networkPoolId {
+		t.Errorf("expected network pool %s, got %v", networkPoolId, flattenedHost["network_pool_id"])
+	}
+	if _, ok := flattenedHost["cluster_id"]; ok {
+		t.Error("expected no cluster for an unassigned host")
+	}
+}
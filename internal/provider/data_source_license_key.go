@@ -0,0 +1,132 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func DataSourceLicenseKey() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLicenseKeyRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"product_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The type of the product to which the license key is applicable. One among: VCENTER, VSAN, ESXI, NSXT, NSXIO, WCP, HORIZON_VIEW",
+				ValidateFunc: validation.StringInSlice(licenseProductTypes, false),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Description of the license key. Required when several license keys are registered for the product type",
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The 29 alpha numeric character license key with hyphens",
+			},
+			"is_unlimited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates if the license key has unlimited usage",
+			},
+			"license_unit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Units of the license key, e.g. CPUPACKAGE, INSTANCE, CORES",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total units of the license key",
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The consumed units of the license key",
+			},
+			"remaining": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The remaining units of the license key",
+			},
+			"expiry_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license key expiry date",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The validity status of the license key. One among: EXPIRED, ACTIVE, NEVER_EXPIRES",
+			},
+		},
+	}
+}
+
+func dataSourceLicenseKeyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	productType := data.Get("product_type").(string)
+	getLicenseKeysParams := license_keys.NewGetLicenseKeysParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithProductType([]string{productType})
+
+	licenseKeysResult, err := apiClient.LicenseKeys.GetLicenseKeys(getLicenseKeysParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	licenseKey, err := findLicenseKey(licenseKeysResult.Payload.Elements, productType, data.Get("description").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = setLicenseKeyAttributes(data, licenseKey); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// findLicenseKey returns the only license key of the product type productType, or the one with the
+// given description if not empty.
+func findLicenseKey(licenseKeys []*models.LicenseKey, productType, description string) (*models.LicenseKey, error) {
+	var matchingLicenseKeys []*models.LicenseKey
+	for _, licenseKey := range licenseKeys {
+		if licenseKey == nil || licenseKey.ProductType == nil || *licenseKey.ProductType != productType {
+			continue
+		}
+		if description != "" && (licenseKey.Description == nil || *licenseKey.Description != description) {
+			continue
+		}
+		matchingLicenseKeys = append(matchingLicenseKeys, licenseKey)
+	}
+	if len(matchingLicenseKeys) == 0 {
+		if description != "" {
+			return nil, fmt.Errorf("no %s license key with description %q found", productType, description)
+		}
+		return nil, fmt.Errorf("no %s license key found", productType)
+	}
+	if len(matchingLicenseKeys) > 1 {
+		return nil, fmt.Errorf("found %d %s license keys, set \"description\" to select one of them",
+			len(matchingLicenseKeys), productType)
+	}
+	return matchingLicenseKeys[0], nil
+}
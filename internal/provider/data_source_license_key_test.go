@@ -0,0 +1,35 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestFindLicenseKey(t *testing.T) {
+	newLicenseKey := func(id, productType, description string) *models.LicenseKey {
+		return &models.LicenseKey{ID: id, ProductType: &productType, Description: &description}
+	}
+	licenseKeys := []*models.LicenseKey{
+		newLicenseKey("1", "ESXI", "management domain"),
+		newLicenseKey("2", "VSAN", "management domain"),
+		newLicenseKey("3", "VSAN", "workload domain"),
+	}
+
+	if licenseKey, err := findLicenseKey(licenseKeys, "ESXI", ""); err != nil || licenseKey.ID != "1" {
+		t.Errorf("expected license key 1, got %v, %v", licenseKey, err)
+	}
+	if licenseKey, err := findLicenseKey(licenseKeys, "VSAN", "workload domain"); err != nil || licenseKey.ID != "3" {
+		t.Errorf("expected license key 3, got %v, %v", licenseKey, err)
+	}
+	if _, err := findLicenseKey(licenseKeys, "VSAN", ""); err == nil {
+		t.Error("expected an error for several matching license keys")
+	}
+	if _, err := findLicenseKey(licenseKeys, "NSXT", ""); err == nil {
+		t.Error("expected an error for no matching license key")
+	}
+}
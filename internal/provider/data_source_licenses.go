@@ -0,0 +1,170 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"github.com/vmware/vcf-sdk-go/models"
+	"sort"
+	"strings"
+	"time"
+)
+
+var licenseProductTypes = []string{"VCENTER", "VSAN", "ESXI", "NSXT", "NSXIO", "WCP", "HORIZON_VIEW"}
+
+func DataSourceLicenses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceLicensesRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"product_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Return only license keys for the given product types. One among: VCENTER, VSAN, ESXI, NSXT, NSXIO, WCP, HORIZON_VIEW",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(licenseProductTypes, false),
+				},
+			},
+			"license_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "License keys registered in SDDC Manager",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the license key",
+						},
+						"key": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+							Description: "The 29 alpha numeric character license key with hyphens",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the license key",
+						},
+						"product_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the product to which the license key is applicable",
+						},
+						"is_unlimited": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Indicates if the license key has unlimited usage",
+						},
+						"license_unit": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Units of the license key, e.g. CPUPACKAGE, INSTANCE, CORES",
+						},
+						"total": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The total units of the license key",
+						},
+						"used": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The consumed units of the license key",
+						},
+						"remaining": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The remaining units of the license key",
+						},
+						"expiry_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The license key expiry date",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The validity status of the license key. One among: EXPIRED, ACTIVE, NEVER_EXPIRES",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceLicensesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	productTypes := resource_utils.ToStringSlice(data.Get("product_types").([]interface{}))
+	getLicenseKeysParams := license_keys.NewGetLicenseKeysParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	if len(productTypes) > 0 {
+		getLicenseKeysParams.ProductType = productTypes
+	}
+
+	licenseKeysResult, err := apiClient.LicenseKeys.GetLicenseKeys(getLicenseKeysParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	licenseKeys := licenseKeysResult.Payload.Elements
+	// Sort for reproducibility, the backend API returns license keys in random order
+	sort.SliceStable(licenseKeys, func(i, j int) bool {
+		return licenseKeys[i].ID < licenseKeys[j].ID
+	})
+	flattenedLicenseKeys := *new([]map[string]interface{})
+	for _, licenseKey := range licenseKeys {
+		flattenedLicenseKeys = append(flattenedLicenseKeys, flattenLicenseKey(licenseKey))
+	}
+	_ = data.Set("license_keys", flattenedLicenseKeys)
+
+	sort.Strings(productTypes)
+	data.SetId("licenses:" + strings.Join(productTypes, ","))
+
+	return nil
+}
+
+func flattenLicenseKey(licenseKey *models.LicenseKey) map[string]interface{} {
+	result := make(map[string]interface{})
+	if licenseKey == nil {
+		return result
+	}
+	result["id"] = licenseKey.ID
+	if licenseKey.Key != nil {
+		result["key"] = *licenseKey.Key
+	}
+	if licenseKey.Description != nil {
+		result["description"] = *licenseKey.Description
+	}
+	if licenseKey.ProductType != nil {
+		result["product_type"] = *licenseKey.ProductType
+	}
+	result["is_unlimited"] = licenseKey.IsUnlimited
+	if licenseKey.LicenseKeyUsage != nil {
+		result["license_unit"] = licenseKey.LicenseKeyUsage.LicenseUnit
+		result["total"] = int(licenseKey.LicenseKeyUsage.Total)
+		result["used"] = int(licenseKey.LicenseKeyUsage.Used)
+		result["remaining"] = int(licenseKey.LicenseKeyUsage.Remaining)
+	}
+	if licenseKey.LicenseKeyValidity != nil {
+		result["expiry_date"] = licenseKey.LicenseKeyValidity.ExpiryDate
+		result["status"] = licenseKey.LicenseKeyValidity.LicenseKeyStatus
+	}
+
+	return result
+}
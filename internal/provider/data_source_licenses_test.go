@@ -0,0 +1,36 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDataSourceVcfLicenses(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfLicensesDataSourceConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_licenses.esxi_licenses", "license_keys.0.id"),
+					resource.TestCheckResourceAttrSet("data.vcf_licenses.esxi_licenses", "license_keys.0.key"),
+					resource.TestCheckResourceAttr("data.vcf_licenses.esxi_licenses", "license_keys.0.product_type", "ESXI"),
+					resource.TestCheckResourceAttrSet("data.vcf_licenses.esxi_licenses", "license_keys.0.status"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfLicensesDataSourceConfig() string {
+	return `
+	data "vcf_licenses" "esxi_licenses" {
+		product_types = ["ESXI"]
+	}`
+}
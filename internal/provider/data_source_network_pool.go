@@ -0,0 +1,176 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/network_pools"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func DataSourceNetworkPool() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkPoolRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the network pool",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"network": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Networks of the network pool",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the network",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Network Type of the network, e.g. VSAN, VMOTION, NFS",
+						},
+						"gateway": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Gateway for the network",
+						},
+						"mask": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subnet mask for the subnet of the network",
+						},
+						"mtu": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "MTU of the network",
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Subnet associated with the network",
+						},
+						"vlan_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "VLAN ID associated with the network",
+						},
+						"free_ip_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of IP addresses of the IP pools of the network that are not in use",
+						},
+						"ip_pools": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "IP pool ranges of the network",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"start": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "Start IP address of the IP pool",
+									},
+									"end": {
+										Type:        schema.TypeString,
+										Computed:    true,
+										Description: "End IP address of the IP pool",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkPoolRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+	name := data.Get("name").(string)
+
+	getNetworkPoolsParams := network_pools.NewGetNetworkPoolsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	networkPoolsResult, err := apiClient.NetworkPools.GetNetworkPools(getNetworkPoolsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	networkPoolId := ""
+	for _, networkPool := range networkPoolsResult.Payload.Elements {
+		if networkPool != nil && networkPool.Name == name {
+			networkPoolId = networkPool.ID
+			break
+		}
+	}
+	if len(networkPoolId) == 0 {
+		return diag.Errorf("network pool %q not found", name)
+	}
+
+	// the networks of the network pool list lack the IP addresses in use
+	getNetworksParams := network_pools.NewGetNetworksOfNetworkPoolParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(networkPoolId)
+	networksResult, err := apiClient.NetworkPools.GetNetworksOfNetworkPool(getNetworksParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	flattenedNetworks, err := flattenNetworkPoolNetworks(networksResult.Payload.Elements)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("network", flattenedNetworks)
+	data.SetId(networkPoolId)
+
+	return nil
+}
+
+func flattenNetworkPoolNetworks(networks []*models.Network) ([]map[string]interface{}, error) {
+	flattenedNetworks := *new([]map[string]interface{})
+	for _, network := range networks {
+		if network == nil {
+			continue
+		}
+		ipPoolAllocations, err := getIpPoolAllocations(network.IPPools, network.UsedIps)
+		if err != nil {
+			return nil, err
+		}
+		freeIpCount := 0
+		var ipPools []map[string]interface{}
+		for _, ipPoolAllocation := range ipPoolAllocations {
+			freeIpCount += ipPoolAllocation["free_ip_count"].(int)
+			ipPools = append(ipPools, map[string]interface{}{
+				"start": ipPoolAllocation["start"],
+				"end":   ipPoolAllocation["end"],
+			})
+		}
+		flattenedNetworks = append(flattenedNetworks, map[string]interface{}{
+			"id":            network.ID,
+			"type":          network.Type,
+			"gateway":       network.Gateway,
+			"mask":          network.Mask,
+			"mtu":           int(network.Mtu),
+			"subnet":        network.Subnet,
+			"vlan_id":       int(network.VlanID),
+			"free_ip_count": freeIpCount,
+			"ip_pools":      ipPools,
+		})
+	}
+	return flattenedNetworks, nil
+}
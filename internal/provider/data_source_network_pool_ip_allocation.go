@@ -0,0 +1,218 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/network_pools"
+	"github.com/vmware/vcf-sdk-go/models"
+	"math"
+	"math/big"
+	"net/netip"
+	"sort"
+	"time"
+)
+
+// maxFreeIpCount caps the number of free IP addresses reported for an IP pool, as IPv6 ranges
+// can hold more addresses than an integer attribute does.
+const maxFreeIpCount = math.MaxInt32
+
+func DataSourceNetworkPoolIpAllocation() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNetworkPoolIpAllocationRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"network_pool_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the network pool",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"network_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Network Type of the network in the network pool, e.g. VSAN, VMOTION, NFS",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"network_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the network",
+			},
+			"next_free_ip": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The first IP address of the IP pools of the network that is not in use. Empty if all IP addresses are in use",
+			},
+			"free_ip_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of IP addresses of the IP pools of the network that are not in use, capped at 2147483647",
+			},
+			"ip_pools": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Allocation state of the IP pool ranges of the network",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Start IP address of the IP pool",
+						},
+						"end": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "End IP address of the IP pool",
+						},
+						"next_free_ip": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The first IP address of the IP pool that is not in use. Empty if all IP addresses are in use",
+						},
+						"free_ip_count": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of IP addresses of the IP pool that are not in use, capped at 2147483647",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceNetworkPoolIpAllocationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+	networkPoolId := data.Get("network_pool_id").(string)
+	networkType := data.Get("network_type").(string)
+
+	getNetworksParams := network_pools.NewGetNetworksOfNetworkPoolParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getNetworksParams.ID = networkPoolId
+	networksResult, err := apiClient.NetworkPools.GetNetworksOfNetworkPool(getNetworksParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var network *models.Network
+	for _, candidate := range networksResult.Payload.Elements {
+		if candidate != nil && candidate.Type == networkType {
+			network = candidate
+			break
+		}
+	}
+	if network == nil {
+		return diag.FromErr(fmt.Errorf("network pool %q has no network of type %q", networkPoolId, networkType))
+	}
+	_ = data.Set("network_id", network.ID)
+
+	ipPoolAllocations, err := getIpPoolAllocations(network.IPPools, network.UsedIps)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	nextFreeIp := ""
+	freeIpCount := 0
+	for _, ipPoolAllocation := range ipPoolAllocations {
+		if nextFreeIp == "" {
+			nextFreeIp = ipPoolAllocation["next_free_ip"].(string)
+		}
+		freeIpCount += ipPoolAllocation["free_ip_count"].(int)
+		if freeIpCount > maxFreeIpCount {
+			freeIpCount = maxFreeIpCount
+		}
+	}
+	_ = data.Set("ip_pools", ipPoolAllocations)
+	_ = data.Set("next_free_ip", nextFreeIp)
+	_ = data.Set("free_ip_count", freeIpCount)
+
+	data.SetId(networkPoolId + ":" + network.ID)
+	return nil
+}
+
+// getIpPoolAllocations calculates the first free IP address and the number of free IP addresses
+// of every IP pool range, based on the IP addresses in use. Only the bounds of the ranges and the
+// IP addresses in use are compared, so that large IPv6 ranges are not walked address by address.
+func getIpPoolAllocations(ipPools []*models.IPPool, usedIps []string) ([]map[string]interface{}, error) {
+	usedAddresses := make([]netip.Addr, 0, len(usedIps))
+	seenAddresses := make(map[netip.Addr]bool, len(usedIps))
+	for _, usedIp := range usedIps {
+		usedAddress, err := netip.ParseAddr(usedIp)
+		if err != nil {
+			return nil, fmt.Errorf("invalid used IP address %q: %w", usedIp, err)
+		}
+		if !seenAddresses[usedAddress] {
+			seenAddresses[usedAddress] = true
+			usedAddresses = append(usedAddresses, usedAddress)
+		}
+	}
+	sort.Slice(usedAddresses, func(i, j int) bool {
+		return usedAddresses[i].Less(usedAddresses[j])
+	})
+
+	ipPoolAllocations := *new([]map[string]interface{})
+	for _, ipPool := range ipPools {
+		if ipPool == nil {
+			continue
+		}
+		start, err := netip.ParseAddr(ipPool.Start)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start IP address %q of IP pool: %w", ipPool.Start, err)
+		}
+		end, err := netip.ParseAddr(ipPool.End)
+		if err != nil {
+			return nil, fmt.Errorf("invalid end IP address %q of IP pool: %w", ipPool.End, err)
+		}
+		if start.BitLen() != end.BitLen() || end.Less(start) {
+			return nil, fmt.Errorf("invalid IP pool range %s - %s", ipPool.Start, ipPool.End)
+		}
+
+		// the used addresses are sorted, so the first free address is the first gap from the start
+		nextFree := start
+		usedCount := int64(0)
+		for _, usedAddress := range usedAddresses {
+			if usedAddress.BitLen() != start.BitLen() || usedAddress.Less(start) || end.Less(usedAddress) {
+				continue
+			}
+			usedCount++
+			if usedAddress == nextFree {
+				nextFree = nextFree.Next()
+			}
+		}
+		nextFreeIp := ""
+		if nextFree.IsValid() && !end.Less(nextFree) {
+			nextFreeIp = nextFree.String()
+		}
+
+		ipPoolAllocations = append(ipPoolAllocations, map[string]interface{}{
+			"start":         ipPool.Start,
+			"end":           ipPool.End,
+			"next_free_ip":  nextFreeIp,
+			"free_ip_count": getFreeIpCount(start, end, usedCount),
+		})
+	}
+	return ipPoolAllocations, nil
+}
+
+// getFreeIpCount returns the number of IP addresses in the range from start to end that are not
+// among the used ones, capped at maxFreeIpCount.
+func getFreeIpCount(start, end netip.Addr, usedCount int64) int {
+	startBytes, endBytes := start.As16(), end.As16()
+	freeIpCount := new(big.Int).Sub(new(big.Int).SetBytes(endBytes[:]), new(big.Int).SetBytes(startBytes[:]))
+	freeIpCount.Add(freeIpCount, big.NewInt(1-usedCount))
+	if freeIpCount.Cmp(big.NewInt(maxFreeIpCount)) > 0 {
+		return maxFreeIpCount
+	}
+	return int(freeIpCount.Int64())
+}
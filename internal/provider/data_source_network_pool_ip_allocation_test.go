@@ -0,0 +1,62 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestAccDataSourceVcfNetworkPoolIpAllocation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckVcfNetworkPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfNetworkPoolConfig("terraform-test-pool-ip-allocation") + `
+	data "vcf_network_pool_ip_allocation" "vsan" {
+		network_pool_id = vcf_network_pool.test_pool.id
+		network_type    = "VSAN"
+	}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_network_pool_ip_allocation.vsan", "network_id"),
+					resource.TestCheckResourceAttr("data.vcf_network_pool_ip_allocation.vsan", "next_free_ip", "192.168.4.5"),
+					resource.TestCheckResourceAttr("data.vcf_network_pool_ip_allocation.vsan", "free_ip_count", "46"),
+				),
+			},
+		},
+	})
+}
+
+func TestGetIpPoolAllocations(t *testing.T) {
+	ipPools := []*models.IPPool{
+		{Start: "192.168.4.5", End: "192.168.4.8"},
+		{Start: "192.168.4.20", End: "192.168.4.21"},
+	}
+	usedIps := []string{"192.168.4.5", "192.168.4.6", "192.168.4.20", "192.168.4.21"}
+
+	ipPoolAllocations, err := getIpPoolAllocations(ipPools, usedIps)
+	assert.NoError(t, err)
+	assert.Equal(t, "192.168.4.7", ipPoolAllocations[0]["next_free_ip"])
+	assert.Equal(t, 2, ipPoolAllocations[0]["free_ip_count"])
+	assert.Equal(t, "", ipPoolAllocations[1]["next_free_ip"])
+	assert.Equal(t, 0, ipPoolAllocations[1]["free_ip_count"])
+
+	_, err = getIpPoolAllocations(ipPools, []string{"not-an-ip"})
+	assert.Error(t, err)
+
+	_, err = getIpPoolAllocations([]*models.IPPool{{Start: "192.168.4.8", End: "192.168.4.5"}}, nil)
+	assert.Error(t, err)
+
+	ipv6Pools := []*models.IPPool{{Start: "2001:db8::1", End: "2001:db8::ffff:ffff:ffff"}}
+	ipPoolAllocations, err = getIpPoolAllocations(ipv6Pools, []string{"2001:db8::1", "2001:db8::2", "2001:db8::4"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::3", ipPoolAllocations[0]["next_free_ip"])
+	assert.Equal(t, maxFreeIpCount, ipPoolAllocations[0]["free_ip_count"])
+}
@@ -0,0 +1,67 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestAccDataSourceVcfNetworkPool(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckVcfNetworkPoolDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfNetworkPoolConfig("terraform-test-pool-lookup") + `
+	data "vcf_network_pool" "lookup" {
+		name = vcf_network_pool.test_pool.name
+	}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vcf_network_pool.lookup", "id", "vcf_network_pool.test_pool", "id"),
+					resource.TestCheckResourceAttr("data.vcf_network_pool.lookup", "network.#", "2"),
+					resource.TestCheckResourceAttr("data.vcf_network_pool.lookup", "network.0.free_ip_count", "46"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenNetworkPoolNetworks(t *testing.T) {
+	networks := []*models.Network{
+		{
+			ID:      "network-1",
+			Type:    "VSAN",
+			Gateway: "192.168.4.1",
+			Mask:    "255.255.255.0",
+			Mtu:     9000,
+			Subnet:  "192.168.4.0",
+			VlanID:  100,
+			IPPools: []*models.IPPool{
+				{Start: "192.168.4.5", End: "192.168.4.8"},
+				{Start: "192.168.4.20", End: "192.168.4.21"},
+			},
+			UsedIps: []string{"192.168.4.5", "192.168.4.20"},
+		},
+		nil,
+	}
+
+	flattenedNetworks, err := flattenNetworkPoolNetworks(networks)
+	assert.NoError(t, err)
+	assert.Len(t, flattenedNetworks, 1)
+	assert.Equal(t, "network-1", flattenedNetworks[0]["id"])
+	assert.Equal(t, 9000, flattenedNetworks[0]["mtu"])
+	assert.Equal(t, 100, flattenedNetworks[0]["vlan_id"])
+	assert.Equal(t, 4, flattenedNetworks[0]["free_ip_count"])
+	assert.Len(t, flattenedNetworks[0]["ip_pools"], 2)
+
+	networks[0].UsedIps = []string{"not-an-ip"}
+	_, err = flattenNetworkPoolNetworks(networks)
+	assert.Error(t, err)
+}
@@ -0,0 +1,108 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/network"
+	"github.com/vmware/vcf-sdk-go/client/certificates"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"strings"
+	"time"
+)
+
+func DataSourceNsxManager() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceNsxManagerRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the domain whose NSX Manager cluster is looked up",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"nsx_configuration": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Represents NSX Manager cluster references associated with the domain",
+				Elem:        network.NsxSchema(),
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the NSX Manager API, based on the FQDN of the cluster VIP",
+			},
+			"thumbprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Thumbprint of the SSL certificate served on the NSX Manager cluster VIP",
+			},
+			"thumbprint_algorithm": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Algorithm used to compute the thumbprint, e.g. SHA-256",
+			},
+		},
+	}
+}
+
+func dataSourceNsxManagerRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+	domainId := data.Get("domain_id").(string)
+
+	getDomainParams := domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getDomainParams.ID = domainId
+	domainResult, err := apiClient.Domains.GetDomain(getDomainParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	domain := domainResult.Payload
+	if domain.NSXTCluster == nil {
+		return diag.FromErr(fmt.Errorf("domain %q has no NSX Manager cluster", domainId))
+	}
+
+	flattenedNsxCluster, err := network.FlattenNsxClusterRef(ctx, domain.NSXTCluster, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("nsx_configuration", *flattenedNsxCluster)
+
+	vipFqdn := domain.NSXTCluster.VipFqdn
+	_ = data.Set("url", "https://"+vipFqdn)
+
+	viewCertificateParams := certificates.NewViewCertificateParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	viewCertificateParams.DomainName = domain.Name
+	certificatesResult, err := apiClient.Certificates.ViewCertificate(viewCertificateParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	for _, certificate := range certificatesResult.Payload.Elements {
+		if certificate.IssuedTo == nil || !strings.EqualFold(*certificate.IssuedTo, vipFqdn) {
+			continue
+		}
+		if certificate.Thumbprint != nil {
+			_ = data.Set("thumbprint", *certificate.Thumbprint)
+		}
+		if certificate.ThumbprintAlgorithm != nil {
+			_ = data.Set("thumbprint_algorithm", *certificate.ThumbprintAlgorithm)
+		}
+		break
+	}
+
+	data.SetId(domain.NSXTCluster.ID)
+	return nil
+}
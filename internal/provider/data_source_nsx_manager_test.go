@@ -0,0 +1,41 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"os"
+	"testing"
+)
+
+func TestAccDataSourceVcfNsxManager(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfNsxManagerDataSourceConfig(
+					os.Getenv(constants.VcfTestDomainDataSourceId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_nsx_manager.nsx", "id"),
+					resource.TestCheckResourceAttrSet("data.vcf_nsx_manager.nsx", "url"),
+					resource.TestCheckResourceAttrSet("data.vcf_nsx_manager.nsx", "thumbprint"),
+					resource.TestCheckResourceAttrSet("data.vcf_nsx_manager.nsx", "nsx_configuration.0.vip_fqdn"),
+					resource.TestCheckResourceAttrSet("data.vcf_nsx_manager.nsx", "nsx_configuration.0.nsx_manager_node.0.fqdn"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfNsxManagerDataSourceConfig(domainId string) string {
+	return fmt.Sprintf(`
+	data "vcf_nsx_manager" "nsx" {
+		domain_id = %q
+	}`, domainId)
+}
@@ -0,0 +1,146 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/personalities"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func DataSourcePersonality() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePersonalityRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"personality_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "ID of the personality",
+				ValidateFunc: validation.NoZeroValues,
+				ExactlyOneOf: []string{"personality_id", "name"},
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Name of the personality",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"display_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Display name of the personality",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the personality",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the personality",
+			},
+			"base_image_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the ESXi base image of the personality",
+			},
+			"add_on_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the vendor add-on of the personality",
+			},
+			"add_on_version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the vendor add-on of the personality",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "User who uploaded the personality",
+			},
+		},
+	}
+}
+
+func dataSourcePersonalityRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	var personality *models.Personality
+	if personalityId, ok := data.GetOk("personality_id"); ok {
+		getPersonalityParams := personalities.NewGetPersonalityParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout)
+		getPersonalityParams.PersonalityID = personalityId.(string)
+		personalityResult, err := apiClient.Personalities.GetPersonality(getPersonalityParams)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		personality = personalityResult.Payload
+	} else {
+		name := data.Get("name").(string)
+		getPersonalitiesParams := personalities.NewGetPersonalitiesParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout)
+		getPersonalitiesParams.PersonalityName = &name
+		personalitiesResult, err := apiClient.Personalities.GetPersonalities(getPersonalitiesParams)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		personality = findPersonalityByName(personalitiesResult.Payload, name)
+		if personality == nil {
+			return diag.FromErr(fmt.Errorf("personality %q not found", name))
+		}
+	}
+
+	if err := setPersonalityAttributes(data, personality); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func findPersonalityByName(personalityList []*models.Personality, name string) *models.Personality {
+	for _, personality := range personalityList {
+		if personality != nil && personality.PersonalityName != nil && *personality.PersonalityName == name {
+			return personality
+		}
+	}
+	return nil
+}
+
+func setPersonalityAttributes(data *schema.ResourceData, personality *models.Personality) error {
+	if personality.PersonalityID == nil {
+		return fmt.Errorf("personality has no ID")
+	}
+	data.SetId(*personality.PersonalityID)
+	_ = data.Set("personality_id", *personality.PersonalityID)
+	_ = data.Set("name", personality.PersonalityName)
+	_ = data.Set("display_name", personality.DisplayName)
+	_ = data.Set("description", personality.Description)
+	_ = data.Set("version", personality.Version)
+	_ = data.Set("created_by", personality.CreatedBy)
+	if personality.SoftwareInfo != nil {
+		if personality.SoftwareInfo.BaseImage != nil {
+			_ = data.Set("base_image_version", personality.SoftwareInfo.BaseImage.Version)
+		}
+		if personality.SoftwareInfo.AddOn != nil {
+			_ = data.Set("add_on_name", personality.SoftwareInfo.AddOn.Name)
+			_ = data.Set("add_on_version", personality.SoftwareInfo.AddOn.Version)
+		}
+	}
+	return nil
+}
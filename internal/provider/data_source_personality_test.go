@@ -0,0 +1,60 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/models"
+	"os"
+	"testing"
+)
+
+func TestAccDataSourceVcfPersonality(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfPersonalityDataSourceConfig(os.Getenv(constants.VcfTestRemediationPersonalityId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_personality.by_id", "name"),
+					resource.TestCheckResourceAttrSet("data.vcf_personality.by_id", "base_image_version"),
+					resource.TestCheckResourceAttrPair("data.vcf_personality.by_name", "personality_id",
+						"data.vcf_personality.by_id", "personality_id"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfPersonalityDataSourceConfig(personalityId string) string {
+	return fmt.Sprintf(`
+	data "vcf_personality" "by_id" {
+		personality_id = %q
+	}
+
+	data "vcf_personality" "by_name" {
+		name = data.vcf_personality.by_id.name
+	}`, personalityId)
+}
+
+func TestFindPersonalityByName(t *testing.T) {
+	newPersonality := func(id, name string) *models.Personality {
+		return &models.Personality{PersonalityID: &id, PersonalityName: &name}
+	}
+	personalityList := []*models.Personality{
+		newPersonality("personality-1", "vsphere-8.0u1"),
+		newPersonality("personality-2", "vsphere-8.0u1-vendor"),
+	}
+	if personality := findPersonalityByName(personalityList, "vsphere-8.0u1-vendor"); personality == nil || *personality.PersonalityID != "personality-2" {
+		t.Errorf("expected personality-2, got %v", personality)
+	}
+	if personality := findPersonalityByName(personalityList, "vsphere-7.0u3"); personality != nil {
+		t.Errorf("expected no personality, got %v", personality)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/sddc"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+)
+
+func DataSourceResourcePoolTemplate() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceResourcePoolTemplateRead,
+		Schema: map[string]*schema.Schema{
+			"name_prefix": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Prefix of the generated resource pool names, e.g. sfo-m01-cl01",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"management_shares_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "high",
+				Description:  "CPU and memory shares level of the management resource pool, default 'high', possible values: \"custom\", \"high\", \"low\", \"normal\"",
+				ValidateFunc: validation.StringInSlice(sddc.SharesLevelValues, false),
+			},
+			"network_shares_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "high",
+				Description:  "CPU and memory shares level of the network resource pool, default 'high', possible values: \"custom\", \"high\", \"low\", \"normal\"",
+				ValidateFunc: validation.StringInSlice(sddc.SharesLevelValues, false),
+			},
+			"compute_shares_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "normal",
+				Description:  "CPU and memory shares level of the compute resource pool, default 'normal', possible values: \"custom\", \"high\", \"low\", \"normal\"",
+				ValidateFunc: validation.StringInSlice(sddc.SharesLevelValues, false),
+			},
+			"compute_cpu_reservation_mhz": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "CPU reservation of the compute resource pool in Mhz",
+				ValidateFunc: validationUtils.ValidateParsingFloatToInt,
+			},
+			"compute_memory_reservation_mb": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Description:  "Memory reservation of the compute resource pool in MB",
+				ValidateFunc: validationUtils.ValidateParsingFloatToInt,
+			},
+			"include_network": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Generate a resource pool for the network (NSX Edge) appliances, default true",
+			},
+			"resource_pool": sddc.GetComputedResourcePoolSchema(),
+		},
+	}
+}
+
+func dataSourceResourcePoolTemplateRead(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	namePrefix := data.Get("name_prefix").(string)
+
+	resourcePools := []interface{}{
+		newResourcePoolTemplate(namePrefix+"-rp-sddc-mgmt", "management",
+			data.Get("management_shares_level").(string), 0, 0),
+	}
+	if data.Get("include_network").(bool) {
+		resourcePools = append(resourcePools, newResourcePoolTemplate(namePrefix+"-rp-sddc-edge", "network",
+			data.Get("network_shares_level").(string), 0, 0))
+	}
+	resourcePools = append(resourcePools, newResourcePoolTemplate(namePrefix+"-rp-user-compute", "compute",
+		data.Get("compute_shares_level").(string),
+		data.Get("compute_cpu_reservation_mhz").(float64),
+		data.Get("compute_memory_reservation_mb").(float64)))
+
+	if err := sddc.ValidateResourcePools(resourcePools); err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("resource_pool", resourcePools)
+
+	data.SetId("resource-pool-template:" + namePrefix)
+	return nil
+}
+
+// newResourcePoolTemplate returns a resource pool with the defaults of the resource_pool schema.
+func newResourcePoolTemplate(name, resourcePoolType, sharesLevel string, cpuReservationMhz, memoryReservationMb float64) map[string]interface{} {
+	return map[string]interface{}{
+		"name":                          name,
+		"type":                          resourcePoolType,
+		"cpu_limit":                     float64(-1),
+		"cpu_reservation_expandable":    true,
+		"cpu_reservation_mhz":           cpuReservationMhz,
+		"cpu_reservation_percentage":    0,
+		"cpu_shares_level":              sharesLevel,
+		"cpu_shares_value":              0,
+		"memory_limit":                  float64(-1),
+		"memory_reservation_expandable": true,
+		"memory_reservation_mb":         memoryReservationMb,
+		"memory_reservation_percentage": 0,
+		"memory_shares_level":           sharesLevel,
+		"memory_shares_value":           0,
+	}
+}
@@ -0,0 +1,38 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestResourcePoolTemplateRead(t *testing.T) {
+	input := map[string]interface{}{
+		"name_prefix":                   "sfo-m01-cl01",
+		"compute_shares_level":          "low",
+		"compute_cpu_reservation_mhz":   1000,
+		"compute_memory_reservation_mb": 2048,
+		"include_network":               false,
+	}
+	testResourceData := schema.TestResourceDataRaw(t, DataSourceResourcePoolTemplate().Schema, input)
+
+	diags := dataSourceResourcePoolTemplateRead(context.Background(), testResourceData, nil)
+	assert.False(t, diags.HasError())
+	assert.Equal(t, "resource-pool-template:sfo-m01-cl01", testResourceData.Id())
+	assert.Equal(t, 2, testResourceData.Get("resource_pool.#"))
+	assert.Equal(t, "sfo-m01-cl01-rp-sddc-mgmt", testResourceData.Get("resource_pool.0.name"))
+	assert.Equal(t, "management", testResourceData.Get("resource_pool.0.type"))
+	assert.Equal(t, "high", testResourceData.Get("resource_pool.0.cpu_shares_level"))
+	assert.Equal(t, "sfo-m01-cl01-rp-user-compute", testResourceData.Get("resource_pool.1.name"))
+	assert.Equal(t, "compute", testResourceData.Get("resource_pool.1.type"))
+	assert.Equal(t, "low", testResourceData.Get("resource_pool.1.memory_shares_level"))
+	assert.Equal(t, float64(1000), testResourceData.Get("resource_pool.1.cpu_reservation_mhz"))
+	assert.Equal(t, float64(2048), testResourceData.Get("resource_pool.1.memory_reservation_mb"))
+	assert.Equal(t, float64(-1), testResourceData.Get("resource_pool.1.cpu_limit"))
+}
@@ -0,0 +1,99 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/users"
+	"github.com/vmware/vcf-sdk-go/models"
+	"sort"
+	"time"
+)
+
+func DataSourceRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceRolesRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Roles that can be assigned to users and groups in SDDC Manager",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the role",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the role, e.g. ADMIN, OPERATOR, VIEWER",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Description of the role",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceRolesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	roles, err := getRoles(ctx, apiClient.Users)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("roles", flattenRoles(roles))
+	data.SetId("roles")
+
+	return nil
+}
+
+func getRoles(ctx context.Context, usersClient users.ClientService) ([]*models.Role, error) {
+	getRolesParams := users.NewGetRolesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	rolesResult, err := usersClient.GetRoles(getRolesParams)
+	if err != nil {
+		return nil, err
+	}
+	return rolesResult.Payload.Elements, nil
+}
+
+func flattenRoles(roles []*models.Role) []map[string]interface{} {
+	flattenedRoles := *new([]map[string]interface{})
+	for _, role := range roles {
+		if role == nil || role.ID == nil || role.Name == nil {
+			continue
+		}
+		flattenedRole := map[string]interface{}{
+			"id":          *role.ID,
+			"name":        *role.Name,
+			"description": "",
+		}
+		if role.Description != nil {
+			flattenedRole["description"] = *role.Description
+		}
+		flattenedRoles = append(flattenedRoles, flattenedRole)
+	}
+	// Sort for reproducibility, the backend API does not guarantee the order of the roles
+	sort.SliceStable(flattenedRoles, func(i, j int) bool {
+		return flattenedRoles[i]["name"].(string) < flattenedRoles[j]["name"].(string)
+	})
+	return flattenedRoles
+}
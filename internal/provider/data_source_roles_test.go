@@ -0,0 +1,60 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestAccDataSourceVcfRoles(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "vcf_roles" "roles" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_roles.roles", "roles.0.id"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.vcf_roles.roles", "roles.*", map[string]string{
+						"name": "ADMIN",
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenRoles(t *testing.T) {
+	toPointer := func(value string) *string {
+		return &value
+	}
+	roles := []*models.Role{
+		{ID: toPointer("role-2"), Name: toPointer("VIEWER"), Description: toPointer("Read-only access")},
+		{ID: toPointer("role-1"), Name: toPointer("ADMIN")},
+		{ID: toPointer("role-3")},
+		nil,
+	}
+
+	flattenedRoles := flattenRoles(roles)
+	if len(flattenedRoles) != 2 {
+		t.Fatalf("failed. Unexpected number of roles %d, expected 2", len(flattenedRoles))
+	}
+	if flattenedRoles[0]["name"] != "ADMIN" || flattenedRoles[0]["description"] != "" {
+		t.Errorf("failed. Unexpected first role %v, expected ADMIN without description", flattenedRoles[0])
+	}
+	if flattenedRoles[1]["id"] != "role-2" || flattenedRoles[1]["description"] != "Read-only access" {
+		t.Errorf("failed. Unexpected second role %v, expected VIEWER", flattenedRoles[1])
+	}
+
+	if roleName := getRoleName(roles, "role-2"); roleName != "VIEWER" {
+		t.Errorf("failed. Unexpected role name %q, expected VIEWER", roleName)
+	}
+	if roleName := getRoleName(roles, "role-4"); roleName != "" {
+		t.Errorf("failed. Unexpected role name %q for an unknown role", roleName)
+	}
+}
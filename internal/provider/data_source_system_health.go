@@ -0,0 +1,253 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/client/resource_warnings"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func DataSourceSystemHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSystemHealthRead,
+		Timeouts: &schema.ResourceTimeout{
+			Read: schema.DefaultTimeout(1 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "ID of the domain whose health is read",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"run_prechecks": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to run the prechecks on the domain on every read. The prechecks can take several minutes. If false, only the warnings SDDC Manager has raised on the resources of the domain are read",
+			},
+			"bundle_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "ID of a bundle whose upgrade prechecks run on the domain if run_prechecks is true. If omitted, the health prechecks of the domain run",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"precheck": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Results of the prechecks of the domain. Empty if run_prechecks is false",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the precheck",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status of the precheck, e.g. COMPLETED_WITH_SUCCESS or COMPLETED_WITH_FAILURE",
+						},
+						"errors": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Errors of the failed precheck",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"warning": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Warnings SDDC Manager has raised on the domain, its clusters and its hosts",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the resource of the warning",
+						},
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the resource of the warning. One among: HOST, CLUSTER, DOMAIN",
+						},
+						"severity": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Severity of the warning. One among: MINOR, MAJOR",
+						},
+						"warning_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Type of the warning. One among: SKIPPED_RESOURCE, VALIDATION, CONFIGURATION, OTHER",
+						},
+						"message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Message of the warning",
+						},
+						"remediation_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "How to remediate the warning",
+						},
+					},
+				},
+			},
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether all prechecks that have run have passed and there are no warnings with MAJOR severity",
+			},
+		},
+	}
+}
+
+func dataSourceSystemHealthRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	domainId := data.Get("domain_id").(string)
+	domainResourceIds, err := getDomainResourceIds(ctx, apiClient, domainId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var precheckTask *models.Task
+	if data.Get("run_prechecks").(bool) {
+		var diags diag.Diagnostics
+		precheckTask, diags = runPrechecks(ctx, apiClient, domainId, data.Get("bundle_id").(string))
+		if diags != nil {
+			return diags
+		}
+	}
+
+	getResourceWarningsParams := resource_warnings.NewGetResourceWarningsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	resourceWarningsResult, err := apiClient.ResourceWarnings.GetResourceWarnings(getResourceWarningsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resourceWarnings := filterResourceWarnings(resourceWarningsResult.Payload.Elements, domainResourceIds)
+
+	_ = data.Set("precheck", flattenPrechecks(precheckTask))
+	_ = data.Set("warning", flattenResourceWarnings(resourceWarnings))
+	_ = data.Set("healthy", isSystemHealthy(precheckTask, resourceWarnings))
+	data.SetId(domainId)
+
+	return nil
+}
+
+// getDomainResourceIds returns the IDs of the domain, its clusters and its hosts.
+func getDomainResourceIds(ctx context.Context, apiClient *client.VcfClient, domainId string) (map[string]bool, error) {
+	getDomainParams := domains.NewGetDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getDomainParams.ID = domainId
+	domainResult, err := apiClient.Domains.GetDomain(getDomainParams)
+	if err != nil {
+		return nil, err
+	}
+	resourceIds := map[string]bool{domainId: true}
+	for _, clusterReference := range domainResult.Payload.Clusters {
+		if clusterReference != nil && clusterReference.ID != nil {
+			resourceIds[*clusterReference.ID] = true
+		}
+	}
+
+	getHostsParams := hosts.NewGetHostsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getHostsParams.DomainID = &domainId
+	hostsResult, err := apiClient.Hosts.GetHosts(getHostsParams)
+	if err != nil {
+		return nil, err
+	}
+	for _, host := range hostsResult.Payload.Elements {
+		if host != nil {
+			resourceIds[host.ID] = true
+		}
+	}
+	return resourceIds, nil
+}
+
+// filterResourceWarnings returns the resource warnings that SDDC Manager has raised on one of the resources.
+func filterResourceWarnings(resourceWarnings []*models.ResourceWarning, resourceIds map[string]bool) []*models.ResourceWarning {
+	var filteredResourceWarnings []*models.ResourceWarning
+	for _, resourceWarning := range resourceWarnings {
+		if resourceWarning != nil && resourceIds[resourceWarning.ResourceID] {
+			filteredResourceWarnings = append(filteredResourceWarnings, resourceWarning)
+		}
+	}
+	return filteredResourceWarnings
+}
+
+func flattenPrechecks(precheckTask *models.Task) []map[string]interface{} {
+	flattenedPrechecks := *new([]map[string]interface{})
+	if precheckTask == nil {
+		return flattenedPrechecks
+	}
+	for _, subTask := range precheckTask.SubTasks {
+		if subTask == nil {
+			continue
+		}
+		var precheckErrors []string
+		for _, subTaskError := range subTask.Errors {
+			if subTaskError != nil {
+				precheckErrors = append(precheckErrors, subTaskError.Message)
+			}
+		}
+		flattenedPrechecks = append(flattenedPrechecks, map[string]interface{}{
+			"name":   subTask.Name,
+			"status": subTask.Status,
+			"errors": precheckErrors,
+		})
+	}
+	return flattenedPrechecks
+}
+
+func flattenResourceWarnings(resourceWarnings []*models.ResourceWarning) []map[string]interface{} {
+	flattenedResourceWarnings := *new([]map[string]interface{})
+	for _, resourceWarning := range resourceWarnings {
+		if resourceWarning == nil {
+			continue
+		}
+		flattenedResourceWarnings = append(flattenedResourceWarnings, map[string]interface{}{
+			"resource_name":       resourceWarning.ResourceName,
+			"resource_type":       resourceWarning.ResourceType,
+			"severity":            resourceWarning.Severity,
+			"warning_type":        resourceWarning.WarningType,
+			"message":             resourceWarning.Message,
+			"remediation_message": resourceWarning.RemediationMessage,
+		})
+	}
+	return flattenedResourceWarnings
+}
+
+// isSystemHealthy tells whether the precheck task, if any, has passed and none of the resource warnings
+// is MAJOR, minor warnings do not prevent changes to the system.
+func isSystemHealthy(precheckTask *models.Task, resourceWarnings []*models.ResourceWarning) bool {
+	if precheckTask != nil && isTaskFailed(precheckTask.Status) {
+		return false
+	}
+	for _, resourceWarning := range resourceWarnings {
+		if resourceWarning != nil && resourceWarning.Severity == "MAJOR" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/models"
+	"os"
+	"testing"
+)
+
+func TestAccDataSourceVcfSystemHealth(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfSystemHealthDataSourceConfig(
+					os.Getenv(constants.VcfTestDomainDataSourceId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.vcf_system_health.health", "id"),
+					resource.TestCheckResourceAttrSet("data.vcf_system_health.health", "precheck.0.name"),
+					resource.TestCheckResourceAttrSet("data.vcf_system_health.health", "precheck.0.status"),
+					resource.TestCheckResourceAttrSet("data.vcf_system_health.health", "healthy"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfSystemHealthDataSourceConfig(domainId string) string {
+	return fmt.Sprintf(`
+	data "vcf_system_health" "health" {
+		domain_id     = %q
+		run_prechecks = true
+	}`, domainId)
+}
+
+func TestIsSystemHealthy(t *testing.T) {
+	passedPrecheckTask := &models.Task{Status: "COMPLETED_WITH_SUCCESS"}
+	failedPrecheckTask := &models.Task{Status: "COMPLETED_WITH_FAILURE"}
+	minorWarnings := []*models.ResourceWarning{{Severity: "MINOR"}}
+	majorWarnings := []*models.ResourceWarning{{Severity: "MINOR"}, {Severity: "MAJOR"}}
+
+	if !isSystemHealthy(passedPrecheckTask, minorWarnings) {
+		t.Errorf("expected a healthy system with passed prechecks and minor warnings")
+	}
+	if isSystemHealthy(passedPrecheckTask, majorWarnings) {
+		t.Errorf("expected an unhealthy system with a major warning")
+	}
+	if isSystemHealthy(failedPrecheckTask, nil) {
+		t.Errorf("expected an unhealthy system with failed prechecks")
+	}
+	if !isSystemHealthy(nil, minorWarnings) {
+		t.Errorf("expected a healthy system without prechecks and with minor warnings")
+	}
+}
+
+func TestFilterResourceWarnings(t *testing.T) {
+	resourceWarnings := []*models.ResourceWarning{
+		{ResourceID: "domain-1", ResourceType: "DOMAIN"},
+		nil,
+		{ResourceID: "host-1", ResourceType: "HOST"},
+		{ResourceID: "cluster-2", ResourceType: "CLUSTER"},
+	}
+	filteredResourceWarnings := filterResourceWarnings(resourceWarnings,
+		map[string]bool{"domain-1": true, "cluster-1": true, "host-1": true})
+	if len(filteredResourceWarnings) != 2 || filteredResourceWarnings[0].ResourceID != "domain-1" ||
+		filteredResourceWarnings[1].ResourceID != "host-1" {
+		t.Errorf("unexpected resource warnings of the domain %v", filteredResourceWarnings)
+	}
+}
+
+func TestFlattenPrechecks(t *testing.T) {
+	flattenedPrechecks := flattenPrechecks(&models.Task{
+		SubTasks: []*models.SubTask{
+			{Name: "vCenter health", Status: "COMPLETED_WITH_SUCCESS"},
+			nil,
+			{Name: "ESXi disk space", Status: "COMPLETED_WITH_FAILURE", Errors: []*models.Error{{Message: "not enough space"}}},
+		},
+	})
+	if len(flattenedPrechecks) != 2 {
+		t.Fatalf("expected 2 prechecks, got %v", flattenedPrechecks)
+	}
+	if errors := flattenedPrechecks[1]["errors"].([]string); len(errors) != 1 || errors[0] != "not enough space" {
+		t.Errorf("unexpected errors of the failed precheck %v", flattenedPrechecks[1])
+	}
+}
@@ -5,12 +5,20 @@ package provider
 
 import (
 	"context"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"net/url"
+	"regexp"
+	"time"
 )
 
+// sddcManagerTokenAttributes authenticate to SDDC Manager instead of a username and password.
+var sddcManagerTokenAttributes = []string{"sddc_manager_api_key", "sddc_manager_token", "sddc_manager_refresh_token"}
+
 // Provider returns the resource configuration of the VCF provider.
 func Provider() *schema.Provider {
 	return &schema.Provider{
@@ -19,7 +27,7 @@ func Provider() *schema.Provider {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Description:   "Username to authenticate to SDDC Manager",
-				ConflictsWith: []string{"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
+				ConflictsWith: append(sddcManagerTokenAttributes, "cloud_builder_username", "cloud_builder_password", "cloud_builder_host"),
 				RequiredWith:  []string{"sddc_manager_password", "sddc_manager_host"},
 				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfTestUsername, nil),
 			},
@@ -27,7 +35,7 @@ func Provider() *schema.Provider {
 				Type:          schema.TypeString,
 				Optional:      true,
 				Description:   "Password to authenticate to SDDC Manager",
-				ConflictsWith: []string{"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
+				ConflictsWith: append(sddcManagerTokenAttributes, "cloud_builder_username", "cloud_builder_password", "cloud_builder_host"),
 				RequiredWith:  []string{"sddc_manager_username", "sddc_manager_host"},
 				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfTestPassword, nil),
 			},
@@ -36,9 +44,34 @@ func Provider() *schema.Provider {
 				Optional:      true,
 				Description:   "Fully qualified domain name or IP address of the SDDC Manager",
 				ConflictsWith: []string{"cloud_builder_username", "cloud_builder_password", "cloud_builder_host"},
-				RequiredWith:  []string{"sddc_manager_username", "sddc_manager_password"},
 				DefaultFunc:   schema.EnvDefaultFunc(constants.VcfTestUrl, nil),
 			},
+			"sddc_manager_api_key": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "API key of a service user to authenticate to SDDC Manager instead of a username and password",
+				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_token", "sddc_manager_refresh_token"},
+				RequiredWith:  []string{"sddc_manager_host"},
+			},
+			"sddc_manager_token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "Pre-issued access token to authenticate to SDDC Manager instead of a username and password. " +
+					"Unless sddc_manager_refresh_token is set, the access token is not refreshed and must outlive the Terraform run.",
+				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_api_key"},
+				RequiredWith:  []string{"sddc_manager_host"},
+			},
+			"sddc_manager_refresh_token": {
+				Type:      schema.TypeString,
+				Optional:  true,
+				Sensitive: true,
+				Description: "ID of the refresh token with which the SDDC Manager access token is obtained and refreshed " +
+					"before it expires. Can be combined with sddc_manager_token.",
+				ConflictsWith: []string{"sddc_manager_username", "sddc_manager_password", "sddc_manager_api_key"},
+				RequiredWith:  []string{"sddc_manager_host"},
+			},
 			"cloud_builder_username": {
 				Type:          schema.TypeString,
 				Optional:      true,
@@ -69,40 +102,188 @@ func Provider() *schema.Provider {
 				Description: "If set, VMware VCF client will permit unverifiable TLS certificates.",
 				DefaultFunc: schema.EnvDefaultFunc(constants.VcfTestAllowUnverifiedTls, false),
 			},
+			"ca_bundle_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path of a PEM file with the certificates of the CAs that are trusted to verify the certificate of " +
+					"SDDC Manager, in addition to the system trust store, e.g. of a private CA.",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"client_certificate_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Path of a PEM file with the client certificate that is presented to SDDC Manager, e.g. when it " +
+					"is fronted by a proxy requiring mutual TLS.",
+				RequiredWith: []string{"client_key_path"},
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"client_key_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Path of a PEM file with the private key of the client certificate.",
+				RequiredWith: []string{"client_certificate_path"},
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"token_refresh_margin": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  api_client.DefaultTokenRefreshMargin.String(),
+				Description: "How long before its expiry the SDDC Manager access token is refreshed, e.g. 5m. " +
+					"Refreshing ahead of expiry avoids authentication failures during long-running operations.",
+				ValidateDiagFunc: validateDuration,
+			},
+			"connect_timeout": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "0s",
+				Description: "For how long to retry connecting to SDDC Manager while it is unreachable, e.g. 15m right after " +
+					"bring-up. Retries back off exponentially up to a minute. By default, the provider does not retry.",
+				ValidateDiagFunc: validateDuration,
+			},
+			"api_timeout": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "2m",
+				Description: "Timeout of a single SDDC Manager or Cloud Builder API call, e.g. 5m in large environments with slow APIs. " +
+					"Long-running tasks are polled with separate API calls and are not limited by it. Defaults to 2m.",
+				ValidateDiagFunc: validateDuration,
+			},
+			"max_retries": {
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  0,
+				Description: "How many times a failed read API call is retried, e.g. while SDDC Manager is overloaded or its " +
+					"services restart. API calls that change the configuration are not retried. By default, the provider does not retry.",
+				ValidateFunc: validation.IntBetween(0, 10),
+			},
+			"retry_backoff": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "5s",
+				Description:      "Delay before the first retry of a failed read API call, doubled on every retry. Defaults to 5s.",
+				ValidateDiagFunc: validateDuration,
+			},
+			"api_base_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Base path of the SDDC Manager API, e.g. /sddc-manager/ when SDDC Manager is reached through a " +
+					"reverse proxy. If not set, the default base path of the VCF SDK is used.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^/[A-Za-z0-9._~/-]*$`),
+					"must be an absolute URL path, e.g. /sddc-manager/"),
+			},
+			"proxy_url": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "URL of the proxy through which SDDC Manager is reached, e.g. http://proxy.example.com:3128. " +
+					"If not set, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honored.",
+				ValidateFunc: validation.IsURLWithScheme([]string{"http", "https"}),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"vcf_domain":  DataSourceDomain(),
-			"vcf_cluster": DataSourceCluster(),
+			"vcf_domain":                     DataSourceDomain(),
+			"vcf_cluster":                    DataSourceCluster(),
+			"vcf_licenses":                   DataSourceLicenses(),
+			"vcf_nsx_manager":                DataSourceNsxManager(),
+			"vcf_domain_dependencies":        DataSourceDomainDependencies(),
+			"vcf_resource_pool_template":     DataSourceResourcePoolTemplate(),
+			"vcf_network_pool_ip_allocation": DataSourceNetworkPoolIpAllocation(),
+			"vcf_dns_check":                  DataSourceDnsCheck(),
+			"vcf_license_key":                DataSourceLicenseKey(),
+			"vcf_host":                       DataSourceHost(),
+			"vcf_ceip":                       DataSourceCeip(),
+			"vcf_personality":                DataSourcePersonality(),
+			"vcf_credentials":                DataSourceCredentials(),
+			"vcf_system_health":              DataSourceSystemHealth(),
+			"vcf_network_pool":               DataSourceNetworkPool(),
+			"vcf_roles":                      DataSourceRoles(),
 		},
 
+		// TODO add a vcf_edge_cluster resource. Note that EdgeClusterCreationSpec in the VCF API cannot
+		// reference an existing NSX transport node profile, VCF creates the profiles of the edge nodes itself.
+		// The Tier-0 uplink VLANs (NsxTEdgeUplinkNetwork) and the edge MTU of the spec should be validated
+		// against the 0-4094 and 1500-9000 ranges and checked for collisions with the host and overlay VLANs.
+		// The edge node root, admin and audit passwords of the spec should be separate Sensitive attributes.
+		// TODO add a vcf_supervisor resource. The VCF API has no Workload Management enablement endpoint,
+		// vSphere with Tanzu is enabled through the vCenter namespace-management API. Its creation should be
+		// gated on the workload_management_ready attribute of the cluster and reject overlapping CIDRs.
+		// Besides the cluster, it should cover the control plane size, the management network, the ingress
+		// and egress CIDRs and the content library of the supervisor.
+		// TODO add a vcf_license_assignment resource. The VCF API only assigns license keys when clusters, hosts
+		// and domains are created, it has no endpoint for reassigning the license key of an existing component.
+		// TODO add vcf_federation and vcf_federation_member resources to create, join and leave a VCF Federation
+		// and invite members. The VCF API has no federation endpoints.
 		ResourcesMap: map[string]*schema.Resource{
-			"vcf_instance":              ResourceVcfInstance(),
-			"vcf_user":                  ResourceUser(),
-			"vcf_network_pool":          ResourceNetworkPool(),
-			"vcf_ceip":                  ResourceCeip(),
-			"vcf_host":                  ResourceHost(),
-			"vcf_domain":                ResourceDomain(),
-			"vcf_cluster":               ResourceCluster(),
-			"vcf_certificate_authority": ResourceCertificateAuthority(),
+			"vcf_instance":                          ResourceVcfInstance(),
+			"vcf_user":                              ResourceUser(),
+			"vcf_network_pool":                      ResourceNetworkPool(),
+			"vcf_ceip":                              ResourceCeip(),
+			"vcf_host":                              ResourceHost(),
+			"vcf_domain":                            ResourceDomain(),
+			"vcf_cluster":                           ResourceCluster(),
+			"vcf_certificate_authority":             ResourceCertificateAuthority(),
+			"vcf_certificate":                       ResourceCertificate(),
+			"vcf_credentials_rotation":              ResourceCredentialsRotation(),
+			"vcf_cluster_remediation":               ResourceClusterRemediation(),
+			"vcf_license_key":                       ResourceLicenseKey(),
+			"vcf_sddc_manager_backup_configuration": ResourceSddcManagerBackupConfiguration(),
+			"vcf_proxy_configuration":               ResourceProxyConfiguration(),
+			"vcf_depot_settings":                    ResourceDepotSettings(),
+			"vcf_lcm_bundle":                        ResourceLcmBundle(),
+			"vcf_upgrade":                           ResourceUpgrade(),
+			"vcf_dns_configuration":                 ResourceDnsConfiguration(),
+			"vcf_ntp_configuration":                 ResourceNtpConfiguration(),
+			"vcf_sso_domain":                        ResourceSsoDomain(),
 		},
 
 		ConfigureContextFunc: providerConfigure,
 	}
 }
 
-func providerConfigure(_ context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	sddcManagerUsername, isVcfUsernameSet := data.GetOk("sddc_manager_username")
+func providerConfigure(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+	_, isVcfUsernameSet := data.GetOk("sddc_manager_username")
 	allowUnverifiedTLS := data.Get("allow_unverified_tls")
-	if isVcfUsernameSet {
+	apiTimeout, _ := time.ParseDuration(data.Get("api_timeout").(string))
+	if isVcfUsernameSet || isSddcManagerTokenAuthSet(data) {
 		password, isSetPassword := data.GetOk("sddc_manager_password")
 		hostName, isSetHost := data.GetOk("sddc_manager_host")
-		if !isVcfUsernameSet || !isSetPassword || !isSetHost {
+		if isVcfUsernameSet && (!isSetPassword || !isSetHost) {
 			return nil, diag.Errorf("SDDC Manager username, password and host must be provided")
 		}
-		var sddcManagerClient = api_client.NewSddcManagerClient(sddcManagerUsername.(string), password.(string),
-			hostName.(string), allowUnverifiedTLS.(bool))
-		err := sddcManagerClient.Connect()
+		if !isSetHost {
+			return nil, diag.Errorf("SDDC Manager host must be provided")
+		}
+		var sddcManagerClient = api_client.NewSddcManagerClient(data.Get("sddc_manager_username").(string),
+			password.(string), hostName.(string), allowUnverifiedTLS.(bool))
+		if apiKey, isSetApiKey := data.GetOk("sddc_manager_api_key"); isSetApiKey {
+			sddcManagerClient.SetApiKey(apiKey.(string))
+		}
+		sddcManagerClient.SetAccessToken(data.Get("sddc_manager_token").(string),
+			data.Get("sddc_manager_refresh_token").(string))
+		tokenRefreshMargin, _ := time.ParseDuration(data.Get("token_refresh_margin").(string))
+		sddcManagerClient.SetTokenRefreshMargin(tokenRefreshMargin)
+		connectTimeout, _ := time.ParseDuration(data.Get("connect_timeout").(string))
+		sddcManagerClient.SetConnectTimeout(connectTimeout)
+		sddcManagerClient.SetApiTimeout(apiTimeout)
+		retryBackoff, _ := time.ParseDuration(data.Get("retry_backoff").(string))
+		sddcManagerClient.SetRetryPolicy(data.Get("max_retries").(int), retryBackoff)
+		if apiBasePath, isSetApiBasePath := data.GetOk("api_base_path"); isSetApiBasePath {
+			sddcManagerClient.SetBasePath(apiBasePath.(string))
+		}
+		if caBundlePath, isSetCaBundlePath := data.GetOk("ca_bundle_path"); isSetCaBundlePath {
+			sddcManagerClient.SetCaBundlePath(caBundlePath.(string))
+		}
+		if clientCertificatePath, isSetClientCertificatePath := data.GetOk("client_certificate_path"); isSetClientCertificatePath {
+			sddcManagerClient.SetClientCertificate(clientCertificatePath.(string), data.Get("client_key_path").(string))
+		}
+		if proxyUrl, isSetProxyUrl := data.GetOk("proxy_url"); isSetProxyUrl {
+			parsedProxyUrl, err := url.Parse(proxyUrl.(string))
+			if err != nil {
+				return nil, diag.FromErr(err)
+			}
+			sddcManagerClient.SetProxyUrl(parsedProxyUrl)
+		}
+		err := sddcManagerClient.Connect(ctx)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
@@ -116,6 +297,32 @@ func providerConfigure(_ context.Context, data *schema.ResourceData) (interface{
 		}
 		var cloudBuilderClient = api_client.NewCloudBuilderClient(cbUsername.(string), password.(string),
 			hostName.(string), allowUnverifiedTLS.(bool))
+		cloudBuilderClient.SetApiTimeout(apiTimeout)
 		return cloudBuilderClient, nil
 	}
 }
+
+// isSddcManagerTokenAuthSet tells whether SDDC Manager is authenticated to with an API key or tokens.
+func isSddcManagerTokenAuthSet(data *schema.ResourceData) bool {
+	for _, attribute := range sddcManagerTokenAttributes {
+		if _, isSet := data.GetOk(attribute); isSet {
+			return true
+		}
+	}
+	return false
+}
+
+func validateDuration(i interface{}, path cty.Path) diag.Diagnostics {
+	value, ok := i.(string)
+	if !ok {
+		return diag.Errorf("expected type of %v to be string", path)
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return diag.Errorf("invalid duration %q: %s", value, err)
+	}
+	if duration < 0 {
+		return diag.Errorf("duration %q must not be negative", value)
+	}
+	return nil
+}
@@ -70,6 +70,8 @@ func resourceCeipRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	}
 
 	d.SetId(ceipResult.Payload.InstanceID)
+	// Transitional statuses such as ENABLING are reported as drift, so that the operation is repeated.
+	_ = d.Set("status", ceipResult.Payload.Status)
 	return nil
 }
 
@@ -99,7 +101,7 @@ func resourceCeipUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 
-	if vcfClient.WaitForTask(ctx, ceipAccepted.Payload.ID) != nil {
+	if err = vcfClient.WaitForTask(ctx, ceipAccepted.Payload.ID); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -125,7 +127,7 @@ func resourceCeipDelete(ctx context.Context, d *schema.ResourceData, meta interf
 		return diag.FromErr(err)
 	}
 
-	if vcfClient.WaitForTask(ctx, ceipAccepted.Payload.ID) != nil {
+	if err = vcfClient.WaitForTask(ctx, ceipAccepted.Payload.ID); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -0,0 +1,395 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/certificates"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func ResourceCertificate() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCertificateCreate,
+		ReadContext:   resourceCertificateRead,
+		UpdateContext: resourceCertificateUpdate,
+		DeleteContext: resourceCertificateDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(1 * time.Hour),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		CustomizeDiff: validateCertificateSigning,
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the domain of the resource",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Type of the resource whose certificate is replaced. One among: SDDC_MANAGER, VCENTER, NSXT_MANAGER, PSC",
+				ValidateFunc: validation.StringInSlice(certificates.ResourceTypes, false),
+			},
+			"resource_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the resource, e.g. the ID of the vCenter, the NSX Manager cluster or the SDDC Manager",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"resource_fqdn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Fully qualified domain name of the resource, the certificate is issued to",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"sans": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Subject alternative names of the certificate. Defaults to the FQDN and IP address of SDDC Manager, the FQDNs of the VIP and the nodes of NSX Manager, or the FQDN of the resource",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"csr": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Specification of the CSR generated for the resource. Required with \"ca_type\". Without \"ca_type\" and \"external_certificate\" only the CSR is generated and exposed as \"csr_pem\" for an external certificate authority to sign, the signed certificate is installed by adding \"external_certificate\" in a later apply",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"country": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "ISO 3166 country code where company is legally registered",
+							ValidateFunc: validation.StringInSlice(constants.GetIso3166CountryCodes(), false),
+						},
+						"state": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full name (do not abbreviate) of the state, province, region, or territory where your company is legally registered",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"locality": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The city or locality where company is legally registered",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"organization": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "The name under which your company is known",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"organization_unit": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Organization with which the certificate is associated",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Contact email address",
+						},
+						"key_size": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "2048",
+							Description:  "Certificate public key size. One among: 2048, 3072, 4096",
+							ValidateFunc: validation.StringInSlice([]string{"2048", "3072", "4096"}, false),
+						},
+						"key_algorithm": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "RSA",
+							Description:  "Certificate public key algorithm",
+							ValidateFunc: validation.StringInSlice([]string{"RSA"}, false),
+						},
+					},
+				},
+			},
+			"ca_type": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				Description:   "Type of the configured certificate authority that signs the CSR. One among: Microsoft, OpenSSL",
+				ValidateFunc:  validation.StringInSlice([]string{"Microsoft", "OpenSSL"}, false),
+				ConflictsWith: []string{"external_certificate"},
+			},
+			"external_certificate": {
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				Optional:      true,
+				Description:   "Externally signed certificate of the resource. The certificate has to be signed from a CSR generated by SDDC Manager for the resource, e.g. the \"csr_pem\" of this resource. Changing it installs the certificate without generating a new CSR",
+				ConflictsWith: []string{"ca_type"},
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_certificate": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "PEM encoded certificate of the resource",
+							ValidateFunc: validation.StringIsNotEmpty,
+						},
+						"certificate_chain": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "PEM encoded chain of the intermediate certificates",
+						},
+						"ca_certificate": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "PEM encoded certificate of the root certificate authority",
+						},
+					},
+				},
+			},
+			"csr_pem": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded CSR generated for the resource",
+			},
+			"issued_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Issuer of the installed certificate",
+			},
+			"not_before": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Start of the validity of the installed certificate",
+			},
+			"not_after": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "End of the validity of the installed certificate",
+			},
+			"expiration_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Expiration status of the installed certificate, e.g. ACTIVE, EXPIRING, EXPIRED",
+			},
+			"serial_number": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Serial number of the installed certificate",
+			},
+			"thumbprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Thumbprint of the installed certificate",
+			},
+			"subject_alternative_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Subject alternative names of the installed certificate",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pem_encoded": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "PEM encoded installed certificate",
+			},
+		},
+	}
+}
+
+func validateCertificateSigning(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	return checkCertificateSigning(diff.Get("ca_type").(string),
+		!validationUtils.IsEmpty(diff.Get("csr")), !validationUtils.IsEmpty(diff.Get("external_certificate")),
+		diff.Id() == "" || diff.HasChange("csr"))
+}
+
+// checkCertificateSigning verifies that the certificate is either signed by a configured certificate
+// authority from a newly generated CSR, provided as an external certificate, or that only a CSR is
+// generated for an external certificate authority to sign.
+func checkCertificateSigning(caType string, hasCsr, hasExternalCertificate, isNewCsr bool) error {
+	if caType == "" && !hasCsr && !hasExternalCertificate {
+		return fmt.Errorf("one of \"ca_type\", \"csr\" or \"external_certificate\" has to be provided")
+	}
+	if caType != "" && !hasCsr {
+		return fmt.Errorf("\"csr\" has to be provided for the certificate authority %s to sign", caType)
+	}
+	// a new CSR replaces the key pair of the one the external certificate was signed from
+	if isNewCsr && hasCsr && hasExternalCertificate {
+		return fmt.Errorf("\"external_certificate\" has to be signed from the generated CSR, add it once \"csr_pem\" is known")
+	}
+	return nil
+}
+
+func resourceCertificateCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+
+	domainId := data.Get("domain_id").(string)
+	resourceType := data.Get("resource_type").(string)
+	resourceId := data.Get("resource_id").(string)
+	resourceFqdn := data.Get("resource_fqdn").(string)
+
+	domainName, err := certificates.GetDomainName(ctx, domainId, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sans, err := certificates.GetResourceCertificateSans(ctx, resourceType, resourceId, resourceFqdn,
+		utils.ToStringSlice(data.Get("sans").([]interface{})), apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resource := &models.Resource{
+		Fqdn:       resourceFqdn,
+		ResourceID: &resourceId,
+		Sans:       sans,
+		Type:       &resourceType,
+	}
+
+	if csrList := data.Get("csr").([]interface{}); len(csrList) > 0 {
+		taskId, err := certificates.GenerateCsr(ctx, domainName, getCsrGenerationSpec(csrList[0].(map[string]interface{})),
+			resource, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+			return diag.FromErr(err)
+		}
+		csrPem, err := certificates.GetResourceCsr(ctx, domainName, resourceFqdn, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		_ = data.Set("csr_pem", csrPem)
+	}
+
+	if caType := data.Get("ca_type").(string); caType != "" {
+		taskId, err := certificates.GenerateCertificate(ctx, domainName, caType, resource, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+			return diag.FromErr(err)
+		}
+		taskId, err = certificates.InstallCertificate(ctx, domainName, resource, apiClient)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+			return diag.FromErr(err)
+		}
+	} else if len(data.Get("external_certificate").([]interface{})) > 0 {
+		if diags := installExternalCertificate(ctx, data, vcfClient); diags != nil {
+			return diags
+		}
+	}
+
+	_ = data.Set("sans", sans)
+	data.SetId(fmt.Sprintf("%s:%s:%s", domainId, resourceType, resourceId))
+
+	return resourceCertificateRead(ctx, data, meta)
+}
+
+// resourceCertificateUpdate installs a changed external certificate, e.g. the one signed from the CSR
+// generated on creation.
+func resourceCertificateUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.HasChange("external_certificate") && len(data.Get("external_certificate").([]interface{})) > 0 {
+		if diags := installExternalCertificate(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+			return diags
+		}
+	}
+	return resourceCertificateRead(ctx, data, meta)
+}
+
+func installExternalCertificate(ctx context.Context, data *schema.ResourceData, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	externalCertificate := data.Get("external_certificate").([]interface{})[0].(map[string]interface{})
+	taskId, err := certificates.InstallExternalCertificate(ctx, data.Get("domain_id").(string), &models.ResourceCertificateSpec{
+		ResourceCertificate: externalCertificate["resource_certificate"].(string),
+		CertificateChain:    externalCertificate["certificate_chain"].(string),
+		CaCertificate:       externalCertificate["ca_certificate"].(string),
+		ResourceFqdn:        data.Get("resource_fqdn").(string),
+		ResourceID:          data.Get("resource_id").(string),
+	}, vcfClient.ApiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceCertificateRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	domainName, err := certificates.GetDomainName(ctx, data.Get("domain_id").(string), apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	resourceFqdn := data.Get("resource_fqdn").(string)
+	certificate, err := certificates.GetResourceCertificate(ctx, domainName, resourceFqdn, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if certificate == nil {
+		tflog.Warn(ctx, fmt.Sprintf("No certificate issued to %s found in domain %s, removing it from the state",
+			resourceFqdn, domainName))
+		data.SetId("")
+		return nil
+	}
+
+	_ = data.Set("issued_by", certificate.IssuedBy)
+	_ = data.Set("not_before", certificate.NotBefore)
+	_ = data.Set("not_after", certificate.NotAfter)
+	_ = data.Set("expiration_status", certificate.ExpirationStatus)
+	_ = data.Set("serial_number", certificate.SerialNumber)
+	_ = data.Set("thumbprint", certificate.Thumbprint)
+	_ = data.Set("subject_alternative_names", certificate.SubjectAlternativeName)
+	_ = data.Set("pem_encoded", certificate.PemEncoded)
+
+	return nil
+}
+
+// resourceCertificateDelete only removes the certificate from the state, the certificate of
+// a resource cannot be removed and stays installed until it is replaced.
+func resourceCertificateDelete(ctx context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	tflog.Info(ctx, fmt.Sprintf("Certificate of %s stays installed, removing it from the state",
+		data.Get("resource_fqdn").(string)))
+	data.SetId("")
+	return nil
+}
+
+func getCsrGenerationSpec(csr map[string]interface{}) *models.CSRGenerationSpec {
+	return &models.CSRGenerationSpec{
+		Country:          utils.ToStringPointer(csr["country"]),
+		State:            utils.ToStringPointer(csr["state"]),
+		Locality:         utils.ToStringPointer(csr["locality"]),
+		Organization:     utils.ToStringPointer(csr["organization"]),
+		OrganizationUnit: utils.ToStringPointer(csr["organization_unit"]),
+		Email:            csr["email"].(string),
+		KeySize:          utils.ToStringPointer(csr["key_size"]),
+		KeyAlgorithm:     utils.ToStringPointer(csr["key_algorithm"]),
+	}
+}
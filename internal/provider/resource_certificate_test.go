@@ -0,0 +1,34 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestCheckCertificateSigning(t *testing.T) {
+	if err := checkCertificateSigning("OpenSSL", true, false, true); err != nil {
+		t.Errorf("unexpected error for a CSR signed by a certificate authority: %s", err)
+	}
+	if err := checkCertificateSigning("", false, true, false); err != nil {
+		t.Errorf("unexpected error for an external certificate: %s", err)
+	}
+	if err := checkCertificateSigning("", true, false, true); err != nil {
+		t.Errorf("unexpected error for a CSR for an external certificate authority: %s", err)
+	}
+	if err := checkCertificateSigning("", true, true, false); err != nil {
+		t.Errorf("unexpected error for an external certificate signed from the generated CSR: %s", err)
+	}
+	if err := checkCertificateSigning("Microsoft", false, false, false); err == nil {
+		t.Error("expected an error for a certificate authority without a CSR")
+	}
+	if err := checkCertificateSigning("", false, false, false); err == nil {
+		t.Error("expected an error for neither a CSR, a certificate authority nor an external certificate")
+	}
+	if err := checkCertificateSigning("", true, true, true); err == nil {
+		t.Error("expected an error for an external certificate with a CSR that is yet to be generated")
+	}
+}
@@ -5,7 +5,9 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
@@ -18,6 +20,7 @@ import (
 	"github.com/vmware/vcf-sdk-go/client/clusters"
 	"github.com/vmware/vcf-sdk-go/models"
 	"log"
+	"reflect"
 	"strings"
 	"time"
 )
@@ -30,6 +33,23 @@ func ResourceCluster() *schema.Resource {
 		Description:  "The ID of a workload domain that the cluster belongs to",
 		ValidateFunc: validation.NoZeroValues,
 	}
+	clusterResourceSchema["stretch"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Stretches the vSAN cluster across two availability zones. Removing the block unstretches the cluster",
+		Elem:        cluster.StretchSchema(),
+	}
+	clusterResourceSchema["unread_attributes"] = unreadAttributesSchema()
+	clusterResourceSchema["resume_failed_creation"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "If the latest creation of a cluster with the same name in the domain failed within the last 24 hours, retry the " +
+			"failed workflow instead of submitting a new creation. The workflow is retried with the specification it was submitted with, " +
+			"changes to the configuration since are not applied. " +
+			"A creation that is still running, e.g. after Terraform was interrupted, is always waited for",
+	}
 
 	return &schema.Resource{
 		CreateContext: resourceClusterCreate,
@@ -41,10 +61,17 @@ func ResourceCluster() *schema.Resource {
 				vcfClient := meta.(*api_client.SddcManagerClient)
 				apiClient := vcfClient.ApiClient
 				clusterId := data.Id()
-				return cluster.ImportCluster(ctx, data, apiClient, clusterId)
+				_ = data.Set("resume_failed_creation", false)
+				importedData, err := cluster.ImportCluster(ctx, data, apiClient, clusterId)
+				if err != nil {
+					return nil, err
+				}
+				_ = data.Set("unread_attributes", cluster.UnreadAttributes(data, ""))
+				return importedData, nil
 			},
 		},
-		Schema: clusterResourceSchema,
+		CustomizeDiff: customdiff.All(validateClusterUpdate, validateStretchedClusterHosts, clearUnreadAttributes),
+		Schema:        clusterResourceSchema,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(2 * time.Hour),
 			Read:   schema.DefaultTimeout(10 * time.Minute),
@@ -54,6 +81,125 @@ func ResourceCluster() *schema.Resource {
 	}
 }
 
+// clusterAttributesWithoutUpdate are the configurable attributes of a cluster that cannot be changed once
+// the cluster exists. The VCF API only renames, expands, contracts, stretches and unstretches clusters.
+var clusterAttributesWithoutUpdate = []string{"cluster_image_id", "evc_mode", "high_availability_enabled",
+	"vsan_datastore", "vmfs_datastore", "vsan_remote_datastore_cluster", "nfs_datastores", "vvol_datastores",
+	"geneve_vlan_id", "ip_address_pool", "vds"}
+
+func validateClusterUpdate(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" {
+		return nil
+	}
+	unreadAttributes := getUnreadAttributes(diff.Get("unread_attributes"))
+	for _, attribute := range append([]string{"domain_id"}, clusterAttributesWithoutUpdate...) {
+		if oldValue, newValue := diff.GetChange(attribute); hasReadChange(attribute, oldValue, newValue, unreadAttributes) {
+			return newClusterAttributeUpdateError(attribute)
+		}
+	}
+	if oldStretch, newStretch := diff.GetChange("stretch"); len(oldStretch.([]interface{})) == 0 &&
+		len(newStretch.([]interface{})) > 0 && diff.Get("is_stretched").(bool) {
+		return fmt.Errorf("the cluster is already stretched, e.g. it was imported as a stretched cluster. " +
+			"Remove the stretch block, a stretched cluster cannot be stretched again")
+	}
+	if diff.HasChange("vsan_network") && !diff.HasChange("host") {
+		return fmt.Errorf("\"vsan_network\" only applies to the hosts added to the cluster, change it together with adding hosts")
+	}
+	return nil
+}
+
+// validateStretchedClusterHosts verifies that hosts added to a stretched cluster keep it balanced across
+// its availability zones, see cluster.ValidateStretchedClusterExpansion.
+func validateStretchedClusterHosts(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.Get("is_stretched").(bool) || !diff.HasChange("host") || !diff.NewValueKnown("host") {
+		return nil
+	}
+	oldHosts, newHosts := diff.GetChange("host")
+	return cluster.ValidateStretchedClusterExpansion(oldHosts.([]interface{}), newHosts.([]interface{}))
+}
+
+// checkClusterUpdate verifies that only the hosts of a cluster of a domain change, which is the only
+// change of the clusters of a domain that the domain update applies. The paths of unreadAttributes
+// are relative to the domain, prefix is the path of the cluster.
+func checkClusterUpdate(oldCluster, newCluster map[string]interface{}, prefix string, unreadAttributes map[string]bool) error {
+	for _, attribute := range append([]string{"name"}, clusterAttributesWithoutUpdate...) {
+		if hasReadChange(prefix+attribute, oldCluster[attribute], newCluster[attribute], unreadAttributes) {
+			return newClusterAttributeUpdateError(attribute)
+		}
+	}
+	return nil
+}
+
+// unreadAttributesSchema tracks the attributes an import could not read back, see cluster.UnreadAttributes.
+func unreadAttributesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Description: "Paths of the attributes that the VCF API does not return and that were therefore not read on import. " +
+			"They are taken from the configuration on the next apply, after which they cannot be changed like the other attributes",
+		Elem: &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+func getUnreadAttributes(unreadAttributesRaw interface{}) map[string]bool {
+	result := make(map[string]bool)
+	unreadAttributesList, _ := unreadAttributesRaw.([]interface{})
+	for _, attribute := range unreadAttributesList {
+		result[attribute.(string)] = true
+	}
+	return result
+}
+
+// clearUnreadAttributes plans to clear the unread attributes after an import, as the next apply takes
+// them from the configuration.
+func clearUnreadAttributes(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if len(diff.Get("unread_attributes").([]interface{})) == 0 {
+		return nil
+	}
+	return diff.SetNew("unread_attributes", []string{})
+}
+
+// hasReadChange reports whether the value of the attribute at path changes, apart from the attributes
+// in unreadAttributes that an import could not read back.
+func hasReadChange(path string, oldValue, newValue interface{}, unreadAttributes map[string]bool) bool {
+	if unreadAttributes[path] {
+		return false
+	}
+	switch oldTyped := oldValue.(type) {
+	case []interface{}:
+		newList, _ := newValue.([]interface{})
+		if len(oldTyped) != len(newList) {
+			return true
+		}
+		for i := range oldTyped {
+			if hasReadChange(fmt.Sprintf("%s.%d", path, i), oldTyped[i], newList[i], unreadAttributes) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		newMap, _ := newValue.(map[string]interface{})
+		for key, oldEntry := range oldTyped {
+			if hasReadChange(path+"."+key, oldEntry, newMap[key], unreadAttributes) {
+				return true
+			}
+		}
+		for key, newEntry := range newMap {
+			if _, ok := oldTyped[key]; !ok && hasReadChange(path+"."+key, nil, newEntry, unreadAttributes) {
+				return true
+			}
+		}
+		return false
+	default:
+		return !reflect.DeepEqual(oldValue, newValue)
+	}
+}
+
+func newClusterAttributeUpdateError(attribute string) error {
+	return fmt.Errorf("%q of an existing cluster cannot be changed, the VCF API only renames, expands, contracts, "+
+		"stretches and unstretches clusters. Replace the cluster to change it", attribute)
+}
+
 // clusterSubresourceSchema this helper function extracts the Cluster schema, so that
 // it's made available for merging in the Domain resource schema.
 func clusterSubresourceSchema() *schema.Resource {
@@ -73,14 +219,14 @@ func clusterSubresourceSchema() *schema.Resource {
 			"host": {
 				Type:        schema.TypeList,
 				Required:    true,
-				Description: "List of ESXi host information from the free pool to consume in a workload domain",
+				Description: "List of ESXi host information from the free pool to consume in a workload domain. Hosts are matched by their IDs, adding hosts expands and removing hosts contracts the cluster in place, in separate configuration changes",
 				MinItems:    2,
 				Elem:        cluster.HostSpecSchema(),
 			},
 			"cluster_image_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Description:  "ID of the cluster image to be used with the cluster",
+				Description:  "ID of the cluster image (personality) the cluster is managed with by vSphere Lifecycle Manager, e.g. from the vcf_personality data source. If omitted, the cluster is managed with baselines",
 				ValidateFunc: validation.NoZeroValues,
 			},
 			"evc_mode": {
@@ -136,7 +282,7 @@ func clusterSubresourceSchema() *schema.Resource {
 			"vsan_remote_datastore_cluster": {
 				Type:        schema.TypeList,
 				Optional:    true,
-				Description: "Cluster storage configuration for vSAN Remote Datastore",
+				Description: "vSAN HCI Mesh remote datastores of other clusters mounted by the cluster, e.g. by a compute-only cluster. Cannot be combined with vsan_datastore",
 				MaxItems:    1,
 				Elem:        datastores.VsanRemoteDatastoreClusterSchema(),
 			},
@@ -152,6 +298,9 @@ func clusterSubresourceSchema() *schema.Resource {
 				Description: "Cluster storage configuration for VVOL",
 				Elem:        datastores.VvolDatastoreSchema(),
 			},
+			// TODO support a TEP VLAN and IP address pool per rack (host group) within a cluster.
+			// The VCF API accepts a single Geneve VLAN and IP address pool per cluster, so in
+			// rack-routed overlay designs each rack has to be a separate cluster.
 			"geneve_vlan_id": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -166,6 +315,14 @@ func clusterSubresourceSchema() *schema.Resource {
 					"provide name only to reuse existing IP Pool, if subnets are provided a new IP Pool will be created",
 				Elem: network.IpAddressPoolSchema(),
 			},
+			"vsan_network": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Description: "vSAN network with a dedicated gateway for the vSAN VMkernel adapters of the hosts added to the cluster, " +
+					"e.g. in a routed vSAN design. If omitted, the gateway is derived from the network pool",
+				Elem: network.VsanNetworkSchema(),
+			},
 			"vds": {
 				Type:        schema.TypeList,
 				Required:    true,
@@ -193,6 +350,19 @@ func clusterSubresourceSchema() *schema.Resource {
 				Computed:    true,
 				Description: "Status of the cluster if stretched or not",
 			},
+			"workload_management_ready": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the cluster meets the prerequisites for enabling Workload Management (vSphere with Tanzu)",
+			},
+			"workload_management_blockers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Workload Management prerequisites that the cluster does not meet",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 		},
 	}
 }
@@ -200,19 +370,71 @@ func clusterSubresourceSchema() *schema.Resource {
 func resourceClusterCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 
-	clusterSpec, err := cluster.TryConvertResourceDataToClusterSpec(data)
+	resumedTaskId, err := vcfClient.ResumeTask(ctx, api_client.TaskFilter{
+		TaskTypes:    []string{constants.ClusterCreationTaskType},
+		ResourceType: "Cluster",
+		ResourceName: data.Get("name").(string),
+		DomainId:     data.Get("domain_id").(string),
+		CreatedAfter: time.Now().Add(-constants.ResumableCreationMaxAge),
+	}, data.Get("resume_failed_creation").(bool))
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	clusterId, diagnostics := createCluster(ctx, data.Get("domain_id").(string),
-		clusterSpec, vcfClient)
-	if diagnostics != nil {
-		return diagnostics
+	var clusterStretchSpec *models.ClusterStretchSpec
+	if len(data.Get("stretch").([]interface{})) > 0 {
+		clusterStretchSpec, err = cluster.CreateClusterStretchSpec(data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	var clusterId string
+	var diagnostics diag.Diagnostics
+	if resumedTaskId != "" {
+		clusterId, err = vcfClient.GetResourceIdAssociatedWithTask(ctx, resumedTaskId, "Cluster")
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		clusterSpec, err := cluster.TryConvertResourceDataToClusterSpec(data)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		clusterId, diagnostics = createCluster(ctx, data.Get("domain_id").(string),
+			clusterSpec, vcfClient)
+		if diagnostics != nil {
+			return diagnostics
+		}
 	}
 
 	data.SetId(clusterId)
 
-	return resourceClusterRead(ctx, data, meta)
+	if clusterStretchSpec != nil {
+		diagnostics = stretchCreatedCluster(ctx, data, clusterStretchSpec, vcfClient)
+	}
+
+	return append(diagnostics, resourceClusterRead(ctx, data, meta)...)
+}
+
+// stretchCreatedCluster stretches a cluster that was just created. A failed stretch is returned as
+// warnings with the stretch block removed from the state, so that the cluster is not replaced and
+// the next apply retries the stretch.
+func stretchCreatedCluster(ctx context.Context, data *schema.ResourceData, clusterStretchSpec *models.ClusterStretchSpec,
+	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	diagnostics := updateCluster(ctx, data.Id(), &models.ClusterUpdateSpec{ClusterStretchSpec: clusterStretchSpec}, vcfClient)
+	if !diagnostics.HasError() {
+		return diagnostics
+	}
+	_ = data.Set("stretch", nil)
+	warnings := diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("cluster %s was created, but stretching it failed", data.Id()),
+		Detail:   "The stretch is retried with the next apply",
+	}}
+	for _, diagnostic := range diagnostics {
+		diagnostic.Severity = diag.Warning
+		warnings = append(warnings, diagnostic)
+	}
+	return warnings
 }
 
 func resourceClusterRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -234,6 +456,12 @@ func resourceClusterRead(ctx context.Context, data *schema.ResourceData, meta in
 	_ = data.Set("is_default", clusterObj.IsDefault)
 	_ = data.Set("is_stretched", clusterObj.IsStretched)
 
+	workloadManagementReadiness := make(map[string]interface{})
+	cluster.NewWorkloadManagementReadiness(apiClient).Set(ctx, workloadManagementReadiness, clusterObj)
+	for attribute, value := range workloadManagementReadiness {
+		_ = data.Set(attribute, value)
+	}
+
 	return nil
 }
 
@@ -244,6 +472,16 @@ func resourceClusterUpdate(ctx context.Context, data *schema.ResourceData, meta
 	if err != nil {
 		return diag.FromErr(err)
 	}
+	if reflect.DeepEqual(clusterUpdateSpec, new(models.ClusterUpdateSpec)) {
+		// e.g. the hosts were only reordered or the settings of the resource itself changed
+		// the create-only attributes only change where an import could not read them, see validateClusterUpdate
+		if data.HasChangesExcept(append([]string{"host", "resume_failed_creation", "unread_attributes"},
+			clusterAttributesWithoutUpdate...)...) {
+			return diag.Errorf("the change of cluster %s cannot be applied, the VCF API only renames, expands, "+
+				"contracts, stretches and unstretches clusters", data.Id())
+		}
+		return resourceClusterRead(ctx, data, meta)
+	}
 
 	diagnostics := updateCluster(ctx, data.Id(), clusterUpdateSpec, vcfClient)
 	if diagnostics != nil {
@@ -256,6 +494,15 @@ func resourceClusterUpdate(ctx context.Context, data *schema.ResourceData, meta
 func resourceClusterDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 
+	if data.Get("is_stretched").(bool) {
+		log.Printf("Unstretching Cluster %s", data.Id())
+		diagnostics := updateCluster(ctx, data.Id(),
+			&models.ClusterUpdateSpec{ClusterUnstretchSpec: cluster.CreateClusterUnstretchSpec()}, vcfClient)
+		if diagnostics != nil {
+			return diagnostics
+		}
+	}
+
 	diagnostics := deleteCluster(ctx, data.Id(), vcfClient)
 	if diagnostics != nil {
 		return diagnostics
@@ -0,0 +1,255 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/personalities"
+	"github.com/vmware/vcf-sdk-go/client/tasks"
+	"github.com/vmware/vcf-sdk-go/client/upgradables"
+	"github.com/vmware/vcf-sdk-go/client/upgrades"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+const (
+	EvacuationPolicyEvacuate    = "EVACUATE"
+	EvacuationPolicyShutdownVms = "SHUTDOWN_VMS"
+)
+
+func ResourceClusterRemediation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceClusterRemediationCreate,
+		ReadContext:   resourceClusterRemediationRead,
+		DeleteContext: resourceClusterRemediationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(12 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the vSphere Lifecycle Manager image managed cluster to remediate",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"bundle_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the ESXi bundle used for the remediation",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"personality_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the personality (cluster image) the hosts of the cluster are remediated against",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"rolling": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+				Description: "Remediate the hosts one at a time. If false, components are remediated in parallel",
+			},
+			"evacuation_policy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      EvacuationPolicyEvacuate,
+				Description:  "How VMs are handled when a host enters maintenance mode. One among: EVACUATE, SHUTDOWN_VMS",
+				ValidateFunc: validation.StringInSlice([]string{EvacuationPolicyEvacuate, EvacuationPolicyShutdownVms}, false),
+			},
+			"enable_quickboot": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Use Quick Boot when rebooting the remediated hosts",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the remediation task",
+			},
+		},
+	}
+}
+
+func resourceClusterRemediationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+
+	clusterId := data.Get("cluster_id").(string)
+	personalityId := data.Get("personality_id").(string)
+	bundleId := data.Get("bundle_id").(string)
+
+	// Clusters that are not managed by a vLCM image have no personality to be remediated against
+	if err := checkClusterImageManaged(ctx, apiClient, clusterId); err != nil {
+		return diag.FromErr(err)
+	}
+	getPersonalityParams := personalities.NewGetPersonalityParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getPersonalityParams.PersonalityID = personalityId
+	if _, err := apiClient.Personalities.GetPersonality(getPersonalityParams); err != nil {
+		return diag.FromErr(fmt.Errorf("cluster %q cannot be remediated, personality %q not found: %w",
+			clusterId, personalityId, err))
+	}
+
+	// TODO support a per-cluster depot override for the remediation. The VCF API only has the global
+	// depot settings of SDDC Manager, neither UpgradeSpec nor the cluster specs accept a bundle source.
+	upgradeSpec := &models.UpgradeSpec{
+		BundleID:        &bundleId,
+		ParallelUpgrade: !data.Get("rolling").(bool),
+		ResourceType:    resource_utils.ToStringPointer("CLUSTER"),
+		ResourceUpgradeSpecs: []*models.ResourceUpgradeSpec{
+			{
+				ResourceID:      &clusterId,
+				EnableQuickboot: data.Get("enable_quickboot").(bool),
+				ShutdownVms:     data.Get("evacuation_policy").(string) == EvacuationPolicyShutdownVms,
+				UpgradeNow:      true,
+				PersonalitySpec: &models.PersonalitySpec{
+					PersonalityID: &personalityId,
+				},
+			},
+		},
+	}
+
+	performUpgradeParams := upgrades.NewPerformUpgradeParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	performUpgradeParams.UpgradeSpec = upgradeSpec
+
+	okResponse, acceptedResponse, err := apiClient.Upgrades.PerformUpgrade(performUpgradeParams)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	var taskId string
+	if okResponse != nil {
+		taskId = okResponse.Payload.ID
+	}
+	if acceptedResponse != nil {
+		taskId = acceptedResponse.Payload.ID
+	}
+	data.SetId(taskId)
+
+	if err = waitForClusterRemediation(ctx, vcfClient, taskId); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClusterRemediationRead(ctx, data, meta)
+}
+
+// checkClusterImageManaged returns an error if the cluster does not exist or is not managed by a
+// vSphere Lifecycle Manager image. Only the upgradables of the domain of the cluster tell whether vLCM
+// is enabled, the cluster itself does not.
+func checkClusterImageManaged(ctx context.Context, apiClient *client.VcfClient, clusterId string) error {
+	getDomainsParams := domains.NewGetDomainsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	domainsResult, err := apiClient.Domains.GetDomains(getDomainsParams)
+	if err != nil {
+		return err
+	}
+	domainId := findClusterDomainId(domainsResult.Payload.Elements, clusterId)
+	if domainId == "" {
+		return fmt.Errorf("cluster %q not found in any domain", clusterId)
+	}
+
+	getUpgradablesClustersParams := upgradables.NewGetUpgradablesClustersParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDomainID(domainId)
+	upgradablesClustersResult, err := apiClient.Upgradables.GetUpgradablesClusters(getUpgradablesClustersParams)
+	if err != nil {
+		return err
+	}
+	if !isClusterVlcmEnabled(upgradablesClustersResult.Payload.Elements, clusterId) {
+		return fmt.Errorf("cluster %q cannot be remediated, it is not managed by a vSphere Lifecycle Manager image", clusterId)
+	}
+	return nil
+}
+
+func findClusterDomainId(domainList []*models.Domain, clusterId string) string {
+	for _, domain := range domainList {
+		if domain == nil {
+			continue
+		}
+		for _, clusterReference := range domain.Clusters {
+			if clusterReference != nil && clusterReference.ID != nil && *clusterReference.ID == clusterId {
+				return domain.ID
+			}
+		}
+	}
+	return ""
+}
+
+func isClusterVlcmEnabled(upgradableClusters []*models.UpgradablesClusterResource, clusterId string) bool {
+	for _, upgradableCluster := range upgradableClusters {
+		if upgradableCluster != nil && upgradableCluster.ResourceID != nil && *upgradableCluster.ResourceID == clusterId {
+			return upgradableCluster.VlcmEnabled
+		}
+	}
+	return false
+}
+
+// waitForClusterRemediation waits for the remediation task and logs the progress of every host in the cluster.
+func waitForClusterRemediation(ctx context.Context, vcfClient *api_client.SddcManagerClient, taskId string) error {
+	return vcfClient.WaitForTaskCompleteWithProgress(ctx, taskId, false, hostProgressReporter(ctx))
+}
+
+// hostProgressReporter returns a task progress callback that logs every change of the state of the
+// subtasks of the ESXi hosts.
+func hostProgressReporter(ctx context.Context) func(task *models.Task) {
+	reportedStatuses := make(map[string]string)
+	return func(task *models.Task) {
+		for _, subTask := range task.SubTasks {
+			if subTask == nil {
+				continue
+			}
+			for _, resource := range subTask.Resources {
+				if resource == nil || resource.Type == nil || *resource.Type != "ESXI" {
+					continue
+				}
+				if reportedStatuses[subTask.Name] != subTask.Status {
+					reportedStatuses[subTask.Name] = subTask.Status
+					tflog.Info(ctx, fmt.Sprintf("Host %s: %q is in state %s", resource.Fqdn, subTask.Name, subTask.Status))
+				}
+			}
+		}
+	}
+}
+
+func resourceClusterRemediationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getTaskParams := tasks.NewGetTaskParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getTaskParams.ID = data.Id()
+	getTaskResult, err := apiClient.Tasks.GetTask(getTaskParams)
+	if err != nil {
+		tflog.Error(ctx, err.Error())
+		return diag.FromErr(err)
+	}
+
+	_ = data.Set("status", getTaskResult.Payload.Status)
+	return nil
+}
+
+// resourceClusterRemediationDelete only removes the resource from the state, a completed remediation cannot be reverted.
+func resourceClusterRemediationDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
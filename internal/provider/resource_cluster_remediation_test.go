@@ -0,0 +1,74 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/vcf-sdk-go/models"
+	"os"
+	"testing"
+)
+
+func TestAccResourceVcfClusterRemediation(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfClusterRemediationConfig(
+					os.Getenv(constants.VcfTestClusterDataSourceId),
+					os.Getenv(constants.VcfTestRemediationBundleId),
+					os.Getenv(constants.VcfTestRemediationPersonalityId)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_cluster_remediation.remediation", "id"),
+					resource.TestCheckResourceAttr("vcf_cluster_remediation.remediation", "status", "Successful"),
+				),
+			},
+		},
+	})
+}
+
+func testAccVcfClusterRemediationConfig(clusterId, bundleId, personalityId string) string {
+	return fmt.Sprintf(`
+	resource "vcf_cluster_remediation" "remediation" {
+		cluster_id        = %q
+		bundle_id         = %q
+		personality_id    = %q
+		rolling           = true
+		evacuation_policy = "EVACUATE"
+	}`, clusterId, bundleId, personalityId)
+}
+
+func TestCheckClusterImageManaged(t *testing.T) {
+	domainList := []*models.Domain{
+		{ID: "domain-1", Clusters: []*models.ClusterReference{{ID: resource_utils.ToStringPointer("cluster-1")}}},
+		nil,
+		{ID: "domain-2", Clusters: []*models.ClusterReference{{ID: resource_utils.ToStringPointer("cluster-2")}}},
+	}
+	if domainId := findClusterDomainId(domainList, "cluster-2"); domainId != "domain-2" {
+		t.Errorf("expected domain-2 for cluster-2, got %q", domainId)
+	}
+	if domainId := findClusterDomainId(domainList, "cluster-3"); domainId != "" {
+		t.Errorf("expected no domain for cluster-3, got %q", domainId)
+	}
+
+	upgradableClusters := []*models.UpgradablesClusterResource{
+		{ResourceID: resource_utils.ToStringPointer("cluster-1"), VlcmEnabled: false},
+		{ResourceID: resource_utils.ToStringPointer("cluster-2"), VlcmEnabled: true},
+	}
+	if isClusterVlcmEnabled(upgradableClusters, "cluster-1") {
+		t.Errorf("expected cluster-1 to be managed by baselines")
+	}
+	if !isClusterVlcmEnabled(upgradableClusters, "cluster-2") {
+		t.Errorf("expected cluster-2 to be managed by an image")
+	}
+	if isClusterVlcmEnabled(upgradableClusters, "cluster-3") {
+		t.Errorf("expected an unknown cluster not to be managed by an image")
+	}
+}
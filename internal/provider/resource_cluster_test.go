@@ -8,12 +8,21 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/cluster"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/datastores"
+	"github.com/vmware/terraform-provider-vcf/internal/network"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/clusters"
+	"github.com/vmware/vcf-sdk-go/client/domains"
+	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/models"
 	"log"
 	"os"
 	"strings"
@@ -403,3 +412,548 @@ func clusterImportStateCheck(states []*terraform.InstanceState) error {
 	}
 	return fmt.Errorf("cluster InstanceState not found! Import failed")
 }
+
+// testClustersClient returns a single cluster with its VDSes and datastores and fails the validation
+// of every cluster operation with validationError.
+type testClustersClient struct {
+	clusters.ClientService
+	cluster         *models.Cluster
+	vdses           []*models.Vds
+	datastores      []*models.Datastore
+	validationError error
+}
+
+func (c *testClustersClient) GetCluster(_ *clusters.GetClusterParams, _ ...clusters.ClientOption) (*clusters.GetClusterOK, error) {
+	return &clusters.GetClusterOK{Payload: c.cluster}, nil
+}
+
+func (c *testClustersClient) GetVdses(_ *clusters.GetVdsesParams, _ ...clusters.ClientOption) (*clusters.GetVdsesOK, error) {
+	return &clusters.GetVdsesOK{Payload: c.vdses}, nil
+}
+
+func (c *testClustersClient) GetClusterDatastores(_ *clusters.GetClusterDatastoresParams,
+	_ ...clusters.ClientOption) (*clusters.GetClusterDatastoresOK, error) {
+	return &clusters.GetClusterDatastoresOK{Payload: c.datastores}, nil
+}
+
+func (c *testClustersClient) ValidateClusterOperations(_ *clusters.ValidateClusterOperationsParams,
+	_ ...clusters.ClientOption) (*clusters.ValidateClusterOperationsOK, error) {
+	return nil, c.validationError
+}
+
+func TestStretchCreatedCluster(t *testing.T) {
+	meta := &api_client.SddcManagerClient{
+		ApiClient: &client.VcfClient{
+			Clusters: &testClustersClient{validationError: fmt.Errorf("witness host is not reachable")},
+		},
+	}
+
+	data := ResourceCluster().TestResourceData()
+	data.SetId("cluster-1")
+	_ = data.Set("stretch", []interface{}{map[string]interface{}{"secondary_az_overlay_vlan_id": 1644}})
+	diags := stretchCreatedCluster(context.Background(), data, &models.ClusterStretchSpec{}, meta)
+	if diags.HasError() {
+		t.Fatalf("expected a failed stretch of a created cluster to return warnings, got %v", diags)
+	}
+	if len(diags) != 2 || diags[1].Severity != diag.Warning || diags[1].Summary != "witness host is not reachable" {
+		t.Errorf("expected the failure of the stretch as a warning, got %v", diags)
+	}
+	if data.Id() != "cluster-1" {
+		t.Errorf("expected the created cluster to be kept, got ID %q", data.Id())
+	}
+	if stretch := data.Get("stretch").([]interface{}); len(stretch) != 0 {
+		t.Errorf("expected the stretch to be removed from the state, got %v", stretch)
+	}
+}
+
+// testHostsClient returns the hosts by ID.
+type testHostsClient struct {
+	hosts.ClientService
+	hosts map[string]*models.Host
+}
+
+func (c *testHostsClient) GetHost(params *hosts.GetHostParams, _ ...hosts.ClientOption) (*hosts.GetHostOK, error) {
+	return &hosts.GetHostOK{Payload: c.hosts[params.ID]}, nil
+}
+
+// testDomainsClient returns the domains.
+type testDomainsClient struct {
+	domains.ClientService
+	domains []*models.Domain
+}
+
+func (c *testDomainsClient) GetDomains(_ *domains.GetDomainsParams, _ ...domains.ClientOption) (*domains.GetDomainsOK, error) {
+	return &domains.GetDomainsOK{Payload: &models.PageOfDomain{Elements: c.domains}}, nil
+}
+
+func TestImportedClusterPlan(t *testing.T) {
+	clusterId := "cluster-1"
+	vdsName := "sfo-w01-cl01-vds01"
+	portgroupName := "sfo-w01-cl01-vds01-pg-mgmt"
+	transportType := "MANAGEMENT"
+	meta := &api_client.SddcManagerClient{
+		ApiClient: &client.VcfClient{
+			Clusters: &testClustersClient{
+				cluster: &models.Cluster{
+					ID:                   clusterId,
+					Name:                 "sfo-w01-cl01",
+					PrimaryDatastoreName: "sfo-w01-cl01-ds-vsan01",
+					PrimaryDatastoreType: "VSAN",
+					Hosts:                []*models.HostReference{{ID: "host-1"}, {ID: "host-2"}},
+				},
+				vdses: []*models.Vds{{
+					Name:         &vdsName,
+					IsUsedByNSXT: true,
+					PortGroups: []*models.Portgroup{{
+						Name:          &portgroupName,
+						TransportType: &transportType,
+						ActiveUplinks: []string{"uplink1", "uplink2"},
+					}},
+				}},
+			},
+			Hosts: &testHostsClient{hosts: map[string]*models.Host{
+				"host-1": {ID: "host-1", Fqdn: "sfo01-w01-esx01.sfo.rainpole.io"},
+				"host-2": {ID: "host-2", Fqdn: "sfo01-w01-esx02.sfo.rainpole.io"},
+			}},
+			Domains: &testDomainsClient{domains: []*models.Domain{{
+				ID:       "domain-1",
+				Clusters: []*models.ClusterReference{{ID: &clusterId}},
+			}}},
+		},
+	}
+
+	clusterResource := ResourceCluster()
+	data := clusterResource.TestResourceData()
+	data.SetId(clusterId)
+	importedData, err := clusterResource.Importer.StateContext(context.Background(), data, meta)
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+	state := importedData[0].State()
+
+	newHost := func(hostId string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":          hostId,
+			"license_key": "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX",
+			"vmnic": []interface{}{
+				map[string]interface{}{"id": "vmnic0", "vds_name": vdsName},
+				map[string]interface{}{"id": "vmnic1", "vds_name": vdsName},
+			},
+		}
+	}
+	newConfig := func(datastoreName string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"domain_id":                 "domain-1",
+			"name":                      "sfo-w01-cl01",
+			"host":                      []interface{}{newHost("host-1"), newHost("host-2")},
+			"evc_mode":                  "INTEL_CASCADELAKE",
+			"high_availability_enabled": true,
+			"geneve_vlan_id":            1644,
+			"ip_address_pool": []interface{}{map[string]interface{}{
+				"name": "sfo-w01-cl01-tep01",
+			}},
+			"vsan_datastore": []interface{}{map[string]interface{}{
+				"datastore_name":       datastoreName,
+				"failures_to_tolerate": 1,
+				"license_key":          "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX",
+			}},
+			"vds": []interface{}{map[string]interface{}{
+				"name":           vdsName,
+				"is_used_by_nsx": true,
+				"portgroup": []interface{}{map[string]interface{}{
+					"name":           portgroupName,
+					"transport_type": transportType,
+					"active_uplinks": []interface{}{"uplink1", "uplink2"},
+				}},
+			}},
+		})
+	}
+
+	diff, err := clusterResource.Diff(context.Background(), state, newConfig("sfo-w01-cl01-ds-vsan01"), meta)
+	if err != nil {
+		t.Fatalf("unexpected error planning the imported cluster: %s", err)
+	}
+	if unreadAttributes := diff.Attributes["unread_attributes.#"]; unreadAttributes == nil || unreadAttributes.New != "0" {
+		t.Errorf("expected the unread attributes to be cleared by the next apply, got %v", unreadAttributes)
+	}
+	if _, err = clusterResource.Diff(context.Background(), state, newConfig("sfo-w01-cl01-ds-vsan02"), meta); err == nil {
+		t.Error("expected an error for changing the vSAN datastore name of the imported cluster")
+	}
+
+	// once applied, the attributes are read like the ones of a created cluster
+	for attribute := range state.Attributes {
+		if strings.HasPrefix(attribute, "unread_attributes.") {
+			delete(state.Attributes, attribute)
+		}
+	}
+	state.Attributes["unread_attributes.#"] = "0"
+	if _, err = clusterResource.Diff(context.Background(), state, newConfig("sfo-w01-cl01-ds-vsan01"), meta); err == nil {
+		t.Error("expected an error for changing the EVC mode of a cluster that is not imported")
+	}
+}
+
+func TestStretchedClusterHostsPlan(t *testing.T) {
+	clusterSchema := ResourceCluster().Schema
+	hostsResource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"host":         clusterSchema["host"],
+			"is_stretched": clusterSchema["is_stretched"],
+		},
+		CustomizeDiff: validateStretchedClusterHosts,
+	}
+	state := &terraform.InstanceState{
+		ID: "cluster-1",
+		Attributes: map[string]string{
+			"id":                            "cluster-1",
+			"is_stretched":                  "true",
+			"host.#":                        "2",
+			"host.0.id":                     "host-1",
+			"host.0.availability_zone_name": "az1",
+			"host.1.id":                     "host-2",
+			"host.1.availability_zone_name": "az2",
+		},
+	}
+	newConfig := func(addedHosts ...map[string]interface{}) *terraform.ResourceConfig {
+		hosts := []interface{}{
+			map[string]interface{}{"id": "host-1", "availability_zone_name": "az1"},
+			map[string]interface{}{"id": "host-2", "availability_zone_name": "az2"},
+		}
+		for _, addedHost := range addedHosts {
+			hosts = append(hosts, addedHost)
+		}
+		return terraform.NewResourceConfigRaw(map[string]interface{}{"host": hosts})
+	}
+
+	testCases := []struct {
+		name        string
+		addedHosts  []map[string]interface{}
+		expectError string
+	}{
+		{
+			name: "balanced",
+			addedHosts: []map[string]interface{}{
+				{"id": "host-3", "availability_zone_name": "az1"},
+				{"id": "host-4", "availability_zone_name": "az2"},
+			},
+		},
+		{
+			name:        "single host",
+			addedHosts:  []map[string]interface{}{{"id": "host-3", "availability_zone_name": "az1"}},
+			expectError: "in pairs",
+		},
+		{
+			name:        "without availability zone",
+			addedHosts:  []map[string]interface{}{{"id": "host-3"}, {"id": "host-4"}},
+			expectError: "availability_zone_name is required",
+		},
+		{
+			name: "unbalanced cluster",
+			addedHosts: []map[string]interface{}{
+				{"id": "host-3", "availability_zone_name": "az1"},
+				{"id": "host-4", "availability_zone_name": "az3"},
+			},
+			expectError: "expected hosts in 2 availability zones",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			_, err := hostsResource.Diff(context.Background(), state, newConfig(testCase.addedHosts...), nil)
+			if testCase.expectError == "" {
+				if err != nil {
+					t.Errorf("unexpected error %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), testCase.expectError) {
+				t.Errorf("expected an error containing %q, got %v", testCase.expectError, err)
+			}
+		})
+	}
+}
+
+func TestClusterStretchSpec(t *testing.T) {
+	newStretch := func(azNames ...string) map[string]interface{} {
+		var secondaryAzHosts []interface{}
+		for i, azName := range azNames {
+			secondaryAzHosts = append(secondaryAzHosts, map[string]interface{}{
+				"id":                     fmt.Sprintf("host-%d", i),
+				"availability_zone_name": azName,
+			})
+		}
+		return map[string]interface{}{
+			"witness_host": []interface{}{map[string]interface{}{
+				"fqdn":      "sfo-m01-wit01.sfo.rainpole.io",
+				"vsan_ip":   "172.18.96.10",
+				"vsan_cidr": "172.18.96.0/24",
+			}},
+			"secondary_az_host":                        secondaryAzHosts,
+			"secondary_az_overlay_vlan_id":             1644,
+			"witness_traffic_shared_with_vsan_traffic": true,
+			"is_edge_cluster_configured_for_multi_az":  false,
+			"vsan_network":                             []interface{}{},
+		}
+	}
+
+	stretchSpec, err := cluster.TryConvertToClusterStretchSpec(newStretch("az2", "az2"), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(stretchSpec.HostSpecs) != 2 || *stretchSpec.SecondaryAzOverlayVlanID != 1644 ||
+		*stretchSpec.WitnessSpec.VSANIP != "172.18.96.10" || !stretchSpec.WitnessTrafficSharedWithVSANTraffic {
+		t.Errorf("unexpected ClusterStretchSpec %+v", stretchSpec)
+	}
+	if _, err = cluster.TryConvertToClusterStretchSpec(newStretch("az2", "az2"), 3); err == nil {
+		t.Error("expected an error for fewer secondary hosts than hosts of the cluster")
+	}
+	if _, err = cluster.TryConvertToClusterStretchSpec(newStretch("az2", "az3"), 2); err == nil {
+		t.Error("expected an error for secondary hosts in different availability zones")
+	}
+	if _, err = cluster.TryConvertToClusterStretchSpec(newStretch("az2", ""), 2); err == nil {
+		t.Error("expected an error for a secondary host without an availability zone")
+	}
+}
+
+func TestCheckVmNicVdsMapping(t *testing.T) {
+	newVds := func(names ...string) []*models.VdsSpec {
+		var vdsSpecs []*models.VdsSpec
+		for i := range names {
+			vdsSpecs = append(vdsSpecs, &models.VdsSpec{Name: &names[i]})
+		}
+		return vdsSpecs
+	}
+	newHost := func(vmNics ...*models.VMNic) []*models.HostSpec {
+		hostId := "host-1"
+		return []*models.HostSpec{{ID: &hostId, HostNetworkSpec: &models.HostNetworkSpec{VMNics: vmNics}}}
+	}
+
+	if err := cluster.CheckVmNicVdsMapping(newVds("sfo-w01-cl01-vds01"), newHost(
+		&models.VMNic{ID: "vmnic0"}, &models.VMNic{ID: "vmnic1"})); err != nil {
+		t.Errorf("unexpected error for a single vds: %s", err)
+	}
+	if err := cluster.CheckVmNicVdsMapping(newVds("sfo-w01-cl01-vds01", "sfo-w01-cl01-vds02"), newHost(
+		&models.VMNic{ID: "vmnic0", VdsName: "sfo-w01-cl01-vds01", Uplink: "uplink1"},
+		&models.VMNic{ID: "vmnic1", VdsName: "sfo-w01-cl01-vds01", Uplink: "uplink2"},
+		&models.VMNic{ID: "vmnic2", VdsName: "sfo-w01-cl01-vds02", Uplink: "uplink1"},
+		&models.VMNic{ID: "vmnic3", VdsName: "sfo-w01-cl01-vds02", Uplink: "uplink2"})); err != nil {
+		t.Errorf("unexpected error for two vds: %s", err)
+	}
+	if err := cluster.CheckVmNicVdsMapping(newVds("sfo-w01-cl01-vds01", "sfo-w01-cl01-vds02"), newHost(
+		&models.VMNic{ID: "vmnic0", VdsName: "sfo-w01-cl01-vds01"}, &models.VMNic{ID: "vmnic1"})); err == nil {
+		t.Error("expected an error for a vmnic without vds_name and two vds")
+	}
+	if err := cluster.CheckVmNicVdsMapping(newVds("sfo-w01-cl01-vds01"), newHost(
+		&models.VMNic{ID: "vmnic0", VdsName: "sfo-w01-cl01-vds02"})); err == nil {
+		t.Error("expected an error for a vmnic referencing an unknown vds")
+	}
+	if err := cluster.CheckVmNicVdsMapping(newVds("sfo-w01-cl01-vds01"), newHost(
+		&models.VMNic{ID: "vmnic0", VdsName: "sfo-w01-cl01-vds01", Uplink: "uplink1"},
+		&models.VMNic{ID: "vmnic1", VdsName: "sfo-w01-cl01-vds01", Uplink: "uplink1"})); err == nil {
+		t.Error("expected an error for an uplink assigned to two vmnics")
+	}
+	if err := cluster.CheckVmNicVdsMapping(newVds("sfo-w01-cl01-vds01", "sfo-w01-cl01-vds01"), nil); err == nil {
+		t.Error("expected an error for duplicate vds names")
+	}
+}
+
+func TestSetExpansionOrContractionSpec(t *testing.T) {
+	newHosts := func(ids ...string) []interface{} {
+		var hosts []interface{}
+		for _, id := range ids {
+			hosts = append(hosts, map[string]interface{}{"id": id})
+		}
+		return hosts
+	}
+	vdsList := []interface{}{map[string]interface{}{"name": "sfo-w01-cl01-vds01"}}
+
+	updateSpec, err := cluster.SetExpansionOrContractionSpec(new(models.ClusterUpdateSpec),
+		newHosts("host-1", "host-2", "host-3"), newHosts("host-3", "host-1", "host-2"), vdsList)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updateSpec.ClusterExpansionSpec != nil || updateSpec.ClusterCompactionSpec != nil {
+		t.Errorf("expected no expansion or contraction for reordered hosts, got %+v", updateSpec)
+	}
+
+	updateSpec, err = cluster.SetExpansionOrContractionSpec(new(models.ClusterUpdateSpec),
+		newHosts("host-1", "host-2", "host-3"), newHosts("host-4", "host-2", "host-1", "host-3"), vdsList)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updateSpec.ClusterExpansionSpec == nil || len(updateSpec.ClusterExpansionSpec.HostSpecs) != 1 ||
+		*updateSpec.ClusterExpansionSpec.HostSpecs[0].ID != "host-4" || updateSpec.ClusterCompactionSpec != nil {
+		t.Errorf("expected the expansion with host-4, got %+v", updateSpec)
+	}
+
+	updateSpec, err = cluster.SetExpansionOrContractionSpec(new(models.ClusterUpdateSpec),
+		newHosts("host-1", "host-2", "host-3", "host-4"), newHosts("host-4", "host-1", "host-3"), vdsList)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if updateSpec.ClusterCompactionSpec == nil || len(updateSpec.ClusterCompactionSpec.Hosts) != 1 ||
+		updateSpec.ClusterCompactionSpec.Hosts[0].ID != "host-2" || updateSpec.ClusterExpansionSpec != nil {
+		t.Errorf("expected the contraction with host-2, got %+v", updateSpec)
+	}
+
+	if _, err = cluster.SetExpansionOrContractionSpec(new(models.ClusterUpdateSpec),
+		newHosts("host-1", "host-2", "host-3"), newHosts("host-1", "host-2", "host-4", "host-5"), vdsList); err == nil {
+		t.Error("expected an error for hosts added and removed in a single change")
+	}
+
+	addedHost := map[string]interface{}{
+		"id":    "host-4",
+		"vmnic": []interface{}{map[string]interface{}{"id": "vmnic0", "vds_name": "sfo-w01-cl01-vds02"}},
+	}
+	if _, err = cluster.SetExpansionOrContractionSpec(new(models.ClusterUpdateSpec),
+		newHosts("host-1", "host-2", "host-3"), append(newHosts("host-1", "host-2", "host-3"), addedHost), vdsList); err == nil {
+		t.Error("expected an error for an added host with a vmnic referencing an unknown vds")
+	}
+}
+
+func TestTryConvertToVSANRemoteDatastoreClusterSpec(t *testing.T) {
+	remoteDatastoreClusterSpec, err := datastores.TryConvertToVSANRemoteDatastoreClusterSpec(map[string]interface{}{
+		"datastore_uuids": []interface{}{"vsan-datastore-1", "vsan-datastore-2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(remoteDatastoreClusterSpec.VSANRemoteDatastoreSpec) != 2 ||
+		*remoteDatastoreClusterSpec.VSANRemoteDatastoreSpec[1].DatastoreUUID != "vsan-datastore-2" {
+		t.Errorf("unexpected VSANRemoteDatastoreClusterSpec %+v", remoteDatastoreClusterSpec)
+	}
+
+	if _, err = datastores.TryConvertToVSANRemoteDatastoreClusterSpec(map[string]interface{}{
+		"datastore_uuids": []interface{}{"vsan-datastore-1", "vsan-datastore-1"},
+	}); err == nil {
+		t.Error("expected an error for a duplicate datastore UUID")
+	}
+}
+
+func TestTryConvertToVmfsDatastoreSpec(t *testing.T) {
+	vmfsDatastoreSpec, err := datastores.TryConvertToVmfsDatastoreSpec(map[string]interface{}{
+		"datastore_names": []interface{}{"sfo-w01-cl01-fc01", "sfo-w01-cl01-fc02"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(vmfsDatastoreSpec.FcSpec) != 2 || *vmfsDatastoreSpec.FcSpec[0].DatastoreName != "sfo-w01-cl01-fc01" ||
+		*vmfsDatastoreSpec.FcSpec[1].DatastoreName != "sfo-w01-cl01-fc02" {
+		t.Errorf("unexpected VmfsDatastoreSpec %+v", vmfsDatastoreSpec)
+	}
+}
+
+func TestTryConvertToVvolDatastoreSpec(t *testing.T) {
+	vvolDatastoreSpec, err := datastores.TryConvertToVvolDatastoreSpec(map[string]interface{}{
+		"datastore_name":        "sfo-w01-cl01-vvol01",
+		"storage_container_id":  "5eb2c5f8-9f8f-4aa1-8b5b-2f3a6bf0f2a1",
+		"storage_protocol_type": "iscsi",
+		"user_id":               "0a1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d",
+		"vasa_provider_id":      "1b2c3d4e-5f6a-4b7c-8d9e-0f1a2b3c4d5e",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if *vvolDatastoreSpec.Name != "sfo-w01-cl01-vvol01" || *vvolDatastoreSpec.VasaProviderSpec.StorageProtocolType != "ISCSI" {
+		t.Errorf("unexpected VvolDatastoreSpec %+v", vvolDatastoreSpec)
+	}
+}
+
+func TestFlattenClusterDatastores(t *testing.T) {
+	clusterDatastores := []*models.Datastore{
+		{ID: "datastore-1", Name: "sfo-w01-cl01-fc01", DatastoreType: "VMFS_FC"},
+		{ID: "datastore-2", Name: "sfo-w01-cl01-fc02", DatastoreType: "VMFS_FC"},
+		{ID: "datastore-3", Name: "sfo-w01-cl02-ds-vsan01", DatastoreType: "VSAN"},
+	}
+
+	flattenedDatastores := cluster.FlattenClusterDatastores("sfo-w01-cl01-ds-vsan01", "VSAN", nil)
+	if fmt.Sprint(flattenedDatastores) != "map[vsan_datastore:[map[datastore_name:sfo-w01-cl01-ds-vsan01]]]" {
+		t.Errorf("unexpected vSAN datastores %v", flattenedDatastores)
+	}
+	flattenedDatastores = cluster.FlattenClusterDatastores("sfo-w01-cl01-fc02", "VMFS_FC", clusterDatastores)
+	if fmt.Sprint(flattenedDatastores) != "map[vmfs_datastore:[map[datastore_names:[sfo-w01-cl01-fc02 sfo-w01-cl01-fc01]]]]" {
+		t.Errorf("unexpected VMFS datastores %v", flattenedDatastores)
+	}
+	flattenedDatastores = cluster.FlattenClusterDatastores("sfo-w01-cl02-ds-vsan01", "VSAN_REMOTE", clusterDatastores)
+	if fmt.Sprint(flattenedDatastores) != "map[vsan_remote_datastore_cluster:[map[datastore_uuids:[datastore-3]]]]" {
+		t.Errorf("unexpected remote vSAN datastores %v", flattenedDatastores)
+	}
+	if flattenedDatastores = cluster.FlattenClusterDatastores("sfo-w01-cl01-nfs01", "NFS", clusterDatastores); len(flattenedDatastores) != 0 {
+		t.Errorf("expected no NFS datastores, got %v", flattenedDatastores)
+	}
+}
+
+func TestFlattenVds(t *testing.T) {
+	vdsName, portgroupName, transportType := "sfo-w01-cl01-vds01", "sfo-w01-cl01-vds01-pg-vmotion", "VMOTION"
+	limit, reservation := int64(-1), int64(0)
+	flattenedVds := network.FlattenVds(&models.Vds{
+		Name: &vdsName,
+		NiocBandwidthAllocations: []*models.NiocBandwidthAllocation{
+			{Type: "vmotion", NiocTrafficResourceAllocation: &models.NiocTrafficResourceAllocation{
+				Limit: &limit, Reservation: &reservation, SharesInfo: &models.SharesInfo{Level: "normal", Shares: 50},
+			}},
+			{Type: "vsan"},
+		},
+		PortGroups: []*models.Portgroup{
+			{Name: &portgroupName, TransportType: &transportType, ActiveUplinks: []string{"uplink1", "uplink2"}},
+			{Name: &portgroupName},
+		},
+	})
+	if flattenedVds["name"] != vdsName {
+		t.Errorf("unexpected name %v", flattenedVds["name"])
+	}
+	niocBandwidthAllocations := flattenedVds["nioc_bandwidth_allocations"].([]map[string]interface{})
+	if len(niocBandwidthAllocations) != 1 || niocBandwidthAllocations[0]["type"] != "vmotion" ||
+		niocBandwidthAllocations[0]["shares_level"] != "normal" {
+		t.Errorf("unexpected NIOC bandwidth allocations %v", niocBandwidthAllocations)
+	}
+	portgroups := flattenedVds["portgroup"].([]map[string]interface{})
+	if len(portgroups) != 1 || portgroups[0]["name"] != portgroupName || portgroups[0]["transport_type"] != transportType {
+		t.Errorf("unexpected portgroups %v", portgroups)
+	}
+}
+
+func TestCheckClusterUpdate(t *testing.T) {
+	newCluster := func(hostIds []interface{}, vdsName string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":   "cluster-1",
+			"name": "sfo-w01-cl01",
+			"host": hostIds,
+			"vds":  []interface{}{map[string]interface{}{"name": vdsName}},
+		}
+	}
+	oldCluster := newCluster([]interface{}{"host-1", "host-2"}, "sfo-w01-cl01-vds01")
+
+	if err := checkClusterUpdate(oldCluster, newCluster([]interface{}{"host-1", "host-2", "host-3"}, "sfo-w01-cl01-vds01"),
+		"cluster.0.", nil); err != nil {
+		t.Errorf("unexpected error for adding a host: %s", err)
+	}
+	if err := checkClusterUpdate(oldCluster, newCluster([]interface{}{"host-1", "host-2"}, "sfo-w01-cl01-vds02"),
+		"cluster.0.", nil); err == nil {
+		t.Error("expected an error for changing the VDS of an existing cluster")
+	}
+
+	// e.g. after an import, which cannot read back the Geneve VLAN and the vSAN failures to tolerate
+	stateCluster := newCluster([]interface{}{"host-1", "host-2"}, "sfo-w01-cl01-vds01")
+	stateCluster["vsan_datastore"] = []interface{}{map[string]interface{}{
+		"datastore_name": "sfo-w01-cl01-ds-vsan01", "failures_to_tolerate": 0}}
+	configuredCluster := newCluster([]interface{}{"host-1", "host-2"}, "sfo-w01-cl01-vds01")
+	configuredCluster["geneve_vlan_id"] = 1644
+	configuredCluster["vsan_datastore"] = []interface{}{map[string]interface{}{
+		"datastore_name": "sfo-w01-cl01-ds-vsan01", "failures_to_tolerate": 1}}
+	unreadAttributes := map[string]bool{"cluster.0.geneve_vlan_id": true, "cluster.0.vsan_datastore.0.failures_to_tolerate": true}
+	if err := checkClusterUpdate(stateCluster, configuredCluster, "cluster.0.", unreadAttributes); err != nil {
+		t.Errorf("unexpected error for attributes that were not read on import: %s", err)
+	}
+	if err := checkClusterUpdate(stateCluster, configuredCluster, "cluster.1.", unreadAttributes); err == nil {
+		t.Error("expected an error for attributes that were not read on import of another cluster")
+	}
+	// a created cluster has all its attributes in the state, even the empty ones
+	if err := checkClusterUpdate(stateCluster, configuredCluster, "cluster.0.", nil); err == nil {
+		t.Error("expected an error for changing the Geneve VLAN of a created cluster")
+	}
+	configuredCluster["vsan_datastore"] = []interface{}{map[string]interface{}{
+		"datastore_name": "sfo-w01-cl01-ds-vsan02", "failures_to_tolerate": 1}}
+	if err := checkClusterUpdate(stateCluster, configuredCluster, "cluster.0.", unreadAttributes); err == nil {
+		t.Error("expected an error for changing the vSAN datastore name of an existing cluster")
+	}
+}
@@ -0,0 +1,314 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/credentials"
+	credentials_api "github.com/vmware/vcf-sdk-go/client/credentials"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+var credentialsOperationTypes = []string{"ROTATE", "UPDATE", "REMEDIATE", "UPDATE_AUTO_ROTATE_POLICY"}
+
+func ResourceCredentialsRotation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCredentialsRotationCreate,
+		ReadContext:   resourceCredentialsRotationRead,
+		DeleteContext: resourceCredentialsRotationDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(1 * time.Hour),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		CustomizeDiff: validateCredentialsOperation,
+		Schema: map[string]*schema.Schema{
+			"resource_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the resource whose credentials are changed, e.g. the FQDN of an ESXi host or a vCenter",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"resource_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Type of the resource whose credentials are changed. One among: ESXI, VCENTER, PSC, NSXT_MANAGER, NSXT_EDGE, VXRAIL_MANAGER, BACKUP",
+				ValidateFunc: validation.StringInSlice([]string{"ESXI", "VCENTER", "PSC", "NSXT_MANAGER", "NSXT_EDGE", "VXRAIL_MANAGER", "BACKUP"}, false),
+			},
+			"operation_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Password operation. One among: ROTATE, UPDATE, REMEDIATE, UPDATE_AUTO_ROTATE_POLICY. ROTATE generates new passwords, UPDATE sets the passwords on the resource and in SDDC Manager, REMEDIATE updates the passwords in SDDC Manager after they have been changed on the resource",
+				ValidateFunc: validation.StringInSlice(credentialsOperationTypes, false),
+			},
+			"auto_rotate_frequency_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Frequency in days of the scheduled rotation of the credentials. Only allowed with UPDATE_AUTO_ROTATE_POLICY, which disables the scheduled rotation when omitted",
+				ValidateFunc: validation.IntBetween(1, 90),
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that trigger the operation again when changed, e.g. a timestamp for periodic rotation",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"credentials": {
+				Type:        schema.TypeList,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Description: "Credentials of the resource to change",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"credential_type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Credential type. One among: SSO, SSH, API, FTP, AUDIT",
+							ValidateFunc: validation.StringInSlice([]string{"SSO", "SSH", "API", "FTP", "AUDIT"}, false),
+						},
+						"username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Username of the credential",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "New password of the credential. Required with UPDATE and REMEDIATE, not allowed otherwise",
+						},
+					},
+				},
+			},
+			"credential": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Current version of the changed credentials, as stored in SDDC Manager",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the credential",
+						},
+						"credential_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Credential type",
+						},
+						"username": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Username of the credential",
+						},
+						"password": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Sensitive:   true,
+							Description: "Current password of the credential",
+						},
+						"modification_timestamp": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Time of the last change of the credential, which identifies its version",
+						},
+						"expiry_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Expiry date of the password",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateCredentialsOperation(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("operation_type") || hasUnknownPasswords(diff.GetRawConfig()) {
+		return nil
+	}
+	var passwords []string
+	for _, credential := range diff.Get("credentials").([]interface{}) {
+		if credential == nil {
+			continue
+		}
+		passwords = append(passwords, credential.(map[string]interface{})["password"].(string))
+	}
+	_, autoRotateFrequencySet := diff.GetOk("auto_rotate_frequency_days")
+	return checkCredentialsOperation(diff.Get("operation_type").(string), passwords, autoRotateFrequencySet)
+}
+
+// hasUnknownPasswords reports whether the credentials or any of their passwords are unknown in the
+// configuration, e.g. until a password generated by another resource is created. The nested passwords
+// of a known list of credentials are not checked by ResourceDiff.NewValueKnown.
+func hasUnknownPasswords(rawConfig cty.Value) bool {
+	if !rawConfig.IsKnown() {
+		return true
+	}
+	if rawConfig.IsNull() {
+		return false
+	}
+	credentialsConfig := rawConfig.GetAttr("credentials")
+	if !credentialsConfig.IsKnown() {
+		return true
+	}
+	if credentialsConfig.IsNull() {
+		return false
+	}
+	for iterator := credentialsConfig.ElementIterator(); iterator.Next(); {
+		_, credential := iterator.Element()
+		if !credential.IsKnown() || (!credential.IsNull() && !credential.GetAttr("password").IsKnown()) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkCredentialsOperation verifies that new passwords are provided exactly for the operations
+// that set them and that the scheduled rotation is configured only with UPDATE_AUTO_ROTATE_POLICY.
+func checkCredentialsOperation(operationType string, passwords []string, autoRotateFrequencySet bool) error {
+	passwordsRequired := operationType == "UPDATE" || operationType == "REMEDIATE"
+	for _, password := range passwords {
+		if passwordsRequired && password == "" {
+			return fmt.Errorf("\"password\" has to be provided for every credential with %s", operationType)
+		}
+		if !passwordsRequired && password != "" {
+			return fmt.Errorf("\"password\" is not allowed with %s", operationType)
+		}
+	}
+	if operationType != "UPDATE_AUTO_ROTATE_POLICY" && autoRotateFrequencySet {
+		return fmt.Errorf("\"auto_rotate_frequency_days\" is only allowed with UPDATE_AUTO_ROTATE_POLICY")
+	}
+	return nil
+}
+
+func resourceCredentialsRotationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+
+	credentialsUpdateSpec := getCredentialsUpdateSpec(data)
+	updateOrRotatePasswordsParams := credentials_api.NewUpdateOrRotatePasswordsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithCredentialsUpdateSpec(credentialsUpdateSpec)
+
+	responseOk, responseAccepted, err := apiClient.Credentials.UpdateOrRotatePasswords(updateOrRotatePasswordsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForCredentialsTask(ctx, taskId); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(taskId)
+
+	return resourceCredentialsRotationRead(ctx, data, meta)
+}
+
+func resourceCredentialsRotationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	resourceName := data.Get("resource_name").(string)
+	resourceType := data.Get("resource_type").(string)
+	getCredentialsParams := credentials_api.NewGetCredentialsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithResourceName(&resourceName).
+		WithResourceType(&resourceType)
+	getCredentialsResponse, err := apiClient.Credentials.GetCredentials(getCredentialsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	flattenedCredentials := *new([]map[string]interface{})
+	for _, credential := range data.Get("credentials").([]interface{}) {
+		credentialData := credential.(map[string]interface{})
+		current := credentials.FindCredential(getCredentialsResponse.Payload.Elements,
+			credentialData["credential_type"].(string), credentialData["username"].(string))
+		if current == nil {
+			tflog.Warn(ctx, fmt.Sprintf("Credential %s of type %s of %s not found",
+				credentialData["username"], credentialData["credential_type"], resourceName))
+			continue
+		}
+		flattenedCredential := map[string]interface{}{
+			"id":                     current.ID,
+			"credential_type":        current.CredentialType,
+			"username":               current.Username,
+			"password":               current.Password,
+			"modification_timestamp": current.ModificationTimestamp,
+		}
+		if current.Expiry != nil {
+			flattenedCredential["expiry_date"] = current.Expiry.ExpiryDate
+		}
+		flattenedCredentials = append(flattenedCredentials, flattenedCredential)
+	}
+	_ = data.Set("credential", flattenedCredentials)
+
+	return nil
+}
+
+// resourceCredentialsRotationDelete only removes the operation from the state, changed passwords
+// are not reverted.
+func resourceCredentialsRotationDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
+
+func getCredentialsUpdateSpec(data *schema.ResourceData) *models.CredentialsUpdateSpec {
+	operationType := data.Get("operation_type").(string)
+	resourceType := data.Get("resource_type").(string)
+
+	var baseCredentials []*models.BaseCredential
+	for _, credential := range data.Get("credentials").([]interface{}) {
+		credentialData := credential.(map[string]interface{})
+		username := credentialData["username"].(string)
+		baseCredentials = append(baseCredentials, &models.BaseCredential{
+			CredentialType: credentialData["credential_type"].(string),
+			Username:       &username,
+			Password:       credentialData["password"].(string),
+		})
+	}
+
+	credentialsUpdateSpec := &models.CredentialsUpdateSpec{
+		OperationType: &operationType,
+		Elements: []*models.ResourceCredentials{
+			{
+				ResourceName: data.Get("resource_name").(string),
+				ResourceType: &resourceType,
+				Credentials:  baseCredentials,
+			},
+		},
+	}
+	if operationType == "UPDATE_AUTO_ROTATE_POLICY" {
+		frequencyInDays := data.Get("auto_rotate_frequency_days").(int)
+		credentialsUpdateSpec.AutoRotatePolicy = &models.AutoRotateCredentialPolicyInputSpec{
+			EnableAutoRotatePolicy: frequencyInDays > 0,
+			FrequencyInDays:        int32(frequencyInDays),
+		}
+	}
+	return credentialsUpdateSpec
+}
@@ -0,0 +1,58 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"testing"
+)
+
+func TestCheckCredentialsOperation(t *testing.T) {
+	if err := checkCredentialsOperation("ROTATE", []string{"", ""}, false); err != nil {
+		t.Errorf("unexpected error for a rotation: %s", err)
+	}
+	if err := checkCredentialsOperation("UPDATE", []string{"VMware123!VMware123!"}, false); err != nil {
+		t.Errorf("unexpected error for an update with a password: %s", err)
+	}
+	if err := checkCredentialsOperation("REMEDIATE", []string{"VMware123!VMware123!", ""}, false); err == nil {
+		t.Error("expected an error for a remediation without a password")
+	}
+	if err := checkCredentialsOperation("ROTATE", []string{"VMware123!VMware123!"}, false); err == nil {
+		t.Error("expected an error for a rotation with a password")
+	}
+	if err := checkCredentialsOperation("UPDATE_AUTO_ROTATE_POLICY", []string{""}, true); err != nil {
+		t.Errorf("unexpected error for an auto rotate policy update: %s", err)
+	}
+	if err := checkCredentialsOperation("ROTATE", []string{""}, true); err == nil {
+		t.Error("expected an error for a rotation with an auto rotate frequency")
+	}
+}
+
+func TestHasUnknownPasswords(t *testing.T) {
+	newConfig := func(password cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"credentials": cty.ListVal([]cty.Value{
+				cty.ObjectVal(map[string]cty.Value{
+					"username": cty.StringVal("root"),
+					"password": cty.StringVal("VMware123!VMware123!"),
+				}),
+				cty.ObjectVal(map[string]cty.Value{
+					"username": cty.StringVal("admin"),
+					"password": password,
+				}),
+			}),
+		})
+	}
+	if hasUnknownPasswords(newConfig(cty.StringVal("VMware123!VMware123!"))) {
+		t.Error("unexpected unknown passwords for known passwords")
+	}
+	if hasUnknownPasswords(newConfig(cty.NullVal(cty.String))) {
+		t.Error("unexpected unknown passwords for an omitted password")
+	}
+	if !hasUnknownPasswords(newConfig(cty.UnknownVal(cty.String))) {
+		t.Error("expected unknown passwords for a password that is not known yet")
+	}
+}
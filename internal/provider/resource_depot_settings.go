@@ -0,0 +1,184 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/depot_settings"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+const depotSettingsId = "sddc-manager-depot-settings"
+
+var depotAccounts = []string{"vmware_account", "dell_emc_account"}
+
+func ResourceDepotSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDepotSettingsCreate,
+		ReadContext:   resourceDepotSettingsRead,
+		UpdateContext: resourceDepotSettingsUpdate,
+		DeleteContext: resourceDepotSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				data.SetId(depotSettingsId)
+				return []*schema.ResourceData{data}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"vmware_account": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "Account of the VMware Depot from which SDDC Manager downloads bundles",
+				Elem:         depotAccountSchema(),
+				AtLeastOneOf: depotAccounts,
+			},
+			"dell_emc_account": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				MaxItems:     1,
+				Description:  "Dell EMC support account from which SDDC Manager downloads VxRail bundles",
+				Elem:         depotAccountSchema(),
+				AtLeastOneOf: depotAccounts,
+			},
+		},
+	}
+}
+
+func depotAccountSchema() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Username of the depot account",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "Password of the depot account",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the connection to the depot. One among: DEPOT_UNKNOWN_HOST, DEPOT_NOT_AVAILABLE, DEPOT_USER_NOT_SET, DEPOT_INVALID_CREDENTIAL, UNKNOWN_FAILURE, DEPOT_CONNECTION_SUCCESSFUL",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Message explaining the status of the connection to the depot",
+			},
+		},
+	}
+}
+
+func resourceDepotSettingsCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := updateDepotSettings(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+		return diags
+	}
+	data.SetId(depotSettingsId)
+
+	return resourceDepotSettingsRead(ctx, data, meta)
+}
+
+func resourceDepotSettingsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getDepotSettingsParams := depot_settings.NewGetDepotSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	depotSettingsResult, err := apiClient.DepotSettings.GetDepotSettings(getDepotSettingsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// SDDC Manager has a single depot settings element
+	if len(depotSettingsResult.Payload) == 0 || depotSettingsResult.Payload[0] == nil {
+		return diag.Errorf("no depot settings found in SDDC Manager")
+	}
+	depotSettings := depotSettingsResult.Payload[0]
+	_ = data.Set("vmware_account", flattenDepotAccount(depotSettings.VMWAREAccount, data.Get("vmware_account").([]interface{})))
+	_ = data.Set("dell_emc_account", flattenDepotAccount(depotSettings.DellEmcSupportAccount, data.Get("dell_emc_account").([]interface{})))
+
+	return nil
+}
+
+func resourceDepotSettingsUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := updateDepotSettings(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+		return diags
+	}
+
+	return resourceDepotSettingsRead(ctx, data, meta)
+}
+
+// resourceDepotSettingsDelete only removes the depot settings from the state, the VCF API cannot
+// remove the depot accounts.
+func resourceDepotSettingsDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
+
+func updateDepotSettings(ctx context.Context, data *schema.ResourceData, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	updateDepotSettingsParams := depot_settings.NewUpdateDepotSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDepotSettings(getDepotSettings(data))
+
+	_, _, err := vcfClient.ApiClient.DepotSettings.UpdateDepotSettings(updateDepotSettingsParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	return nil
+}
+
+func getDepotSettings(data *schema.ResourceData) *models.DepotSettings {
+	return &models.DepotSettings{
+		VMWAREAccount:         getDepotAccount(data.Get("vmware_account").([]interface{})),
+		DellEmcSupportAccount: getDepotAccount(data.Get("dell_emc_account").([]interface{})),
+	}
+}
+
+func getDepotAccount(depotAccountList []interface{}) *models.DepotAccount {
+	if len(depotAccountList) == 0 || depotAccountList[0] == nil {
+		return nil
+	}
+	depotAccount := depotAccountList[0].(map[string]interface{})
+	username := depotAccount["username"].(string)
+	password := depotAccount["password"].(string)
+	return &models.DepotAccount{
+		Username: &username,
+		Password: &password,
+	}
+}
+
+// flattenDepotAccount flattens a depot account, keeping the password of the configured account as
+// the VCF API does not return it.
+func flattenDepotAccount(depotAccount *models.DepotAccount, configuredAccountList []interface{}) []map[string]interface{} {
+	if depotAccount == nil || depotAccount.Username == nil || len(*depotAccount.Username) == 0 {
+		return nil
+	}
+	flattenedDepotAccount := map[string]interface{}{
+		"username": *depotAccount.Username,
+		"password": "",
+		"status":   depotAccount.Status,
+		"message":  depotAccount.Message,
+	}
+	if len(configuredAccountList) > 0 && configuredAccountList[0] != nil {
+		flattenedDepotAccount["password"] = configuredAccountList[0].(map[string]interface{})["password"]
+	}
+	return []map[string]interface{}{flattenedDepotAccount}
+}
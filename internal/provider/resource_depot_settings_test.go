@@ -0,0 +1,55 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestGetDepotSettings(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, ResourceDepotSettings().Schema, map[string]interface{}{
+		"vmware_account": []interface{}{
+			map[string]interface{}{
+				"username": "depot-user@rainpole.io",
+				"password": "VMware1!",
+			},
+		},
+	})
+
+	depotSettings := getDepotSettings(data)
+	if depotSettings.DellEmcSupportAccount != nil {
+		t.Errorf("expected no Dell EMC support account, got %+v", depotSettings.DellEmcSupportAccount)
+	}
+	vmwareAccount := depotSettings.VMWAREAccount
+	if vmwareAccount == nil || *vmwareAccount.Username != "depot-user@rainpole.io" || *vmwareAccount.Password != "VMware1!" {
+		t.Errorf("unexpected VMware Depot account %+v", vmwareAccount)
+	}
+}
+
+func TestFlattenDepotAccount(t *testing.T) {
+	if flattenedDepotAccount := flattenDepotAccount(nil, nil); flattenedDepotAccount != nil {
+		t.Errorf("expected no depot account, got %v", flattenedDepotAccount)
+	}
+
+	username := "depot-user@rainpole.io"
+	depotAccount := &models.DepotAccount{
+		Username: &username,
+		Status:   "DEPOT_CONNECTION_SUCCESSFUL",
+	}
+	configuredAccountList := []interface{}{
+		map[string]interface{}{
+			"username": username,
+			"password": "VMware1!",
+		},
+	}
+	flattenedDepotAccount := flattenDepotAccount(depotAccount, configuredAccountList)
+	if len(flattenedDepotAccount) != 1 || flattenedDepotAccount[0]["password"] != "VMware1!" ||
+		flattenedDepotAccount[0]["status"] != "DEPOT_CONNECTION_SUCCESSFUL" {
+		t.Errorf("unexpected flattened depot account %v", flattenedDepotAccount)
+	}
+}
@@ -0,0 +1,267 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/system"
+	"github.com/vmware/vcf-sdk-go/models"
+	"sort"
+	"time"
+)
+
+const dnsConfigurationId = "dns-configuration"
+
+// systemConfigurationValidationPollInterval is the interval between polls of the status of a
+// validation of the DNS or NTP configuration.
+var systemConfigurationValidationPollInterval = 10 * time.Second
+
+func ResourceDnsConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceDnsConfigurationCreate,
+		ReadContext:   resourceDnsConfigurationRead,
+		UpdateContext: resourceDnsConfigurationUpdate,
+		DeleteContext: resourceDnsConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				data.SetId(dnsConfigurationId)
+				return []*schema.ResourceData{data}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Hour),
+		},
+		CustomizeDiff: validateDnsConfiguration,
+		Schema: map[string]*schema.Schema{
+			"dns_server": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "DNS servers of all VCF components",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "IP address of the DNS server",
+							ValidateFunc:     validation.IsIPAddress,
+							DiffSuppressFunc: validationutils.SuppressIpAddressDiff,
+						},
+						"is_primary": {
+							Type:        schema.TypeBool,
+							Required:    true,
+							Description: "Whether the DNS server is the primary DNS server. Exactly one DNS server has to be the primary",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceDnsConfigurationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := configureDns(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+		return diags
+	}
+	data.SetId(dnsConfigurationId)
+
+	return resourceDnsConfigurationRead(ctx, data, meta)
+}
+
+func resourceDnsConfigurationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getDnsConfigurationParams := system.NewGetDNSConfigurationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	dnsConfigurationResult, err := apiClient.System.GetDNSConfiguration(getDnsConfigurationParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	_ = data.Set("dns_server", flattenDnsServers(dnsConfigurationResult.Payload.DNSServers,
+		data.Get("dns_server").([]interface{})))
+
+	return nil
+}
+
+func resourceDnsConfigurationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := configureDns(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+		return diags
+	}
+
+	return resourceDnsConfigurationRead(ctx, data, meta)
+}
+
+// resourceDnsConfigurationDelete only removes the DNS configuration from the state, the VCF
+// components keep using the configured DNS servers.
+func resourceDnsConfigurationDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
+
+// configureDns validates the DNS configuration and applies it to all VCF components.
+func configureDns(ctx context.Context, data *schema.ResourceData, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	apiClient := vcfClient.ApiClient
+	dnsConfiguration := getDnsConfiguration(data.Get("dns_server").([]interface{}))
+
+	validateDnsConfigurationParams := system.NewValidateDNSConfigurationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDNSConfiguration(dnsConfiguration)
+	validationOk, validationAccepted, err := apiClient.System.ValidateDNSConfiguration(validateDnsConfigurationParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var validationResult *models.Validation
+	if validationOk != nil {
+		validationResult = validationOk.Payload
+	} else {
+		validationResult = validationAccepted.Payload
+	}
+	diags := waitForSystemConfigurationValidation(ctx, validationResult, func(validationId string) (*models.Validation, error) {
+		getValidationParams := system.NewGetValidationOfDNSConfigurationParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(validationId)
+		getValidationResult, err := apiClient.System.GetValidationOfDNSConfiguration(getValidationParams)
+		if err != nil {
+			return nil, err
+		}
+		return getValidationResult.Payload, nil
+	})
+	if diags != nil {
+		return diags
+	}
+
+	configureDnsParams := system.NewConfigureDNSParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithDNSConfiguration(dnsConfiguration)
+	responseOk, responseAccepted, err := apiClient.System.ConfigureDNS(configureDnsParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// waitForSystemConfigurationValidation polls a validation of the DNS or NTP configuration with
+// getValidation until its checks have finished and converts the failed checks to diagnostics.
+func waitForSystemConfigurationValidation(ctx context.Context, validationResult *models.Validation,
+	getValidation func(validationId string) (*models.Validation, error)) diag.Diagnostics {
+	if validationResult == nil {
+		return diag.Errorf("the validation returned no result")
+	}
+	for validationResult.ExecutionStatus == "IN_PROGRESS" ||
+		!validationutils.HaveValidationChecksFinished(validationResult.ValidationChecks) {
+		select {
+		case <-ctx.Done():
+			return diag.Errorf("stopped waiting for validation %s: %s", validationResult.ID, ctx.Err())
+		case <-time.After(systemConfigurationValidationPollInterval):
+		}
+		validationId := validationResult.ID
+		var err error
+		if validationResult, err = getValidation(validationId); err != nil {
+			return validationutils.ConvertVcfErrorToDiag(err)
+		}
+		if validationResult == nil {
+			return diag.Errorf("validation %s returned no result", validationId)
+		}
+	}
+	if validationutils.HasValidationFailed(validationResult) {
+		return validationutils.ConvertValidationResultToDiag(validationResult)
+	}
+	return nil
+}
+
+func validateDnsConfiguration(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.GetRawConfig().GetAttr("dns_server").IsWhollyKnown() {
+		return nil
+	}
+	return checkDnsServers(diff.Get("dns_server").([]interface{}))
+}
+
+// checkDnsServers verifies that the DNS servers are not repeated and that exactly one of them is the primary.
+func checkDnsServers(dnsServerList []interface{}) error {
+	var ipAddresses []string
+	primaryDnsServers := 0
+	for _, dnsServerRaw := range dnsServerList {
+		dnsServer, ok := dnsServerRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		ipAddresses = append(ipAddresses, validationutils.NormalizeIpAddress(dnsServer["ip_address"].(string)))
+		if dnsServer["is_primary"].(bool) {
+			primaryDnsServers++
+		}
+	}
+	if _, errs := validationutils.ValidateDnsServersSchema(ipAddresses, "dns_server"); len(errs) > 0 {
+		return errs[0]
+	}
+	if primaryDnsServers != 1 {
+		return fmt.Errorf("exactly one DNS server has to be the primary, got %d", primaryDnsServers)
+	}
+	return nil
+}
+
+func getDnsConfiguration(dnsServerList []interface{}) *models.DNSConfiguration {
+	dnsConfiguration := &models.DNSConfiguration{}
+	for _, dnsServerRaw := range dnsServerList {
+		dnsServer := dnsServerRaw.(map[string]interface{})
+		ipAddress := dnsServer["ip_address"].(string)
+		isPrimary := dnsServer["is_primary"].(bool)
+		dnsConfiguration.DNSServers = append(dnsConfiguration.DNSServers, &models.DNSServer{
+			IPAddress: &ipAddress,
+			IsPrimary: &isPrimary,
+		})
+	}
+	return dnsConfiguration
+}
+
+// flattenDnsServers flattens the DNS servers in the configured order, which SDDC Manager does not keep.
+// DNS servers that are not configured, e.g. after an import, follow with the primary DNS server first.
+func flattenDnsServers(dnsServers []*models.DNSServer, configuredDnsServers []interface{}) []map[string]interface{} {
+	configuredPositions := make(map[string]int, len(configuredDnsServers))
+	for position, configuredDnsServerRaw := range configuredDnsServers {
+		if configuredDnsServer, ok := configuredDnsServerRaw.(map[string]interface{}); ok {
+			configuredPositions[validationutils.NormalizeIpAddress(configuredDnsServer["ip_address"].(string))] = position
+		}
+	}
+	getPosition := func(flattenedDnsServer map[string]interface{}) int {
+		if position, configured := configuredPositions[validationutils.NormalizeIpAddress(flattenedDnsServer["ip_address"].(string))]; configured {
+			return position
+		}
+		if flattenedDnsServer["is_primary"].(bool) {
+			return len(configuredDnsServers)
+		}
+		return len(configuredDnsServers) + 1
+	}
+
+	flattenedDnsServers := *new([]map[string]interface{})
+	for _, dnsServer := range dnsServers {
+		if dnsServer == nil || dnsServer.IPAddress == nil {
+			continue
+		}
+		flattenedDnsServers = append(flattenedDnsServers, map[string]interface{}{
+			"ip_address": *dnsServer.IPAddress,
+			"is_primary": dnsServer.IsPrimary != nil && *dnsServer.IsPrimary,
+		})
+	}
+	sort.SliceStable(flattenedDnsServers, func(i, j int) bool {
+		return getPosition(flattenedDnsServers[i]) < getPosition(flattenedDnsServers[j])
+	})
+	return flattenedDnsServers
+}
@@ -0,0 +1,114 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+	"time"
+)
+
+func TestGetDnsConfiguration(t *testing.T) {
+	dnsConfiguration := getDnsConfiguration([]interface{}{
+		map[string]interface{}{"ip_address": "172.16.11.5", "is_primary": false},
+		map[string]interface{}{"ip_address": "172.16.11.4", "is_primary": true},
+	})
+	if len(dnsConfiguration.DNSServers) != 2 || *dnsConfiguration.DNSServers[1].IPAddress != "172.16.11.4" ||
+		!*dnsConfiguration.DNSServers[1].IsPrimary || *dnsConfiguration.DNSServers[0].IsPrimary {
+		t.Errorf("unexpected DNS configuration %+v", dnsConfiguration)
+	}
+}
+
+func TestFlattenDnsServers(t *testing.T) {
+	newDnsServer := func(ipAddress string, isPrimary bool) *models.DNSServer {
+		return &models.DNSServer{IPAddress: &ipAddress, IsPrimary: &isPrimary}
+	}
+	dnsServers := []*models.DNSServer{
+		newDnsServer("172.16.11.4", true), newDnsServer("172.16.11.6", false), newDnsServer("172.16.11.5", false),
+	}
+
+	flattenedDnsServers := flattenDnsServers(dnsServers, []interface{}{
+		map[string]interface{}{"ip_address": "172.16.11.5", "is_primary": false},
+		map[string]interface{}{"ip_address": "172.16.11.6", "is_primary": false},
+		map[string]interface{}{"ip_address": "172.16.11.4", "is_primary": true},
+	})
+	if len(flattenedDnsServers) != 3 || flattenedDnsServers[0]["ip_address"] != "172.16.11.5" ||
+		flattenedDnsServers[1]["ip_address"] != "172.16.11.6" || flattenedDnsServers[2]["ip_address"] != "172.16.11.4" {
+		t.Errorf("expected the configured order, got %v", flattenedDnsServers)
+	}
+
+	flattenedDnsServers = flattenDnsServers([]*models.DNSServer{dnsServers[1], dnsServers[0]}, nil)
+	if len(flattenedDnsServers) != 2 || flattenedDnsServers[0]["ip_address"] != "172.16.11.4" ||
+		flattenedDnsServers[0]["is_primary"] != true {
+		t.Errorf("expected the primary DNS server first without configuration, got %v", flattenedDnsServers)
+	}
+}
+
+func TestCheckDnsServers(t *testing.T) {
+	if err := checkDnsServers([]interface{}{
+		map[string]interface{}{"ip_address": "172.16.11.4", "is_primary": true},
+		map[string]interface{}{"ip_address": "172.16.11.5", "is_primary": false},
+	}); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+	if err := checkDnsServers([]interface{}{
+		map[string]interface{}{"ip_address": "172.16.11.4", "is_primary": false},
+	}); err == nil {
+		t.Error("expected an error for a DNS configuration without a primary DNS server")
+	}
+	if err := checkDnsServers([]interface{}{
+		map[string]interface{}{"ip_address": "172.16.11.4", "is_primary": true},
+		map[string]interface{}{"ip_address": "172.16.11.5", "is_primary": true},
+	}); err == nil {
+		t.Error("expected an error for a DNS configuration with two primary DNS servers")
+	}
+	if err := checkDnsServers([]interface{}{
+		map[string]interface{}{"ip_address": "2001:db8::53", "is_primary": true},
+		map[string]interface{}{"ip_address": "2001:0db8:0:0:0:0:0:53", "is_primary": false},
+	}); err == nil {
+		t.Error("expected an error for a repeated DNS server")
+	}
+}
+
+func TestWaitForSystemConfigurationValidation(t *testing.T) {
+	defaultPollInterval := systemConfigurationValidationPollInterval
+	systemConfigurationValidationPollInterval = time.Millisecond
+	t.Cleanup(func() { systemConfigurationValidationPollInterval = defaultPollInterval })
+	newValidation := func(executionStatus, resultStatus string) *models.Validation {
+		return &models.Validation{
+			ID:              "validation-1",
+			ExecutionStatus: executionStatus,
+			ResultStatus:    resultStatus,
+			ValidationChecks: []*models.ValidationCheck{{
+				Description:   "Validate DNS servers",
+				ResultStatus:  resultStatus,
+				ErrorResponse: &models.Error{Message: "DNS server 172.16.11.5 is not reachable"},
+			}},
+		}
+	}
+
+	polls := 0
+	diags := waitForSystemConfigurationValidation(context.Background(), newValidation("IN_PROGRESS", "IN_PROGRESS"),
+		func(validationId string) (*models.Validation, error) {
+			polls++
+			if polls < 3 {
+				return newValidation("IN_PROGRESS", "IN_PROGRESS"), nil
+			}
+			return newValidation("COMPLETED", "SUCCEEDED"), nil
+		})
+	if diags != nil || polls != 3 {
+		t.Errorf("expected a successful validation after 3 polls, got %v after %d polls", diags, polls)
+	}
+
+	diags = waitForSystemConfigurationValidation(context.Background(), newValidation("IN_PROGRESS", "IN_PROGRESS"),
+		func(validationId string) (*models.Validation, error) {
+			return newValidation("COMPLETED", "FAILED"), nil
+		})
+	if len(diags) != 1 || diags[0].Detail != "DNS server 172.16.11.5 is not reachable" {
+		t.Errorf("expected the failed validation check, got %v", diags)
+	}
+}
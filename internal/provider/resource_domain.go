@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
@@ -18,9 +19,11 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/vcenter"
+	"github.com/vmware/vcf-sdk-go/client/credentials"
 	"github.com/vmware/vcf-sdk-go/client/domains"
 	"github.com/vmware/vcf-sdk-go/models"
 	"reflect"
+	"strings"
 	"time"
 )
 
@@ -37,9 +40,20 @@ func ResourceDomain() *schema.Resource {
 				domainId := data.Id()
 				// NOTE: Management domain cannot be imported, to not allow users to accidentally delete it,
 				// but it can be used as datasource
-				return domain.ImportDomain(ctx, data, apiClient, domainId, false)
+				importedData, err := domain.ImportDomain(ctx, data, apiClient, domainId, false)
+				if err != nil {
+					return nil, err
+				}
+				var unreadAttributes []string
+				for i := range data.Get("cluster").([]interface{}) {
+					unreadAttributes = append(unreadAttributes, cluster.UnreadAttributes(data, fmt.Sprintf("cluster.%d.", i))...)
+				}
+				_ = data.Set("unread_attributes", unreadAttributes)
+				_ = data.Set("resume_failed_creation", false)
+				return importedData, domain.SetImportedPasswords(ctx, data, apiClient)
 			},
 		},
+		CustomizeDiff: customdiff.All(validateDomainVsanDatastoreNames, validateDomainClusterUpdates, clearUnreadAttributes),
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(4 * time.Hour),
 			Read:   schema.DefaultTimeout(20 * time.Minute),
@@ -81,6 +95,21 @@ func ResourceDomain() *schema.Resource {
 				MinItems:    1,
 				Elem:        clusterSubresourceSchema(),
 			},
+			"unread_attributes": unreadAttributesSchema(),
+			"resume_failed_creation": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If the latest creation of a workload domain with the same name failed within the last 24 hours, retry the failed " +
+					"workflow instead of submitting a new creation. The workflow is retried with the specification it was submitted with, " +
+					"changes to the configuration since are not applied. " +
+					"A creation that is still running, e.g. after Terraform was interrupted, is always waited for",
+			},
+			"creation_task_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the task that created the workload domain",
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -101,6 +130,17 @@ func ResourceDomain() *schema.Resource {
 				Computed:    true,
 				Description: "Name of the SSO domain associated with the workload domain",
 			},
+			"sso_admin_username": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Username of the SSO administrator of the SSO domain of the workload domain, which is the one of the management domain if the workload domain joined it. Unset if SDDC Manager does not manage the credentials",
+			},
+			"sso_admin_password": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Current password of the SSO administrator of the SSO domain of the workload domain, which is the one of the management domain if the workload domain joined it. Unset if SDDC Manager does not manage the credentials",
+			},
 			"is_management_sso_domain": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -110,10 +150,92 @@ func ResourceDomain() *schema.Resource {
 	}
 }
 
+// validateDomainVsanDatastoreNames rejects clusters that declare the same vSAN datastore name, as
+// datastore names must be unique within the vCenter Server of the domain.
+func validateDomainVsanDatastoreNames(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("cluster") {
+		return nil
+	}
+	return checkUniqueVsanDatastoreNames(diff.Get("cluster").([]interface{}))
+}
+
+func validateDomainClusterUpdates(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.GetRawConfig().GetAttr("cluster").IsWhollyKnown() {
+		return nil
+	}
+	unreadAttributes := getUnreadAttributes(diff.Get("unread_attributes"))
+	oldClusters, newClusters := diff.GetChange("cluster")
+	oldClustersList := oldClusters.([]interface{})
+	newClustersList := newClusters.([]interface{})
+	// clusters are added or removed instead
+	if len(oldClustersList) != len(newClustersList) {
+		return nil
+	}
+	for i, newCluster := range newClustersList {
+		oldClusterMap, oldOk := oldClustersList[i].(map[string]interface{})
+		newClusterMap, newOk := newCluster.(map[string]interface{})
+		if !oldOk || !newOk {
+			continue
+		}
+		if err := checkClusterUpdate(oldClusterMap, newClusterMap, fmt.Sprintf("cluster.%d.", i), unreadAttributes); err != nil {
+			return err
+		}
+		if isStretched, _ := oldClusterMap["is_stretched"].(bool); isStretched {
+			oldHosts, _ := oldClusterMap["host"].([]interface{})
+			newHosts, _ := newClusterMap["host"].([]interface{})
+			if err := cluster.ValidateStretchedClusterExpansion(oldHosts, newHosts); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func checkUniqueVsanDatastoreNames(clusters []interface{}) error {
+	clusterNamesByDatastoreName := make(map[string]string)
+	for _, clusterRaw := range clusters {
+		clusterMap, ok := clusterRaw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		vsanDatastores, _ := clusterMap["vsan_datastore"].([]interface{})
+		for _, vsanDatastoreRaw := range vsanDatastores {
+			vsanDatastore, ok := vsanDatastoreRaw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			datastoreName, _ := vsanDatastore["datastore_name"].(string)
+			if datastoreName == "" {
+				continue
+			}
+			clusterName, _ := clusterMap["name"].(string)
+			if otherClusterName, exists := clusterNamesByDatastoreName[strings.ToLower(datastoreName)]; exists {
+				return fmt.Errorf("vSAN datastore name %q of cluster %q is already used by cluster %q",
+					datastoreName, clusterName, otherClusterName)
+			}
+			clusterNamesByDatastoreName[strings.ToLower(datastoreName)] = clusterName
+		}
+	}
+	return nil
+}
+
 func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 
+	resumedTaskId, err := vcfClient.ResumeTask(ctx, api_client.TaskFilter{
+		TaskTypes:    []string{constants.DomainCreationTaskType},
+		ResourceType: "Domain",
+		ResourceName: data.Get("name").(string),
+		CreatedAfter: time.Now().Add(-constants.ResumableCreationMaxAge),
+	}, data.Get("resume_failed_creation").(bool))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if resumedTaskId != "" {
+		return setDomainIdFromCreationTask(ctx, data, meta, resumedTaskId)
+	}
+
 	domainCreationSpec, err := domain.CreateDomainCreationSpec(data)
 	if err != nil {
 		return diag.FromErr(err)
@@ -139,16 +261,25 @@ func resourceDomainCreate(ctx context.Context, data *schema.ResourceData, meta i
 		return validationUtils.ConvertVcfErrorToDiag(err)
 	}
 	taskId := accepted.Payload.ID
+	// TODO support completing NSX host transport preparation as a separate step. The domain creation
+	// in the VCF API is a single task that only completes once the hosts are prepared for NSX.
 	err = vcfClient.WaitForTaskComplete(ctx, taskId, true)
 	if err != nil {
 		return diag.FromErr(err)
 	}
+
+	return setDomainIdFromCreationTask(ctx, data, meta, taskId)
+}
+
+func setDomainIdFromCreationTask(ctx context.Context, data *schema.ResourceData, meta interface{}, taskId string) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
 	domainId, err := vcfClient.GetResourceIdAssociatedWithTask(ctx, taskId, "Domain")
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	data.SetId(domainId)
+	_ = data.Set("creation_task_id", taskId)
 
 	return resourceDomainRead(ctx, data, meta)
 }
@@ -166,10 +297,10 @@ func resourceDomainRead(ctx context.Context, data *schema.ResourceData, meta int
 	if err != nil {
 		return diag.FromErr(err)
 	}
-	nsxtClusterConfigRaw := data.Get("nsx_configuration").([]interface{})
-	nsxtClusterConfig := nsxtClusterConfigRaw[0].(map[string]interface{})
-	nsxtClusterConfig["id"] = domainObj.NSXTCluster.ID
-	_ = data.Set("nsx_configuration", nsxtClusterConfigRaw)
+	err = domain.ReadAndSetNsxDataToDomainResource(domainObj.NSXTCluster, ctx, data, apiClient)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	return nil
 }
@@ -197,12 +328,22 @@ func resourceDomainUpdate(ctx context.Context, data *schema.ResourceData, meta i
 		}
 	}
 
+	if data.HasChange("nsx_configuration.0.password_version") {
+		diags := updateNsxManagerPasswords(ctx, data, vcfClient)
+		if diags != nil {
+			return diags
+		}
+	}
+
 	if data.HasChange("cluster") {
 		oldClustersValue, newClustersValue := data.GetChange("cluster")
 		newClustersList := newClustersValue.([]interface{})
 		oldClustersList := oldClustersValue.([]interface{})
 		if len(oldClustersList) == len(newClustersList) {
-			diags := handleClusterUpdateInDomain(ctx, newClustersList, oldClustersList, vcfClient)
+			// the unread attributes are cleared by this apply, see clearUnreadAttributes
+			oldUnreadAttributes, _ := data.GetChange("unread_attributes")
+			diags := handleClusterUpdateInDomain(ctx, newClustersList, oldClustersList,
+				getUnreadAttributes(oldUnreadAttributes), vcfClient)
 			if diags != nil {
 				return diags
 			}
@@ -217,6 +358,63 @@ func resourceDomainUpdate(ctx context.Context, data *schema.ResourceData, meta i
 	return resourceDomainRead(ctx, data, meta)
 }
 
+// updateNsxManagerPasswords sets the configured admin and audit passwords on the NSX Manager cluster
+// of the domain and in SDDC Manager.
+func updateNsxManagerPasswords(ctx context.Context, data *schema.ResourceData,
+	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	nsxConfig := data.Get("nsx_configuration").([]interface{})[0].(map[string]interface{})
+	credentialsUpdateSpec := getNsxManagerCredentialsUpdateSpec(nsxConfig["vip_fqdn"].(string),
+		nsxConfig["nsx_manager_admin_password"].(string), nsxConfig["nsx_manager_audit_password"].(string))
+	updateOrRotatePasswordsParams := credentials.NewUpdateOrRotatePasswordsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithCredentialsUpdateSpec(credentialsUpdateSpec)
+
+	responseOk, responseAccepted, err := vcfClient.ApiClient.Credentials.UpdateOrRotatePasswords(updateOrRotatePasswordsParams)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForCredentialsTask(ctx, taskId); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// getNsxManagerCredentialsUpdateSpec returns the spec that updates the admin and, if set, the audit password
+// of the NSX Manager cluster with the VIP FQDN vipFqdn.
+func getNsxManagerCredentialsUpdateSpec(vipFqdn, adminPassword, auditPassword string) *models.CredentialsUpdateSpec {
+	operationType := "UPDATE"
+	resourceType := "NSXT_MANAGER"
+	baseCredentials := []*models.BaseCredential{
+		{
+			CredentialType: "API",
+			Username:       resource_utils.ToStringPointer("admin"),
+			Password:       adminPassword,
+		},
+	}
+	if len(auditPassword) > 0 {
+		baseCredentials = append(baseCredentials, &models.BaseCredential{
+			CredentialType: "AUDIT",
+			Username:       resource_utils.ToStringPointer("audit"),
+			Password:       auditPassword,
+		})
+	}
+	return &models.CredentialsUpdateSpec{
+		OperationType: &operationType,
+		Elements: []*models.ResourceCredentials{
+			{
+				ResourceName: vipFqdn,
+				ResourceType: &resourceType,
+				Credentials:  baseCredentials,
+			},
+		},
+	}
+}
+
 func handleClusterAddRemoveToDomain(ctx context.Context, domainId string, newClustersList, oldClustersList []interface{},
 	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
 	addedClustersList, removedClustersList := resource_utils.CalculateAddedRemovedResources(newClustersList, oldClustersList)
@@ -244,7 +442,7 @@ func handleClusterAddRemoveToDomain(ctx context.Context, domainId string, newClu
 }
 
 func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, oldClustersStateList []interface{},
-	vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	unreadAttributes map[string]bool, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
 	if len(oldClustersStateList) != len(newClustersStateList) {
 		return diag.FromErr(fmt.Errorf("expecting old and new cluster list to have the same length"))
 	}
@@ -261,15 +459,26 @@ func handleClusterUpdateInDomain(ctx context.Context, newClustersStateList, oldC
 		if newClusterStateId != oldClusterStateId {
 			return diag.FromErr(fmt.Errorf("cluster order has changed, updating hosts in cluster not supported"))
 		}
+		if err := checkClusterUpdate(oldClusterStateMap, newClusterStateMap, fmt.Sprintf("cluster.%d.", i), unreadAttributes); err != nil {
+			return diag.FromErr(err)
+		}
 		oldHostsList := oldClusterStateMap["host"].([]interface{})
 		newHostsList := newClusterStateMap["host"].([]interface{})
 		if reflect.DeepEqual(oldHostsList, newHostsList) {
-			tflog.Warn(ctx, "only expand/contract cluster update is supported")
 			continue
 		}
 
 		clusterUpdateSpec := new(models.ClusterUpdateSpec)
-		populatedClusterUpdateSpec, err := cluster.SetExpansionOrContractionSpec(clusterUpdateSpec, oldHostsList, newHostsList)
+		populatedClusterUpdateSpec, err := cluster.SetExpansionOrContractionSpec(clusterUpdateSpec, oldHostsList, newHostsList,
+			newClusterStateMap["vds"].([]interface{}))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if populatedClusterUpdateSpec.ClusterExpansionSpec == nil && populatedClusterUpdateSpec.ClusterCompactionSpec == nil {
+			tflog.Warn(ctx, "only adding and removing hosts is supported, hosts already in the cluster are not updated")
+			continue
+		}
+		err = cluster.SetVsanNetworkSpecs(populatedClusterUpdateSpec, newClusterStateMap["vsan_network"].([]interface{}))
 		if err != nil {
 			return diag.FromErr(err)
 		}
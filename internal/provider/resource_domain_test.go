@@ -593,3 +593,43 @@ func domainImportStateCheck(states []*terraform.InstanceState) error {
 	}
 	return fmt.Errorf("domain InstanceState not found! Import failed")
 }
+
+func TestCheckUniqueVsanDatastoreNames(t *testing.T) {
+	newCluster := func(clusterName, datastoreName string) map[string]interface{} {
+		return map[string]interface{}{
+			"name": clusterName,
+			"vsan_datastore": []interface{}{
+				map[string]interface{}{"datastore_name": datastoreName},
+			},
+		}
+	}
+
+	if err := checkUniqueVsanDatastoreNames([]interface{}{
+		newCluster("sfo-w01-cl01", "sfo-w01-cl01-ds-vsan01"),
+		newCluster("sfo-w01-cl02", "sfo-w01-cl02-ds-vsan01"),
+	}); err != nil {
+		t.Errorf("unexpected error for unique datastore names: %s", err)
+	}
+	if err := checkUniqueVsanDatastoreNames([]interface{}{
+		newCluster("sfo-w01-cl01", "sfo-w01-ds-vsan01"),
+		newCluster("sfo-w01-cl02", "SFO-W01-DS-VSAN01"),
+	}); err == nil {
+		t.Error("expected an error for a duplicate datastore name")
+	}
+}
+
+func TestGetNsxManagerCredentialsUpdateSpec(t *testing.T) {
+	credentialsUpdateSpec := getNsxManagerCredentialsUpdateSpec("sfo-w01-nsx01.sfo.rainpole.io", "VMware1!VMware1!", "")
+	credentials := credentialsUpdateSpec.Elements[0].Credentials
+	if *credentialsUpdateSpec.OperationType != "UPDATE" || credentialsUpdateSpec.Elements[0].ResourceName != "sfo-w01-nsx01.sfo.rainpole.io" ||
+		len(credentials) != 1 || credentials[0].CredentialType != "API" || *credentials[0].Username != "admin" {
+		t.Errorf("unexpected CredentialsUpdateSpec %+v", credentialsUpdateSpec)
+	}
+
+	credentialsUpdateSpec = getNsxManagerCredentialsUpdateSpec("sfo-w01-nsx01.sfo.rainpole.io", "VMware1!VMware1!", "VMware2!VMware2!")
+	credentials = credentialsUpdateSpec.Elements[0].Credentials
+	if len(credentials) != 2 || credentials[1].CredentialType != "AUDIT" || *credentials[1].Username != "audit" ||
+		credentials[1].Password != "VMware2!VMware2!" {
+		t.Errorf("unexpected audit credential in CredentialsUpdateSpec %+v", credentialsUpdateSpec)
+	}
+}
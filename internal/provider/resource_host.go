@@ -5,22 +5,38 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/clusters"
 	"github.com/vmware/vcf-sdk-go/client/credentials"
 	"github.com/vmware/vcf-sdk-go/client/hosts"
+	"github.com/vmware/vcf-sdk-go/client/network_pools"
 	"github.com/vmware/vcf-sdk-go/models"
 
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+var hostStorageTypes = []string{"VSAN", "VSAN_REMOTE", "NFS", "VMFS_FC", "VVOL"}
+
+// TODO add a vcf_host_syslog resource for the syslog target and scratch location of a host once
+// SDDC Manager exposes these host settings. The VCF API has no syslog or scratch configuration,
+// and the provider does not connect to vCenter or the hosts directly.
+// TODO add a vcf_host_maintenance_mode resource that enters and exits maintenance mode with a vSAN
+// data migration mode, e.g. for patching outside of LCM. The VCF API has no maintenance mode operation
+// for hosts, only the cluster contraction and LCM upgrades put hosts into maintenance mode internally.
+// TODO support the NTP and DNS servers of a host. HostCommissionSpec in the VCF API has no NTP or DNS
+// settings, hosts use the system-wide ones managed by vcf_ntp_configuration and vcf_dns_configuration.
 func ResourceHost() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceHostCreate,
@@ -30,24 +46,33 @@ func ResourceHost() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: validateHostNetworkPoolChange,
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(12 * time.Hour),
+			Delete: schema.DefaultTimeout(2 * time.Hour),
 		},
 		Schema: map[string]*schema.Schema{
 			"fqdn": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Fully qualified domain name of ESXi host",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Fully qualified domain name of ESXi host",
+				DiffSuppressFunc: validationutils.SuppressFqdnDiff,
 			},
 			"network_pool_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "ID of the network pool to associate the ESXi host with",
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				Description: "ID of the network pool to associate the ESXi host with. Changing it replaces the host: " +
+					"the VCF API cannot rebind a host, so the host is decommissioned and commissioned again into the new network pool. " +
+					"Rejected for hosts assigned to a cluster",
 			},
+			// TODO support commissioning hosts for vSAN ESA. The VCF API has no ESA storage type or flag
+			// in HostCommissionSpec, ESA ready hosts can only be commissioned as VSAN.
 			"storage_type": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Storage Type. One among: VSAN, VSAN_REMOTE, NFS, VMFS_FC, VVOL",
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Storage Type. One among: VSAN, VSAN_REMOTE, NFS, VMFS_FC, VVOL",
+				ValidateFunc: validation.StringInSlice(hostStorageTypes, false),
 			},
 			"username": {
 				Type:        schema.TypeString,
@@ -60,6 +85,14 @@ func ResourceHost() *schema.Resource {
 				Sensitive:   true,
 				Description: "Password to authenticate to the ESXi host",
 			},
+			"force_decommission": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "If set, destroying the host first removes it from the cluster it is still assigned to, " +
+					"even if the host is unreachable or the cluster is below its minimum size, e.g. when the cluster is broken. " +
+					"Otherwise, destroying a host that is assigned to a cluster fails",
+			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -69,6 +102,25 @@ func ResourceHost() *schema.Resource {
 	}
 }
 
+// validateHostNetworkPoolChange rejects moving a host that is assigned to a cluster to another network pool,
+// as replacing the host would decommission it from SDDC Manager.
+func validateHostNetworkPoolChange(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.Id() == "" || !diff.HasChange("network_pool_id") {
+		return nil
+	}
+	oldNetworkPoolId, newNetworkPoolId := diff.GetChange("network_pool_id")
+	return checkHostNetworkPoolRebind(diff.Get("fqdn").(string), diff.Get("status").(string),
+		oldNetworkPoolId.(string), newNetworkPoolId.(string))
+}
+
+func checkHostNetworkPoolRebind(fqdn, status, oldNetworkPoolId, newNetworkPoolId string) error {
+	if status == "ASSIGNED" {
+		return fmt.Errorf("host %s cannot be moved from network pool %s to %s while it is assigned to a cluster, "+
+			"remove it from the cluster first", fqdn, oldNetworkPoolId, newNetworkPoolId)
+	}
+	return nil
+}
+
 func resourceHostCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
@@ -100,6 +152,13 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		commissionSpec.NetworkPoolID = &networkPoolIdStr
 	}
 
+	// TODO support the vSAN and vMotion VMkernel networks of the host. HostCommissionSpec in the VCF API
+	// has no such settings, the VMkernel adapters are always configured from the networks of the network pool.
+	err := validateNetworkPoolForHost(ctx, vcfClient, *commissionSpec.NetworkPoolID, *commissionSpec.StorageType)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	params.HostCommissionSpecs = []*models.HostCommissionSpec{&commissionSpec}
 
 	_, accepted, err := apiClient.Hosts.CommissionHosts(params)
@@ -127,6 +186,50 @@ func resourceHostCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	return resourceHostRead(ctx, d, meta)
 }
 
+// validateNetworkPoolForHost checks that the network pool has the networks the VMkernel adapters
+// of a host with the storage type are configured from.
+func validateNetworkPoolForHost(ctx context.Context, vcfClient *api_client.SddcManagerClient, networkPoolId, storageType string) error {
+	apiClient := vcfClient.ApiClient
+	getNetworksParams := network_pools.NewGetNetworksOfNetworkPoolParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getNetworksParams.ID = networkPoolId
+	networksResult, err := apiClient.NetworkPools.GetNetworksOfNetworkPool(getNetworksParams)
+	if err != nil {
+		return err
+	}
+	var networkTypes []string
+	for _, network := range networksResult.Payload.Elements {
+		if network != nil {
+			networkTypes = append(networkTypes, network.Type)
+		}
+	}
+	return checkNetworkPoolForHost(networkPoolId, storageType, networkTypes)
+}
+
+func checkNetworkPoolForHost(networkPoolId, storageType string, networkTypes []string) error {
+	requiredNetworkTypes := []string{"VMOTION"}
+	switch storageType {
+	case "VSAN", "VSAN_REMOTE":
+		requiredNetworkTypes = append(requiredNetworkTypes, "VSAN")
+	case "NFS":
+		requiredNetworkTypes = append(requiredNetworkTypes, "NFS")
+	}
+	for _, requiredNetworkType := range requiredNetworkTypes {
+		found := false
+		for _, networkType := range networkTypes {
+			if strings.EqualFold(networkType, requiredNetworkType) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("network pool %s has no %s network, which is required for hosts with storage type %s",
+				networkPoolId, requiredNetworkType, storageType)
+		}
+	}
+	return nil
+}
+
 func resourceHostRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
@@ -138,11 +241,19 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	hostResponse, err := apiClient.Hosts.GetHost(getHostParams)
 	if err != nil {
+		var hostNotFound *hosts.GetHostNotFound
+		if errors.As(err, &hostNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Host %s not found, removing it from the state", hostId))
+			d.SetId("")
+			return nil
+		}
 		tflog.Error(ctx, err.Error())
 		return diag.FromErr(err)
 	}
 	host := hostResponse.Payload
 
+	// TODO detect drift of the SSL thumbprint of the host. The host inventory of the VCF API only
+	// reports whether a thumbprint is present, not its value, so a replaced certificate cannot be detected.
 	_ = d.Set("network_pool_id", host.Networkpool.ID)
 	_ = d.Set("fqdn", host.Fqdn)
 	_ = d.Set("status", host.Status)
@@ -174,13 +285,38 @@ func resourceHostRead(ctx context.Context, d *schema.ResourceData, meta interfac
 
 // There is no update method for commissioned hosts.
 func resourceHostUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return resourceDomainRead(ctx, d, meta)
+	return resourceHostRead(ctx, d, meta)
 }
 
 func resourceHostDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcfClient := meta.(*api_client.SddcManagerClient)
 	apiClient := vcfClient.ApiClient
 
+	getHostParams := hosts.NewGetHostParamsWithContext(ctx).WithTimeout(constants.DefaultVcfApiCallTimeout)
+	getHostParams.ID = d.Id()
+	hostResponse, err := apiClient.Hosts.GetHost(getHostParams)
+	if err != nil {
+		var hostNotFound *hosts.GetHostNotFound
+		if errors.As(err, &hostNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Host %s not found, it is already decommissioned", d.Id()))
+			d.SetId("")
+			return nil
+		}
+		tflog.Error(ctx, err.Error())
+		return diag.FromErr(err)
+	}
+	host := hostResponse.Payload
+	forceDecommission := d.Get("force_decommission").(bool)
+	if err = checkHostDecommission(host, forceDecommission); err != nil {
+		return diag.FromErr(err)
+	}
+	if host.Status == "ASSIGNED" && forceDecommission {
+		if err = removeHostFromCluster(ctx, vcfClient, host); err != nil {
+			tflog.Error(ctx, err.Error())
+			return diag.FromErr(err)
+		}
+	}
+
 	params := hosts.NewDecommissionHostsParamsWithTimeout(constants.DefaultVcfApiCallTimeout)
 	decommissionSpec := models.HostDecommissionSpec{}
 	decommissionSpec.Fqdn = resource_utils.ToStringPointer(d.Get("fqdn"))
@@ -204,3 +340,48 @@ func resourceHostDelete(ctx context.Context, d *schema.ResourceData, meta interf
 
 	return nil
 }
+
+// checkHostDecommission rejects decommissioning a host that is still assigned to a cluster, unless
+// it is forcibly removed from the cluster first.
+func checkHostDecommission(host *models.Host, forceDecommission bool) error {
+	if host.Status != "ASSIGNED" || forceDecommission {
+		return nil
+	}
+	clusterId := ""
+	if host.Cluster != nil && host.Cluster.ID != nil {
+		clusterId = *host.Cluster.ID
+	}
+	return fmt.Errorf("host %s cannot be decommissioned while it is assigned to cluster %q, remove it from the cluster "+
+		"first or set \"force_decommission\"", host.Fqdn, clusterId)
+}
+
+// removeHostFromCluster forcibly removes a host from the cluster it is assigned to, which returns it
+// to the free pool. The removal is not validated, as validating it fails for unreachable hosts.
+func removeHostFromCluster(ctx context.Context, vcfClient *api_client.SddcManagerClient, host *models.Host) error {
+	if host.Cluster == nil || host.Cluster.ID == nil {
+		return fmt.Errorf("host %s is assigned, but not to a cluster", host.Fqdn)
+	}
+	clusterUpdateParams := clusters.NewUpdateClusterParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	clusterUpdateParams.ID = *host.Cluster.ID
+	clusterUpdateParams.SetClusterUpdateSpec(&models.ClusterUpdateSpec{
+		ClusterCompactionSpec: &models.ClusterCompactionSpec{
+			Hosts:                     []*models.HostReference{{ID: host.ID, Fqdn: host.Fqdn}},
+			Force:                     true,
+			ForceByPassingSafeMinSize: true,
+		},
+	})
+
+	tflog.Info(ctx, fmt.Sprintf("Removing host %s from cluster %s", host.Fqdn, *host.Cluster.ID))
+	responseOk, responseAccepted, err := vcfClient.ApiClient.Clusters.UpdateCluster(clusterUpdateParams)
+	if err != nil {
+		return err
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	return vcfClient.WaitForTaskComplete(ctx, taskId, false)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/models"
 	"log"
 	"os"
 	"testing"
@@ -97,3 +98,43 @@ func testCheckVcfHostDestroy(_ *terraform.State) error {
 	// Found the host
 	return nil
 }
+
+func TestCheckHostNetworkPoolRebind(t *testing.T) {
+	if err := checkHostNetworkPoolRebind("esxi-1.vrack.vsphere.local", "UNASSIGNED_USEABLE", "pool-1", "pool-2"); err != nil {
+		t.Errorf("unexpected error for an unassigned host: %s", err)
+	}
+	if err := checkHostNetworkPoolRebind("esxi-1.vrack.vsphere.local", "ASSIGNED", "pool-1", "pool-2"); err == nil {
+		t.Error("expected an error for a host assigned to a cluster")
+	}
+}
+
+func TestCheckNetworkPoolForHost(t *testing.T) {
+	if err := checkNetworkPoolForHost("pool-1", "VSAN", []string{"VSAN", "vMotion"}); err != nil {
+		t.Errorf("unexpected error for a network pool with vSAN and vMotion networks: %s", err)
+	}
+	if err := checkNetworkPoolForHost("pool-1", "VSAN", []string{"VMOTION", "NFS"}); err == nil {
+		t.Error("expected an error for a vSAN host and a network pool without a vSAN network")
+	}
+	if err := checkNetworkPoolForHost("pool-1", "NFS", []string{"NFS"}); err == nil {
+		t.Error("expected an error for a network pool without a vMotion network")
+	}
+	if err := checkNetworkPoolForHost("pool-1", "VMFS_FC", []string{"VMOTION"}); err != nil {
+		t.Errorf("unexpected error for a VMFS on FC host: %s", err)
+	}
+}
+
+func TestCheckHostDecommission(t *testing.T) {
+	clusterId := "cluster-1"
+	assignedHost := &models.Host{Fqdn: "esxi-1.vrack.vsphere.local", Status: "ASSIGNED",
+		Cluster: &models.ClusterReference{ID: &clusterId}}
+	if err := checkHostDecommission(assignedHost, false); err == nil {
+		t.Error("expected an error for a host assigned to a cluster")
+	}
+	if err := checkHostDecommission(assignedHost, true); err != nil {
+		t.Errorf("unexpected error for a forcibly decommissioned host: %s", err)
+	}
+	unassignedHost := &models.Host{Fqdn: "esxi-1.vrack.vsphere.local", Status: "UNASSIGNED_USEABLE"}
+	if err := checkHostDecommission(unassignedHost, false); err != nil {
+		t.Errorf("unexpected error for an unassigned host: %s", err)
+	}
+}
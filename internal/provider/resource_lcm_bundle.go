@@ -0,0 +1,211 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/bundles"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func ResourceLcmBundle() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLcmBundleCreate,
+		ReadContext:   resourceLcmBundleRead,
+		DeleteContext: resourceLcmBundleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				_ = data.Set("bundle_id", data.Id())
+				return []*schema.ResourceData{data}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(4 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"bundle_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the bundle to download",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"scheduled_timestamp": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "Time in RFC 3339 format at which the download is scheduled, e.g. 2023-10-01T02:00:00Z. If omitted, the bundle is downloaded immediately and the download is waited for",
+				ValidateFunc: validation.IsRFC3339Time,
+			},
+			"download_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Download status of the bundle. One among: PENDING, SCHEDULED, IN_PROGRESS, SUCCESSFUL, FAILED, RECALLED",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Type of the bundle. One among: SDDC_MANAGER, VMWARE_SOFTWARE, VXRAIL",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the bundle",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Description of the bundle",
+			},
+			"vendor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Vendor of the bundle",
+			},
+			"severity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Severity of the bundle. One among: CRITICAL, IMPORTANT, MODERATE, LOW",
+			},
+			"released_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Release date of the bundle",
+			},
+			"size_mb": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "Size of the bundle in MB",
+			},
+			"is_compliant": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the bundle is compliant with the current VCF version",
+			},
+		},
+	}
+}
+
+func resourceLcmBundleCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+	bundleId := data.Get("bundle_id").(string)
+
+	bundle, err := getBundle(ctx, apiClient.Bundles, bundleId)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if bundle.DownloadStatus != nil && *bundle.DownloadStatus == "SUCCESSFUL" {
+		tflog.Info(ctx, fmt.Sprintf("Bundle %s is already downloaded", bundleId))
+		data.SetId(bundleId)
+		return resourceLcmBundleRead(ctx, data, meta)
+	}
+
+	scheduledTimestamp := data.Get("scheduled_timestamp").(string)
+	updateBundleParams := bundles.NewUpdateBundleParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(bundleId).
+		WithBundleUpdateSpec(getBundleUpdateSpec(scheduledTimestamp))
+	responseOk, responseAccepted, err := apiClient.Bundles.UpdateBundle(updateBundleParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	data.SetId(bundleId)
+	if len(scheduledTimestamp) > 0 {
+		return resourceLcmBundleRead(ctx, data, meta)
+	}
+
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return diag.FromErr(err)
+	}
+	if bundle, err = getBundle(ctx, apiClient.Bundles, bundleId); err != nil {
+		return diag.FromErr(err)
+	}
+	if bundle.DownloadStatus == nil || *bundle.DownloadStatus != "SUCCESSFUL" {
+		data.SetId("")
+		return diag.Errorf("download of bundle %s has not succeeded", bundleId)
+	}
+
+	return resourceLcmBundleRead(ctx, data, meta)
+}
+
+func resourceLcmBundleRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	bundle, err := getBundle(ctx, apiClient.Bundles, data.Id())
+	if err != nil {
+		var bundleNotFound *bundles.GetBundleNotFound
+		if errors.As(err, &bundleNotFound) {
+			tflog.Warn(ctx, fmt.Sprintf("Bundle %s not found, removing it from the state", data.Id()))
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	_ = data.Set("bundle_id", data.Id())
+	if bundle.DownloadStatus != nil {
+		_ = data.Set("download_status", *bundle.DownloadStatus)
+	}
+	if bundle.Type != nil {
+		_ = data.Set("type", *bundle.Type)
+	}
+	_ = data.Set("version", bundle.Version)
+	_ = data.Set("description", bundle.Description)
+	_ = data.Set("vendor", bundle.Vendor)
+	_ = data.Set("severity", bundle.Severity)
+	_ = data.Set("released_date", bundle.ReleasedDate)
+	_ = data.Set("size_mb", bundle.SizeMB)
+	_ = data.Set("is_compliant", bundle.IsCompliant)
+
+	return nil
+}
+
+// resourceLcmBundleDelete only removes the bundle from the state, the VCF API cannot delete
+// downloaded bundles.
+func resourceLcmBundleDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
+
+func getBundle(ctx context.Context, bundlesClient bundles.ClientService, bundleId string) (*models.Bundle, error) {
+	getBundleParams := bundles.NewGetBundleParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(bundleId)
+	bundleResult, err := bundlesClient.GetBundle(getBundleParams)
+	if err != nil {
+		return nil, err
+	}
+	if bundleResult.Payload == nil {
+		return nil, fmt.Errorf("bundle %s not found", bundleId)
+	}
+	return bundleResult.Payload, nil
+}
+
+// getBundleUpdateSpec returns the spec that downloads a bundle immediately or, if scheduledTimestamp
+// is set, at the scheduled time.
+func getBundleUpdateSpec(scheduledTimestamp string) *models.BundleUpdateSpec {
+	return &models.BundleUpdateSpec{
+		BundleDownloadSpec: &models.BundleDownloadSpec{
+			DownloadNow:        len(scheduledTimestamp) == 0,
+			ScheduledTimestamp: scheduledTimestamp,
+		},
+	}
+}
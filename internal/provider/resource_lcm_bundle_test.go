@@ -0,0 +1,72 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/bundles"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+// testBundlesClient returns the bundles by ID and GetBundleNotFound for any other bundle.
+type testBundlesClient struct {
+	bundles.ClientService
+	bundles map[string]*models.Bundle
+}
+
+func (c *testBundlesClient) GetBundle(params *bundles.GetBundleParams, _ ...bundles.ClientOption) (*bundles.GetBundleOK, error) {
+	bundle, ok := c.bundles[params.ID]
+	if !ok {
+		return nil, bundles.NewGetBundleNotFound()
+	}
+	return &bundles.GetBundleOK{Payload: bundle}, nil
+}
+
+func TestGetBundleUpdateSpec(t *testing.T) {
+	bundleUpdateSpec := getBundleUpdateSpec("")
+	if !bundleUpdateSpec.BundleDownloadSpec.DownloadNow || bundleUpdateSpec.BundleDownloadSpec.ScheduledTimestamp != "" {
+		t.Errorf("expected an immediate download, got %+v", bundleUpdateSpec.BundleDownloadSpec)
+	}
+
+	bundleUpdateSpec = getBundleUpdateSpec("2023-10-01T02:00:00Z")
+	if bundleUpdateSpec.BundleDownloadSpec.DownloadNow ||
+		bundleUpdateSpec.BundleDownloadSpec.ScheduledTimestamp != "2023-10-01T02:00:00Z" {
+		t.Errorf("expected a scheduled download, got %+v", bundleUpdateSpec.BundleDownloadSpec)
+	}
+}
+
+func TestResourceLcmBundleRead(t *testing.T) {
+	meta := &api_client.SddcManagerClient{
+		ApiClient: &client.VcfClient{
+			Bundles: &testBundlesClient{bundles: map[string]*models.Bundle{
+				"bundle-1": {Description: "ESXi 8.0 Update 2", Version: "8.0.2"},
+			}},
+		},
+	}
+
+	data := ResourceLcmBundle().TestResourceData()
+	data.SetId("bundle-1")
+	if diags := resourceLcmBundleRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("unexpected error %v", diags)
+	}
+	if description := data.Get("description").(string); description != "ESXi 8.0 Update 2" {
+		t.Errorf("expected the description of the bundle, got %q", description)
+	}
+	if bundleId := data.Get("bundle_id").(string); bundleId != "bundle-1" {
+		t.Errorf("expected bundle_id bundle-1, got %q", bundleId)
+	}
+
+	data.SetId("bundle-2")
+	if diags := resourceLcmBundleRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("unexpected error %v", diags)
+	}
+	if data.Id() != "" {
+		t.Errorf("expected a missing bundle to be removed from the state, got ID %q", data.Id())
+	}
+}
@@ -0,0 +1,187 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+func ResourceLicenseKey() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLicenseKeyCreate,
+		ReadContext:   resourceLicenseKeyRead,
+		DeleteContext: resourceLicenseKeyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLicenseKeyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true, // Updating license keys is not supported in VCF API.
+				Sensitive:    true,
+				Description:  "The 29 alpha numeric character license key with hyphens",
+				ValidateFunc: validation.StringLenBetween(29, 29),
+			},
+			"product_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The type of the product to which the license key is applicable. One among: VCENTER, VSAN, ESXI, NSXT, NSXIO, WCP, HORIZON_VIEW",
+				ValidateFunc: validation.StringInSlice(licenseProductTypes, false),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Description of the license key",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"is_unlimited": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Indicates if the license key has unlimited usage",
+			},
+			"license_unit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Units of the license key, e.g. CPUPACKAGE, INSTANCE, CORES",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total units of the license key",
+			},
+			"used": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The consumed units of the license key",
+			},
+			"remaining": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The remaining units of the license key",
+			},
+			"expiry_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The license key expiry date",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The validity status of the license key. One among: EXPIRED, ACTIVE, NEVER_EXPIRES",
+			},
+		},
+	}
+}
+
+func resourceLicenseKeyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	key := data.Get("key").(string)
+	productType := data.Get("product_type").(string)
+	description := data.Get("description").(string)
+	addLicenseKeyParams := license_keys.NewAddLicenseKeyParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithLicenseKey(&models.LicenseKey{
+			Key:         &key,
+			ProductType: &productType,
+			Description: &description,
+		})
+
+	responseOk, responseCreated, err := apiClient.LicenseKeys.AddLicenseKey(addLicenseKeyParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var licenseKey *models.LicenseKey
+	if responseOk != nil {
+		licenseKey = responseOk.Payload
+	} else {
+		licenseKey = responseCreated.Payload
+	}
+	data.SetId(licenseKey.ID)
+
+	return resourceLicenseKeyRead(ctx, data, meta)
+}
+
+func resourceLicenseKeyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getLicenseKeyParams := license_keys.NewGetLicenseKeyParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithKey(data.Get("key").(string))
+
+	licenseKeyResult, err := apiClient.LicenseKeys.GetLicenseKey(getLicenseKeyParams)
+	if err != nil {
+		notFound := &license_keys.GetLicenseKeyNotFound{}
+		if errors.As(err, &notFound) {
+			tflog.Warn(ctx, fmt.Sprintf("License key %s not found, removing it from the state", data.Id()))
+			data.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if err = setLicenseKeyAttributes(data, licenseKeyResult.Payload); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceLicenseKeyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	deleteLicenseKeyParams := license_keys.NewDeleteLicenseKeyParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithKey(data.Get("key").(string))
+
+	_, _, err := apiClient.LicenseKeys.DeleteLicenseKey(deleteLicenseKeyParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// resourceLicenseKeyImport imports a license key by the key itself, as the VCF API looks up
+// license keys only by key.
+func resourceLicenseKeyImport(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	_ = data.Set("key", data.Id())
+	return []*schema.ResourceData{data}, nil
+}
+
+// setLicenseKeyAttributes sets the attributes of the vcf_license_key resource and data source from
+// a license key. The ID of the license key becomes the ID of the resource.
+func setLicenseKeyAttributes(data *schema.ResourceData, licenseKey *models.LicenseKey) error {
+	for attribute, value := range flattenLicenseKey(licenseKey) {
+		if attribute == "id" {
+			continue
+		}
+		if err := data.Set(attribute, value); err != nil {
+			return err
+		}
+	}
+	data.SetId(licenseKey.ID)
+	return nil
+}
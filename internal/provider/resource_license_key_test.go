@@ -0,0 +1,73 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/client/license_keys"
+	"os"
+	"testing"
+)
+
+const testLicenseKeyDescription = "terraform acceptance test license key"
+
+func TestAccResourceVcfLicenseKey(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: providerFactories,
+		CheckDestroy:      testCheckVcfLicenseKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccVcfLicenseKeyConfig(os.Getenv(constants.VcfTestUnregisteredLicenseKey)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcf_license_key.esxi", "id"),
+					resource.TestCheckResourceAttrSet("vcf_license_key.esxi", "status"),
+					resource.TestCheckResourceAttrPair("data.vcf_license_key.esxi", "id", "vcf_license_key.esxi", "id"),
+					resource.TestCheckResourceAttrPair("data.vcf_license_key.esxi", "key", "vcf_license_key.esxi", "key"),
+				),
+			},
+			{
+				ResourceName:      "vcf_license_key.esxi",
+				ImportState:       true,
+				ImportStateId:     os.Getenv(constants.VcfTestUnregisteredLicenseKey),
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccVcfLicenseKeyConfig(licenseKey string) string {
+	return fmt.Sprintf(`
+	resource "vcf_license_key" "esxi" {
+		key          = %q
+		product_type = "ESXI"
+		description  = %q
+	}
+
+	data "vcf_license_key" "esxi" {
+		product_type = vcf_license_key.esxi.product_type
+		description  = vcf_license_key.esxi.description
+	}`, licenseKey, testLicenseKeyDescription)
+}
+
+func testCheckVcfLicenseKeyDestroy(_ *terraform.State) error {
+	apiClient := testAccProvider.Meta().(*api_client.SddcManagerClient).ApiClient
+
+	licenseKeysResult, err := apiClient.LicenseKeys.GetLicenseKeys(license_keys.NewGetLicenseKeysParams())
+	if err != nil {
+		return err
+	}
+	for _, licenseKey := range licenseKeysResult.Payload.Elements {
+		if licenseKey.Description != nil && *licenseKey.Description == testLicenseKeyDescription {
+			return fmt.Errorf("found license key %q", licenseKey.ID)
+		}
+	}
+	return nil
+}
@@ -6,13 +6,20 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client"
 	"github.com/vmware/vcf-sdk-go/client/network_pools"
 	"github.com/vmware/vcf-sdk-go/models"
 	"log"
+	"net"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -22,25 +29,30 @@ func ResourceNetworkPool() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceNetworkPoolCreate,
 		ReadContext:   resourceNetworkPoolRead,
+		UpdateContext: resourceNetworkPoolUpdate,
 		DeleteContext: resourceNetworkPoolDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(12 * time.Hour),
+			Update: schema.DefaultTimeout(1 * time.Hour),
 		},
+		// TODO support adding networks to an existing network pool. The VCF API can only rename a network
+		// pool and add or remove IP pools of its networks, other changes of the networks recreate the pool.
+		CustomizeDiff: customdiff.ForceNewIfChange("network", func(_ context.Context, oldValue, newValue, _ interface{}) bool {
+			return haveNetworkPoolNetworksChanged(oldValue.([]interface{}), newValue.([]interface{}))
+		}),
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true, // Updating network pools is partially supported in VCF API.
 				Description: "The name of the network pool",
 			},
 			"network": {
 				Type:        schema.TypeList,
 				Required:    true,
-				ForceNew:    true, // Updating network pools is partially supported in VCF API.
-				Description: "Represents a network in a network pool",
+				Description: "Represents a network in a network pool. Changes other than to the IP pools recreate the network pool",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"gateway": {
@@ -75,7 +87,7 @@ func ResourceNetworkPool() *schema.Resource {
 						},
 						"ip_pools": {
 							Type:        schema.TypeList,
-							Description: "List of IP pool ranges to use",
+							Description: "List of IP pool ranges to use. IP pool ranges can be added and removed in place, ranges with IP addresses used by hosts cannot be removed",
 							Optional:    true,
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
@@ -125,16 +137,7 @@ func resourceNetworkPoolCreate(ctx context.Context, d *schema.ResourceData, meta
 				VlanID:  int32(networkMap["vlan_id"].(int)),
 			}
 
-			ipPools := networkMap["ip_pools"].([]interface{})
-			networkPool.Networks[i].IPPools = make([]*models.IPPool, len(ipPools))
-			for j, ipPool := range ipPools {
-				ipPoolMap := ipPool.(map[string]interface{})
-
-				networkPool.Networks[i].IPPools[j] = &models.IPPool{
-					Start: ipPoolMap["start"].(string),
-					End:   ipPoolMap["end"].(string),
-				}
-			}
+			networkPool.Networks[i].IPPools = toIpPools(networkMap["ip_pools"].([]interface{}))
 		}
 	}
 
@@ -170,6 +173,84 @@ func resourceNetworkPoolRead(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+func resourceNetworkPoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	if d.HasChange("name") {
+		updateParams := network_pools.NewUpdateNetworkPoolParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(d.Id()).
+			WithNetworkPoolUpdateSpec(&models.NetworkPoolUpdateSpec{Name: d.Get("name").(string)})
+		if _, err := apiClient.NetworkPools.UpdateNetworkPool(updateParams); err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
+	}
+
+	if d.HasChange("network") {
+		getNetworksParams := network_pools.NewGetNetworksOfNetworkPoolParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(d.Id())
+		networksResult, err := apiClient.NetworkPools.GetNetworksOfNetworkPool(getNetworksParams)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		oldNetworks, newNetworks := d.GetChange("network")
+		for _, networkRaw := range newNetworks.([]interface{}) {
+			network := networkRaw.(map[string]interface{})
+			networkType := network["type"].(string)
+			networkOfPool := findNetworkOfType(networksResult.Payload.Elements, networkType)
+			if networkOfPool == nil {
+				return diag.Errorf("network pool %s has no network of type %s", d.Id(), networkType)
+			}
+			oldIpPools := getIpPoolsOfNetworkType(oldNetworks.([]interface{}), networkType)
+			addedIpPools, removedIpPools := calculateIpPoolDelta(oldIpPools, toIpPools(network["ip_pools"].([]interface{})))
+			replacedIpPools, removedIpPools, err := splitReplacedIpPools(addedIpPools, removedIpPools, networkOfPool.UsedIps)
+			if err != nil {
+				return diag.FromErr(err)
+			}
+
+			// remove the IP pools that overlap added ones first, the VCF API rejects overlapping IP pools
+			for _, ipPool := range replacedIpPools {
+				if diags := deleteIpPool(ctx, apiClient, d.Id(), networkOfPool.ID, ipPool); diags != nil {
+					return diags
+				}
+			}
+			// add the IP pools before removing the others, so that the network never runs out of IP pools
+			for _, ipPool := range addedIpPools {
+				log.Printf("Adding IP pool %s-%s to network %s of network pool %s", ipPool.Start, ipPool.End, networkOfPool.ID, d.Id())
+				addParams := network_pools.NewAddIPPoolToNetworkOfNetworkPoolParamsWithContext(ctx).
+					WithTimeout(constants.DefaultVcfApiCallTimeout).
+					WithID(d.Id()).
+					WithNetworkID(networkOfPool.ID).
+					WithIPPool(ipPool)
+				if _, err = apiClient.NetworkPools.AddIPPoolToNetworkOfNetworkPool(addParams); err != nil {
+					return validationUtils.ConvertVcfErrorToDiag(err)
+				}
+			}
+			for _, ipPool := range removedIpPools {
+				if diags := deleteIpPool(ctx, apiClient, d.Id(), networkOfPool.ID, ipPool); diags != nil {
+					return diags
+				}
+			}
+		}
+	}
+
+	return resourceNetworkPoolRead(ctx, d, meta)
+}
+
+func deleteIpPool(ctx context.Context, apiClient *client.VcfClient, networkPoolId, networkId string, ipPool *models.IPPool) diag.Diagnostics {
+	log.Printf("Removing IP pool %s-%s from network %s of network pool %s", ipPool.Start, ipPool.End, networkId, networkPoolId)
+	deleteParams := network_pools.NewDeleteIPPoolFromNetworkOfNetworkPoolParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(networkPoolId).
+		WithNetworkID(networkId).
+		WithIPPool(ipPool)
+	if _, err := apiClient.NetworkPools.DeleteIPPoolFromNetworkOfNetworkPool(deleteParams); err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	return nil
+}
+
 func resourceNetworkPoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
 
@@ -188,3 +269,126 @@ func resourceNetworkPoolDelete(ctx context.Context, d *schema.ResourceData, meta
 	d.SetId("")
 	return nil
 }
+
+// haveNetworkPoolNetworksChanged tells whether networks have been added, removed or changed other than
+// in their IP pools, which the VCF API cannot update in place. Networks are matched by their type.
+func haveNetworkPoolNetworksChanged(oldNetworks, newNetworks []interface{}) bool {
+	if len(oldNetworks) != len(newNetworks) {
+		return true
+	}
+	oldNetworksByType := make(map[string]map[string]interface{})
+	for _, networkRaw := range oldNetworks {
+		network := networkRaw.(map[string]interface{})
+		oldNetworksByType[strings.ToUpper(network["type"].(string))] = network
+	}
+	for _, networkRaw := range newNetworks {
+		network := networkRaw.(map[string]interface{})
+		oldNetwork, ok := oldNetworksByType[strings.ToUpper(network["type"].(string))]
+		if !ok {
+			return true
+		}
+		for _, attribute := range []string{"gateway", "mask", "mtu", "subnet", "vlan_id"} {
+			if oldNetwork[attribute] != network[attribute] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// calculateIpPoolDelta returns the IP pools that are only in newIpPools and those that are only in
+// oldIpPools, ignoring their order.
+func calculateIpPoolDelta(oldIpPools, newIpPools []*models.IPPool) (added, removed []*models.IPPool) {
+	ipPoolKey := func(ipPool *models.IPPool) string {
+		return fmt.Sprintf("%s-%s", ipPool.Start, ipPool.End)
+	}
+	oldIpPoolKeys := make(map[string]bool)
+	for _, ipPool := range oldIpPools {
+		oldIpPoolKeys[ipPoolKey(ipPool)] = true
+	}
+	newIpPoolKeys := make(map[string]bool)
+	for _, ipPool := range newIpPools {
+		newIpPoolKeys[ipPoolKey(ipPool)] = true
+		if !oldIpPoolKeys[ipPoolKey(ipPool)] {
+			added = append(added, ipPool)
+		}
+	}
+	for _, ipPool := range oldIpPools {
+		if !newIpPoolKeys[ipPoolKey(ipPool)] {
+			removed = append(removed, ipPool)
+		}
+	}
+	return added, removed
+}
+
+// splitReplacedIpPools splits the removed IP pools into those that overlap an added IP pool, e.g. a range
+// that is widened in place, and the others. The VCF API rejects an IP pool that overlaps an existing one,
+// so the overlapping IP pools have to be removed before the added ones, which is only possible if none
+// of their IP addresses are in use.
+func splitReplacedIpPools(addedIpPools, removedIpPools []*models.IPPool, usedIps []string) (replaced, removed []*models.IPPool, err error) {
+	for _, removedIpPool := range removedIpPools {
+		var overlappingIpPool *models.IPPool
+		for _, addedIpPool := range addedIpPools {
+			if ipPoolsOverlap(removedIpPool, addedIpPool) {
+				overlappingIpPool = addedIpPool
+				break
+			}
+		}
+		if overlappingIpPool == nil {
+			removed = append(removed, removedIpPool)
+			continue
+		}
+		for _, usedIp := range usedIps {
+			if isIpInPool(usedIp, removedIpPool) {
+				return nil, nil, fmt.Errorf("IP pool %s-%s cannot be replaced by the overlapping IP pool %s-%s, IP address %s is in use. "+
+					"Add IP pools that do not overlap the existing ones instead", removedIpPool.Start, removedIpPool.End,
+					overlappingIpPool.Start, overlappingIpPool.End, usedIp)
+			}
+		}
+		replaced = append(replaced, removedIpPool)
+	}
+	return replaced, removed, nil
+}
+
+func ipPoolsOverlap(ipPool, otherIpPool *models.IPPool) bool {
+	return compareIps(ipPool.Start, otherIpPool.End) <= 0 && compareIps(otherIpPool.Start, ipPool.End) <= 0
+}
+
+func isIpInPool(ip string, ipPool *models.IPPool) bool {
+	return compareIps(ipPool.Start, ip) <= 0 && compareIps(ip, ipPool.End) <= 0
+}
+
+func compareIps(ip, otherIp string) int {
+	return bytes.Compare(net.ParseIP(ip).To16(), net.ParseIP(otherIp).To16())
+}
+
+func getIpPoolsOfNetworkType(networks []interface{}, networkType string) []*models.IPPool {
+	for _, networkRaw := range networks {
+		network := networkRaw.(map[string]interface{})
+		if strings.EqualFold(network["type"].(string), networkType) {
+			return toIpPools(network["ip_pools"].([]interface{}))
+		}
+	}
+	return nil
+}
+
+func toIpPools(ipPoolList []interface{}) []*models.IPPool {
+	ipPools := make([]*models.IPPool, 0, len(ipPoolList))
+	for _, ipPoolRaw := range ipPoolList {
+		ipPool := ipPoolRaw.(map[string]interface{})
+		ipPools = append(ipPools, &models.IPPool{
+			Start: ipPool["start"].(string),
+			End:   ipPool["end"].(string),
+		})
+	}
+	return ipPools
+}
+
+func findNetworkOfType(networks []*models.Network, networkType string) *models.Network {
+	for _, network := range networks {
+		if network != nil && strings.EqualFold(network.Type, networkType) {
+			return network
+		}
+	}
+	return nil
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/vcf-sdk-go/models"
 	"log"
 	"testing"
 )
@@ -81,3 +82,89 @@ func testCheckVcfNetworkPoolDestroy(_ *terraform.State) error {
 	// Didn't find the networkPool
 	return nil
 }
+
+func TestHaveNetworkPoolNetworksChanged(t *testing.T) {
+	newNetwork := func(networkType string, vlanId int, ipPoolStarts ...string) interface{} {
+		var ipPools []interface{}
+		for _, ipPoolStart := range ipPoolStarts {
+			ipPools = append(ipPools, map[string]interface{}{"start": ipPoolStart, "end": "192.168.4.250"})
+		}
+		return map[string]interface{}{
+			"gateway":  "192.168.4.1",
+			"mask":     "255.255.255.0",
+			"mtu":      9000,
+			"subnet":   "192.168.4.0",
+			"type":     networkType,
+			"vlan_id":  vlanId,
+			"ip_pools": ipPools,
+		}
+	}
+	oldNetworks := []interface{}{newNetwork("VSAN", 100, "192.168.4.5"), newNetwork("vMotion", 101, "192.168.4.5")}
+
+	if haveNetworkPoolNetworksChanged(oldNetworks,
+		[]interface{}{newNetwork("VMOTION", 101, "192.168.4.5"), newNetwork("VSAN", 100, "192.168.4.5", "192.168.4.200")}) {
+		t.Error("expected reordered networks with an added IP pool to be updated in place")
+	}
+	if !haveNetworkPoolNetworksChanged(oldNetworks,
+		[]interface{}{newNetwork("VSAN", 100, "192.168.4.5"), newNetwork("vMotion", 102, "192.168.4.5")}) {
+		t.Error("expected a changed VLAN ID to recreate the network pool")
+	}
+	if !haveNetworkPoolNetworksChanged(oldNetworks, append(oldNetworks, newNetwork("NFS", 103, "192.168.4.5"))) {
+		t.Error("expected an added network to recreate the network pool")
+	}
+}
+
+func TestCalculateIpPoolDelta(t *testing.T) {
+	oldIpPools := []*models.IPPool{{Start: "192.168.4.5", End: "192.168.4.50"}, {Start: "192.168.4.60", End: "192.168.4.70"}}
+	newIpPools := []*models.IPPool{{Start: "192.168.4.60", End: "192.168.4.70"}, {Start: "192.168.4.100", End: "192.168.4.150"}}
+
+	added, removed := calculateIpPoolDelta(oldIpPools, newIpPools)
+	if len(added) != 1 || added[0].Start != "192.168.4.100" {
+		t.Errorf("unexpected added IP pools %+v", added)
+	}
+	if len(removed) != 1 || removed[0].Start != "192.168.4.5" {
+		t.Errorf("unexpected removed IP pools %+v", removed)
+	}
+}
+
+func TestSplitReplacedIpPools(t *testing.T) {
+	testCases := []struct {
+		name             string
+		removedIpPools   []*models.IPPool
+		usedIps          []string
+		expectedReplaced int
+		expectedRemoved  int
+		expectError      bool
+	}{
+		{
+			name:            "not overlapping",
+			removedIpPools:  []*models.IPPool{{Start: "192.168.4.40", End: "192.168.4.50"}},
+			expectedRemoved: 1,
+		},
+		{
+			name:             "widened without used IPs",
+			removedIpPools:   []*models.IPPool{{Start: "192.168.4.10", End: "192.168.4.20"}},
+			usedIps:          []string{"192.168.4.45"},
+			expectedReplaced: 1,
+		},
+		{
+			name:           "widened with used IPs",
+			removedIpPools: []*models.IPPool{{Start: "192.168.4.10", End: "192.168.4.20"}},
+			usedIps:        []string{"192.168.4.15"},
+			expectError:    true,
+		},
+	}
+
+	addedIpPools := []*models.IPPool{{Start: "192.168.4.10", End: "192.168.4.30"}}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			replaced, removed, err := splitReplacedIpPools(addedIpPools, testCase.removedIpPools, testCase.usedIps)
+			if (err != nil) != testCase.expectError {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if len(replaced) != testCase.expectedReplaced || len(removed) != testCase.expectedRemoved {
+				t.Errorf("unexpected replaced IP pools %+v and removed IP pools %+v", replaced, removed)
+			}
+		})
+	}
+}
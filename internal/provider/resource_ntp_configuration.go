@@ -0,0 +1,164 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/system"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+const ntpConfigurationId = "ntp-configuration"
+
+func ResourceNtpConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceNtpConfigurationCreate,
+		ReadContext:   resourceNtpConfigurationRead,
+		UpdateContext: resourceNtpConfigurationUpdate,
+		DeleteContext: resourceNtpConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				data.SetId(ntpConfigurationId)
+				return []*schema.ResourceData{data}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(2 * time.Hour),
+		},
+		CustomizeDiff: validateNtpConfiguration,
+		Schema: map[string]*schema.Schema{
+			"ntp_servers": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "IP addresses or FQDNs of the NTP servers of all VCF components",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func validateNtpConfiguration(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.GetRawConfig().GetAttr("ntp_servers").IsWhollyKnown() {
+		return nil
+	}
+	if _, errs := validationutils.ValidateNtpServersSchema(diff.Get("ntp_servers"), "ntp_servers"); len(errs) > 0 {
+		return errs[0]
+	}
+	return nil
+}
+
+func resourceNtpConfigurationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := configureNtp(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+		return diags
+	}
+	data.SetId(ntpConfigurationId)
+
+	return resourceNtpConfigurationRead(ctx, data, meta)
+}
+
+func resourceNtpConfigurationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getNtpConfigurationParams := system.NewGetNtpConfigurationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	ntpConfigurationResult, err := apiClient.System.GetNtpConfiguration(getNtpConfigurationParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var ntpServers []string
+	for _, ntpServer := range ntpConfigurationResult.Payload.NtpServers {
+		if ntpServer != nil && ntpServer.IPAddress != nil {
+			ntpServers = append(ntpServers, *ntpServer.IPAddress)
+		}
+	}
+	_ = data.Set("ntp_servers", ntpServers)
+
+	return nil
+}
+
+func resourceNtpConfigurationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := configureNtp(ctx, data, meta.(*api_client.SddcManagerClient)); diags != nil {
+		return diags
+	}
+
+	return resourceNtpConfigurationRead(ctx, data, meta)
+}
+
+// resourceNtpConfigurationDelete only removes the NTP configuration from the state, the VCF
+// components keep using the configured NTP servers.
+func resourceNtpConfigurationDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
+
+// configureNtp validates the NTP configuration and applies it to all VCF components.
+func configureNtp(ctx context.Context, data *schema.ResourceData, vcfClient *api_client.SddcManagerClient) diag.Diagnostics {
+	apiClient := vcfClient.ApiClient
+	ntpConfiguration := getNtpConfiguration(data.Get("ntp_servers").([]interface{}))
+
+	validateNtpConfigurationParams := system.NewValidateNtpConfigurationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithNtpConfiguration(ntpConfiguration)
+	validationOk, validationAccepted, err := apiClient.System.ValidateNtpConfiguration(validateNtpConfigurationParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var validationResult *models.Validation
+	if validationOk != nil {
+		validationResult = validationOk.Payload
+	} else {
+		validationResult = validationAccepted.Payload
+	}
+	diags := waitForSystemConfigurationValidation(ctx, validationResult, func(validationId string) (*models.Validation, error) {
+		getValidationParams := system.NewGetValidationOfNtpConfigurationParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(validationId)
+		getValidationResult, err := apiClient.System.GetValidationOfNtpConfiguration(getValidationParams)
+		if err != nil {
+			return nil, err
+		}
+		return getValidationResult.Payload, nil
+	})
+	if diags != nil {
+		return diags
+	}
+
+	configureNtpParams := system.NewConfigureNtpParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).WithNtpConfiguration(ntpConfiguration)
+	responseOk, responseAccepted, err := apiClient.System.ConfigureNtp(configureNtpParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func getNtpConfiguration(ntpServerList []interface{}) *models.NtpConfiguration {
+	ntpConfiguration := &models.NtpConfiguration{}
+	for _, ipAddress := range resource_utils.ToStringSlice(ntpServerList) {
+		ipAddress := ipAddress
+		ntpConfiguration.NtpServers = append(ntpConfiguration.NtpServers, &models.NtpServer{IPAddress: &ipAddress})
+	}
+	return ntpConfiguration
+}
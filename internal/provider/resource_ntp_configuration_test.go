@@ -0,0 +1,18 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"testing"
+)
+
+func TestGetNtpConfiguration(t *testing.T) {
+	ntpConfiguration := getNtpConfiguration([]interface{}{"172.16.11.253", "172.16.12.253"})
+	if len(ntpConfiguration.NtpServers) != 2 || *ntpConfiguration.NtpServers[0].IPAddress != "172.16.11.253" ||
+		*ntpConfiguration.NtpServers[1].IPAddress != "172.16.12.253" {
+		t.Errorf("unexpected NTP configuration %+v", ntpConfiguration)
+	}
+}
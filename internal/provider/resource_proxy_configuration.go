@@ -0,0 +1,153 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/proxy_configuration"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+const proxyConfigurationId = "sddc-manager-proxy-configuration"
+
+func ResourceProxyConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceProxyConfigurationCreate,
+		ReadContext:   resourceProxyConfigurationRead,
+		UpdateContext: resourceProxyConfigurationUpdate,
+		DeleteContext: resourceProxyConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+				data.SetId(proxyConfigurationId)
+				return []*schema.ResourceData{data}, nil
+			},
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"host": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "IP address or FQDN of the proxy server",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"port": {
+				Type:         schema.TypeInt,
+				Required:     true,
+				Description:  "Port of the proxy server",
+				ValidateFunc: validation.IsPortNumber,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether SDDC Manager connects to the internet, e.g. to the VMware Depot, through the proxy server, default true",
+			},
+			"is_configured": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the proxy server is configured in SDDC Manager",
+			},
+		},
+	}
+}
+
+func resourceProxyConfigurationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	if diags := updateProxyConfiguration(ctx, vcfClient, getProxyConfiguration(data)); diags != nil {
+		return diags
+	}
+	data.SetId(proxyConfigurationId)
+
+	return resourceProxyConfigurationRead(ctx, data, meta)
+}
+
+func resourceProxyConfigurationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getProxyConfigurationParams := proxy_configuration.NewGetProxyConfigurationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	proxyConfigurationResult, err := apiClient.ProxyConfiguration.GetProxyConfiguration(getProxyConfigurationParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	proxyConfiguration := proxyConfigurationResult.Payload
+	_ = data.Set("host", proxyConfiguration.Host)
+	_ = data.Set("port", int(proxyConfiguration.Port))
+	_ = data.Set("enabled", proxyConfiguration.IsEnabled)
+	_ = data.Set("is_configured", proxyConfiguration.IsConfigured)
+
+	return nil
+}
+
+func resourceProxyConfigurationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	if diags := updateProxyConfiguration(ctx, vcfClient, getProxyConfiguration(data)); diags != nil {
+		return diags
+	}
+
+	return resourceProxyConfigurationRead(ctx, data, meta)
+}
+
+// resourceProxyConfigurationDelete disables the proxy server, the VCF API cannot remove it.
+func resourceProxyConfigurationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	proxyConfiguration := getProxyConfiguration(data)
+	proxyConfiguration.IsEnabled = false
+	if diags := updateProxyConfiguration(ctx, vcfClient, proxyConfiguration); diags != nil {
+		return diags
+	}
+
+	data.SetId("")
+	return nil
+}
+
+func updateProxyConfiguration(ctx context.Context, vcfClient *api_client.SddcManagerClient,
+	proxyConfiguration *models.ProxyConfiguration) diag.Diagnostics {
+	updateProxyConfigurationParams := proxy_configuration.NewUpdateProxyConfigurationParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithProxyConfig(proxyConfiguration)
+
+	responseOk, responseAccepted, err := vcfClient.ApiClient.ProxyConfiguration.UpdateProxyConfiguration(updateProxyConfigurationParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var task *models.Task
+	if responseOk != nil {
+		task = responseOk.Payload
+	} else {
+		task = responseAccepted.Payload
+	}
+	// the proxy configuration is applied synchronously unless a task is returned
+	if task == nil || len(task.ID) == 0 {
+		return nil
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, task.ID, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func getProxyConfiguration(data *schema.ResourceData) *models.ProxyConfiguration {
+	return &models.ProxyConfiguration{
+		Host:      data.Get("host").(string),
+		Port:      int32(data.Get("port").(int)),
+		IsEnabled: data.Get("enabled").(bool),
+	}
+}
@@ -0,0 +1,28 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"testing"
+)
+
+func TestGetProxyConfiguration(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, ResourceProxyConfiguration().Schema, map[string]interface{}{
+		"host": "proxy.vrack.vsphere.local",
+		"port": 3128,
+	})
+
+	proxyConfiguration := getProxyConfiguration(data)
+	if proxyConfiguration.Host != "proxy.vrack.vsphere.local" || proxyConfiguration.Port != 3128 || !proxyConfiguration.IsEnabled {
+		t.Errorf("unexpected proxy configuration %+v", proxyConfiguration)
+	}
+
+	_ = data.Set("enabled", false)
+	if proxyConfiguration = getProxyConfiguration(data); proxyConfiguration.IsEnabled {
+		t.Errorf("expected a disabled proxy configuration, got %+v", proxyConfiguration)
+	}
+}
@@ -0,0 +1,400 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/backup_restore"
+	"github.com/vmware/vcf-sdk-go/models"
+	"time"
+)
+
+const (
+	backupConfigurationId      = "sddc-manager-backup-configuration"
+	backupScheduleResourceType = "SDDC_MANAGER"
+	backupLocationProtocolSftp = "SFTP"
+)
+
+var daysOfWeek = []string{"SUNDAY", "MONDAY", "TUESDAY", "WEDNESDAY", "THURSDAY", "FRIDAY", "SATURDAY"}
+
+func ResourceSddcManagerBackupConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSddcManagerBackupConfigurationCreate,
+		ReadContext:   resourceSddcManagerBackupConfigurationRead,
+		UpdateContext: resourceSddcManagerBackupConfigurationUpdate,
+		DeleteContext: resourceSddcManagerBackupConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Read:   schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"backup_location": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MaxItems:    1,
+				Description: "SFTP server to which SDDC Manager and NSX Manager are backed up",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "IP address or FQDN of the backup server",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      22,
+							Description:  "Port of the backup server, default 22",
+							ValidateFunc: validation.IsPortNumber,
+						},
+						"directory_path": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full directory path on the backup server to save the backup files to",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"username": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Username to authenticate to the backup server",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"password": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Sensitive:    true,
+							Description:  "Password to authenticate to the backup server",
+							ValidateFunc: validation.NoZeroValues,
+						},
+						"ssh_fingerprint": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "SSH fingerprint of the backup server, e.g. SHA256:hvZb4aLOe6EMRY+Xq3vCN9Hx9FDI7lwzVZDaZmcm2Q0",
+							ValidateFunc: validation.NoZeroValues,
+						},
+					},
+				},
+			},
+			"encryption_passphrase": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "Passphrase the backup files are encrypted with. It is required to restore from them",
+				ValidateFunc: validationutils.ValidatePassword,
+			},
+			"backup_schedule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Schedule of the backups of SDDC Manager. If omitted, the schedule configured in SDDC Manager is kept, without a schedule SDDC Manager is only backed up on demand",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"frequency": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Backup frequency. One among: WEEKLY, HOURLY",
+							ValidateFunc: validation.StringInSlice([]string{"WEEKLY", "HOURLY"}, false),
+						},
+						"days_of_week": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							Description: "Days of the week of weekly backups. One among: SUNDAY, MONDAY, TUESDAY, WEDNESDAY, THURSDAY, FRIDAY, SATURDAY",
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(daysOfWeek, false),
+							},
+						},
+						"hour_of_day": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Hour of the day of weekly backups, from 0 to 23",
+							ValidateFunc: validation.IntBetween(0, 23),
+						},
+						"minute_of_hour": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Description:  "Minute of the hour of the backups, from 0 to 59",
+							ValidateFunc: validation.IntBetween(0, 59),
+						},
+						"take_scheduled_backups": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+							Description: "Whether the scheduled backups are enabled, default true",
+						},
+						"take_backup_on_state_change": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Whether SDDC Manager is backed up after every operation that changes its state, default false. Requires scheduled backups",
+						},
+						"retention_policy": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "Retention of the backup files. Backup files are deleted if they do not satisfy any of its settings",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"number_of_most_recent_backups": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										Description:  "Number of the most recent backup files to retain, from 1 to 600",
+										ValidateFunc: validation.IntBetween(1, 600),
+									},
+									"number_of_days_of_hourly_backups": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Description:  "Number of days for which one backup file per hour is retained, from 0 to 14",
+										ValidateFunc: validation.IntBetween(0, 14),
+									},
+									"number_of_days_of_daily_backups": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Description:  "Number of days for which one backup file per day is retained, from 0 to 30",
+										ValidateFunc: validation.IntBetween(0, 30),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"is_configured": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the backups are configured in SDDC Manager",
+			},
+		},
+	}
+}
+
+func resourceSddcManagerBackupConfigurationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+
+	configureBackupSettingsParams := backup_restore.NewConfigureBackupSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithBackupConfigurationSpec(getBackupConfigurationSpec(data))
+
+	responseOk, responseAccepted, err := apiClient.BackupRestore.ConfigureBackupSettings(configureBackupSettingsParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return diag.FromErr(err)
+	}
+	data.SetId(backupConfigurationId)
+
+	return resourceSddcManagerBackupConfigurationRead(ctx, data, meta)
+}
+
+// resourceSddcManagerBackupConfigurationRead reads the backup configuration. The passwords and the
+// encryption passphrase are not returned by the VCF API and are kept from the configuration.
+func resourceSddcManagerBackupConfigurationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	getBackupSettingsParams := backup_restore.NewGetBackupSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	backupSettingsResult, err := apiClient.BackupRestore.GetBackupSettings(getBackupSettingsParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	backupConfiguration := backupSettingsResult.Payload
+	_ = data.Set("is_configured", backupConfiguration.IsConfigured)
+
+	if len(backupConfiguration.BackupLocations) > 0 && backupConfiguration.BackupLocations[0] != nil {
+		backupLocation := backupConfiguration.BackupLocations[0]
+		flattenedBackupLocation := map[string]interface{}{
+			"server":          backupLocation.Server,
+			"port":            int(backupLocation.Port),
+			"directory_path":  backupLocation.DirectoryPath,
+			"username":        backupLocation.Username,
+			"password":        data.Get("backup_location.0.password"),
+			"ssh_fingerprint": backupLocation.SSHFingerprint,
+		}
+		_ = data.Set("backup_location", []interface{}{flattenedBackupLocation})
+	}
+
+	var flattenedBackupSchedules []interface{}
+	for _, backupSchedule := range backupConfiguration.BackupSchedules {
+		if backupSchedule == nil || backupSchedule.ResourceType == nil || *backupSchedule.ResourceType != backupScheduleResourceType {
+			continue
+		}
+		flattenedBackupSchedules = append(flattenedBackupSchedules, flattenBackupSchedule(backupSchedule))
+	}
+	_ = data.Set("backup_schedule", flattenedBackupSchedules)
+
+	return nil
+}
+
+func resourceSddcManagerBackupConfigurationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	if diags := editBackupSettings(ctx, vcfClient, getBackupConfigurationSpec(data)); diags != nil {
+		return diags
+	}
+
+	return resourceSddcManagerBackupConfigurationRead(ctx, data, meta)
+}
+
+// resourceSddcManagerBackupConfigurationDelete disables the scheduled backups, the VCF API
+// cannot remove the backup location. An imported backup configuration whose password and encryption
+// passphrase have never been set cannot be edited and is left unchanged.
+func resourceSddcManagerBackupConfigurationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+
+	backupConfigurationSpec := getBackupConfigurationSpec(data)
+	if !hasBackupSecrets(backupConfigurationSpec) {
+		tflog.Warn(ctx, "The password or the encryption passphrase of the backup configuration is unknown, the scheduled backups are left unchanged")
+		data.SetId("")
+		return nil
+	}
+	if len(backupConfigurationSpec.BackupSchedules) > 0 {
+		backupConfigurationSpec.BackupSchedules[0].TakeScheduledBackups = false
+		backupConfigurationSpec.BackupSchedules[0].TakeBackupOnStateChange = false
+		if diags := editBackupSettings(ctx, vcfClient, backupConfigurationSpec); diags != nil {
+			return diags
+		}
+	}
+
+	data.SetId("")
+	return nil
+}
+
+func editBackupSettings(ctx context.Context, vcfClient *api_client.SddcManagerClient,
+	backupConfigurationSpec *models.BackupConfigurationSpec) diag.Diagnostics {
+	editBackupSettingsParams := backup_restore.NewEditBackupSettingsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithBackupConfigurationSpec(backupConfigurationSpec)
+
+	responseOk, responseAccepted, err := vcfClient.ApiClient.BackupRestore.EditBackupSettings(editBackupSettingsParams)
+	if err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	var taskId string
+	if responseOk != nil {
+		taskId = responseOk.Payload.ID
+	} else {
+		taskId = responseAccepted.Payload.ID
+	}
+	if err = vcfClient.WaitForTaskComplete(ctx, taskId, false); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+// hasBackupSecrets reports whether the backup configuration spec has a backup location with a password
+// and an encryption passphrase, which the VCF API requires to edit the backup settings. They are not
+// returned by the VCF API, so an imported backup configuration does not have them until they are configured.
+func hasBackupSecrets(backupConfigurationSpec *models.BackupConfigurationSpec) bool {
+	if len(backupConfigurationSpec.BackupLocations) == 0 {
+		return false
+	}
+	password := backupConfigurationSpec.BackupLocations[0].Password
+	passphrase := backupConfigurationSpec.Encryption.Passphrase
+	return password != nil && *password != "" && passphrase != nil && *passphrase != ""
+}
+
+func getBackupConfigurationSpec(data *schema.ResourceData) *models.BackupConfigurationSpec {
+	backupConfigurationSpec := &models.BackupConfigurationSpec{
+		Encryption: &models.Encryption{
+			Passphrase: resource_utils.ToStringPointer(data.Get("encryption_passphrase")),
+		},
+	}
+
+	backupLocations := data.Get("backup_location").([]interface{})
+	if len(backupLocations) > 0 && backupLocations[0] != nil {
+		protocol := backupLocationProtocolSftp
+		backupLocationData := backupLocations[0].(map[string]interface{})
+		backupConfigurationSpec.BackupLocations = []*models.BackupLocation{
+			{
+				Server:         backupLocationData["server"].(string),
+				Port:           int32(backupLocationData["port"].(int)),
+				Protocol:       &protocol,
+				DirectoryPath:  backupLocationData["directory_path"].(string),
+				Username:       backupLocationData["username"].(string),
+				Password:       resource_utils.ToStringPointer(backupLocationData["password"]),
+				SSHFingerprint: backupLocationData["ssh_fingerprint"].(string),
+			},
+		}
+	}
+
+	backupSchedules := data.Get("backup_schedule").([]interface{})
+	if len(backupSchedules) > 0 && backupSchedules[0] != nil {
+		backupConfigurationSpec.BackupSchedules = []*models.BackupSchedule{
+			getBackupSchedule(backupSchedules[0].(map[string]interface{})),
+		}
+	}
+	return backupConfigurationSpec
+}
+
+func getBackupSchedule(backupScheduleData map[string]interface{}) *models.BackupSchedule {
+	resourceType := backupScheduleResourceType
+	backupSchedule := &models.BackupSchedule{
+		ResourceType:            &resourceType,
+		Frequency:               resource_utils.ToStringPointer(backupScheduleData["frequency"]),
+		DaysOfWeek:              resource_utils.ToStringSlice(backupScheduleData["days_of_week"].([]interface{})),
+		HourOfDay:               int32(backupScheduleData["hour_of_day"].(int)),
+		MinuteOfHour:            int32(backupScheduleData["minute_of_hour"].(int)),
+		TakeScheduledBackups:    backupScheduleData["take_scheduled_backups"].(bool),
+		TakeBackupOnStateChange: backupScheduleData["take_backup_on_state_change"].(bool),
+	}
+	retentionPolicies := backupScheduleData["retention_policy"].([]interface{})
+	if len(retentionPolicies) > 0 && retentionPolicies[0] != nil {
+		retentionPolicyData := retentionPolicies[0].(map[string]interface{})
+		backupSchedule.RetentionPolicy = &models.BackupRetentionPolicy{
+			NumberOfMostRecentBackups:   resource_utils.ToInt32Pointer(retentionPolicyData["number_of_most_recent_backups"]),
+			NumberOfDaysOfHourlyBackups: int32(retentionPolicyData["number_of_days_of_hourly_backups"].(int)),
+			NumberOfDaysOfDailyBackups:  int32(retentionPolicyData["number_of_days_of_daily_backups"].(int)),
+		}
+	}
+	return backupSchedule
+}
+
+func flattenBackupSchedule(backupSchedule *models.BackupSchedule) map[string]interface{} {
+	result := map[string]interface{}{
+		"days_of_week":                backupSchedule.DaysOfWeek,
+		"hour_of_day":                 int(backupSchedule.HourOfDay),
+		"minute_of_hour":              int(backupSchedule.MinuteOfHour),
+		"take_scheduled_backups":      backupSchedule.TakeScheduledBackups,
+		"take_backup_on_state_change": backupSchedule.TakeBackupOnStateChange,
+	}
+	if backupSchedule.Frequency != nil {
+		result["frequency"] = *backupSchedule.Frequency
+	}
+	if backupSchedule.RetentionPolicy != nil {
+		retentionPolicy := map[string]interface{}{
+			"number_of_days_of_hourly_backups": int(backupSchedule.RetentionPolicy.NumberOfDaysOfHourlyBackups),
+			"number_of_days_of_daily_backups":  int(backupSchedule.RetentionPolicy.NumberOfDaysOfDailyBackups),
+		}
+		if backupSchedule.RetentionPolicy.NumberOfMostRecentBackups != nil {
+			retentionPolicy["number_of_most_recent_backups"] = int(*backupSchedule.RetentionPolicy.NumberOfMostRecentBackups)
+		}
+		result["retention_policy"] = []interface{}{retentionPolicy}
+	}
+	return result
+}
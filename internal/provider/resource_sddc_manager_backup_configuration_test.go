@@ -0,0 +1,114 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"testing"
+)
+
+func TestGetBackupConfigurationSpec(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, ResourceSddcManagerBackupConfiguration().Schema, map[string]interface{}{
+		"backup_location": []interface{}{
+			map[string]interface{}{
+				"server":          "sftp.vrack.vsphere.local",
+				"directory_path":  "/backups",
+				"username":        "backup",
+				"password":        "VMware123!",
+				"ssh_fingerprint": "SHA256:hvZb4aLOe6EMRY+Xq3vCN9Hx9FDI7lwzVZDaZmcm2Q0",
+			},
+		},
+		"encryption_passphrase": "VMware123!VMware123!",
+		"backup_schedule": []interface{}{
+			map[string]interface{}{
+				"frequency":    "WEEKLY",
+				"days_of_week": []interface{}{"MONDAY", "THURSDAY"},
+				"hour_of_day":  2,
+				"retention_policy": []interface{}{
+					map[string]interface{}{
+						"number_of_most_recent_backups":   10,
+						"number_of_days_of_daily_backups": 7,
+					},
+				},
+			},
+		},
+	})
+
+	backupConfigurationSpec := getBackupConfigurationSpec(data)
+	backupLocation := backupConfigurationSpec.BackupLocations[0]
+	if *backupLocation.Protocol != "SFTP" || backupLocation.Port != 22 || *backupLocation.Password != "VMware123!" {
+		t.Errorf("unexpected backup location %+v", backupLocation)
+	}
+	if *backupConfigurationSpec.Encryption.Passphrase != "VMware123!VMware123!" {
+		t.Errorf("unexpected encryption passphrase %q", *backupConfigurationSpec.Encryption.Passphrase)
+	}
+	backupSchedule := backupConfigurationSpec.BackupSchedules[0]
+	if *backupSchedule.ResourceType != "SDDC_MANAGER" || *backupSchedule.Frequency != "WEEKLY" ||
+		len(backupSchedule.DaysOfWeek) != 2 || backupSchedule.HourOfDay != 2 || !backupSchedule.TakeScheduledBackups {
+		t.Errorf("unexpected backup schedule %+v", backupSchedule)
+	}
+	if *backupSchedule.RetentionPolicy.NumberOfMostRecentBackups != 10 || backupSchedule.RetentionPolicy.NumberOfDaysOfDailyBackups != 7 {
+		t.Errorf("unexpected retention policy %+v", backupSchedule.RetentionPolicy)
+	}
+
+	flattenedBackupSchedule := flattenBackupSchedule(backupSchedule)
+	if flattenedBackupSchedule["frequency"] != "WEEKLY" || flattenedBackupSchedule["hour_of_day"] != 2 {
+		t.Errorf("unexpected flattened backup schedule %v", flattenedBackupSchedule)
+	}
+	retentionPolicy := flattenedBackupSchedule["retention_policy"].([]interface{})[0].(map[string]interface{})
+	if retentionPolicy["number_of_most_recent_backups"] != 10 {
+		t.Errorf("unexpected flattened retention policy %v", retentionPolicy)
+	}
+}
+
+func TestDeleteImportedBackupConfiguration(t *testing.T) {
+	testCases := []struct {
+		name           string
+		backupLocation []interface{}
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "configured without password",
+			backupLocation: []interface{}{
+				map[string]interface{}{
+					"server":          "sftp.vrack.vsphere.local",
+					"directory_path":  "/backups",
+					"username":        "backup",
+					"ssh_fingerprint": "SHA256:hvZb4aLOe6EMRY+Xq3vCN9Hx9FDI7lwzVZDaZmcm2Q0",
+				},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			data := ResourceSddcManagerBackupConfiguration().TestResourceData()
+			data.SetId(backupConfigurationId)
+			if testCase.backupLocation != nil {
+				if err := data.Set("backup_location", testCase.backupLocation); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			backupConfigurationSpec := getBackupConfigurationSpec(data)
+			if hasBackupSecrets(backupConfigurationSpec) {
+				t.Errorf("unexpected backup secrets in %+v", backupConfigurationSpec)
+			}
+			// The edit is skipped, so no API call is made with the empty client.
+			diags := resourceSddcManagerBackupConfigurationDelete(context.Background(), data, &api_client.SddcManagerClient{})
+			if diags.HasError() {
+				t.Errorf("unexpected error %v", diags)
+			}
+			if data.Id() != "" {
+				t.Errorf("unexpected ID %q", data.Id())
+			}
+		})
+	}
+}
@@ -0,0 +1,267 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationutils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client/identity_providers"
+	"github.com/vmware/vcf-sdk-go/models"
+	"strings"
+	"time"
+)
+
+// TODO support federation with an external identity provider, e.g. ADFS. It is configured with OIDC
+// and replaces the embedded identity provider, which fits a separate resource.
+
+func ResourceSsoDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSsoDomainCreate,
+		ReadContext:   resourceSsoDomainRead,
+		UpdateContext: resourceSsoDomainUpdate,
+		DeleteContext: resourceSsoDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The user-friendly name of the identity source",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"domain_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the domain of the identity source, e.g. example.com",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"domain_alias": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The alias of the domain of the identity source, e.g. the NetBIOS name of an Active Directory domain",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The type of the LDAP server. One among: ActiveDirectory, OpenLdap",
+				ValidateFunc: validation.StringInSlice([]string{"ActiveDirectory", "OpenLdap"}, false),
+			},
+			"username": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Username with which the LDAP server is connected to",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"password": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "Password with which the LDAP server is connected to",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"users_base_dn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Base distinguished name for users, e.g. cn=Users,dc=example,dc=com",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"groups_base_dn": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Base distinguished name for groups, e.g. dc=example,dc=com",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"server_endpoints": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Endpoints of the LDAP servers, e.g. ldaps://dc01.example.com:636",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.IsURLWithScheme([]string{"ldap", "ldaps"}),
+				},
+			},
+			"cert_chain": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "SSL certificate chain of the LDAP servers in base64 encoding. Can be omitted only if all the server endpoints use the LDAP (not LDAPS) protocol",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
+			},
+			"identity_provider_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the embedded identity provider of the management SSO domain to which the identity source is added",
+			},
+		},
+	}
+}
+
+func resourceSsoDomainCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	identityProviderId, err := getEmbeddedIdentityProviderId(ctx, apiClient.IdentityProviders)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	addIdentitySourceParams := identity_providers.NewAddEmbeddedIdentitySourceParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(identityProviderId).
+		WithIdentitySourceSpec(getIdentitySourceSpec(data))
+	if _, _, err = apiClient.IdentityProviders.AddEmbeddedIdentitySource(addIdentitySourceParams); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+	data.SetId(data.Get("domain_name").(string))
+
+	return resourceSsoDomainRead(ctx, data, meta)
+}
+
+func resourceSsoDomainRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	identityProviderId, err := getEmbeddedIdentityProviderId(ctx, apiClient.IdentityProviders)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	getIdentityProviderParams := identity_providers.NewGetIdentityProviderByIDParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(identityProviderId)
+	identityProviderResult, err := apiClient.IdentityProviders.GetIdentityProviderByID(getIdentityProviderParams)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	identitySource := findIdentitySource(identityProviderResult.Payload.IdentitySources, data.Id())
+	if identitySource == nil {
+		tflog.Warn(ctx, fmt.Sprintf("Identity source %s not found, removing it from the state", data.Id()))
+		data.SetId("")
+		return nil
+	}
+
+	_ = data.Set("identity_provider_id", identityProviderId)
+	_ = data.Set("domain_name", data.Id())
+	_ = data.Set("name", identitySource.Name)
+	if identitySource.Ldap != nil {
+		_ = data.Set("domain_alias", identitySource.Ldap.DomainAlias)
+		_ = data.Set("type", identitySource.Ldap.Type)
+		_ = data.Set("username", identitySource.Ldap.Username)
+		if sourceDetails := identitySource.Ldap.SourceDetails; sourceDetails != nil {
+			if sourceDetails.UsersBaseDn != nil {
+				_ = data.Set("users_base_dn", *sourceDetails.UsersBaseDn)
+			}
+			if sourceDetails.GroupsBaseDn != nil {
+				_ = data.Set("groups_base_dn", *sourceDetails.GroupsBaseDn)
+			}
+			_ = data.Set("server_endpoints", sourceDetails.ServerEndpoints)
+			if len(sourceDetails.CertChain) > 0 {
+				_ = data.Set("cert_chain", sourceDetails.CertChain)
+			}
+		}
+	}
+
+	return nil
+}
+
+func resourceSsoDomainUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	updateIdentitySourceParams := identity_providers.NewUpdateEmbeddedIdentitySourceParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Get("identity_provider_id").(string)).
+		WithDomainName(data.Id()).
+		WithIdentitySourceSpec(getIdentitySourceSpec(data))
+	if _, _, err := apiClient.IdentityProviders.UpdateEmbeddedIdentitySource(updateIdentitySourceParams); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+
+	return resourceSsoDomainRead(ctx, data, meta)
+}
+
+func resourceSsoDomainDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	deleteIdentitySourceParams := identity_providers.NewDeleteIdentitySourceParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithID(data.Get("identity_provider_id").(string)).
+		WithDomainName(data.Id())
+	if _, _, err := apiClient.IdentityProviders.DeleteIdentitySource(deleteIdentitySourceParams); err != nil {
+		return validationutils.ConvertVcfErrorToDiag(err)
+	}
+
+	data.SetId("")
+	return nil
+}
+
+// getEmbeddedIdentityProviderId returns the ID of the identity provider that is embedded in the vCenter
+// Server of the management domain, to which the identity sources of the management SSO domain belong.
+func getEmbeddedIdentityProviderId(ctx context.Context, identityProvidersClient identity_providers.ClientService) (string, error) {
+	getIdentityProvidersParams := identity_providers.NewGetAllIdpsParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout)
+	identityProvidersResult, err := identityProvidersClient.GetAllIdps(getIdentityProvidersParams)
+	if err != nil {
+		return "", err
+	}
+	for _, identityProvider := range identityProvidersResult.Payload.Elements {
+		if identityProvider != nil && strings.EqualFold(identityProvider.Type, "Embedded") {
+			return identityProvider.ID, nil
+		}
+	}
+	return "", errors.New("no embedded identity provider found, identity sources cannot be added while an external identity provider is used")
+}
+
+// findIdentitySource returns the identity source of the given domain, or nil if there is none.
+func findIdentitySource(identitySources []*models.VcIdentitySources, domainName string) *models.VcIdentitySources {
+	for _, identitySource := range identitySources {
+		if identitySource == nil {
+			continue
+		}
+		if identitySource.Ldap != nil && strings.EqualFold(identitySource.Ldap.DomainName, domainName) {
+			return identitySource
+		}
+		for _, identitySourceDomainName := range identitySource.DomainNames {
+			if strings.EqualFold(identitySourceDomainName, domainName) {
+				return identitySource
+			}
+		}
+	}
+	return nil
+}
+
+func getIdentitySourceSpec(data *schema.ResourceData) *models.IdentitySourceSpec {
+	return &models.IdentitySourceSpec{
+		Name: resource_utils.ToStringPointer(data.Get("name")),
+		Ldap: &models.LdapSpec{
+			DomainName:  resource_utils.ToStringPointer(data.Get("domain_name")),
+			DomainAlias: data.Get("domain_alias").(string),
+			Type:        resource_utils.ToStringPointer(data.Get("type")),
+			Username:    resource_utils.ToStringPointer(data.Get("username")),
+			Password:    resource_utils.ToStringPointer(data.Get("password")),
+			SourceDetails: &models.SourceDetails{
+				UsersBaseDn:     resource_utils.ToStringPointer(data.Get("users_base_dn")),
+				GroupsBaseDn:    resource_utils.ToStringPointer(data.Get("groups_base_dn")),
+				ServerEndpoints: resource_utils.ToStringSlice(data.Get("server_endpoints").([]interface{})),
+				CertChain:       resource_utils.ToStringSlice(data.Get("cert_chain").([]interface{})),
+			},
+		},
+	}
+}
@@ -0,0 +1,59 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/vcf-sdk-go/models"
+	"testing"
+)
+
+func TestGetIdentitySourceSpec(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, ResourceSsoDomain().Schema, map[string]interface{}{
+		"name":             "example",
+		"domain_name":      "example.com",
+		"domain_alias":     "EXAMPLE",
+		"type":             "ActiveDirectory",
+		"username":         "svc-vcf@example.com",
+		"password":         "VMware123!",
+		"users_base_dn":    "cn=Users,dc=example,dc=com",
+		"groups_base_dn":   "dc=example,dc=com",
+		"server_endpoints": []interface{}{"ldaps://dc01.example.com:636", "ldaps://dc02.example.com:636"},
+		"cert_chain":       []interface{}{"MIIDdzCCAl+gAwIBAgIQ"},
+	})
+
+	identitySourceSpec := getIdentitySourceSpec(data)
+	if *identitySourceSpec.Name != "example" || *identitySourceSpec.Ldap.DomainName != "example.com" ||
+		identitySourceSpec.Ldap.DomainAlias != "EXAMPLE" || *identitySourceSpec.Ldap.Type != "ActiveDirectory" {
+		t.Errorf("failed. Unexpected identity source spec %+v", identitySourceSpec.Ldap)
+	}
+	sourceDetails := identitySourceSpec.Ldap.SourceDetails
+	if *sourceDetails.UsersBaseDn != "cn=Users,dc=example,dc=com" || *sourceDetails.GroupsBaseDn != "dc=example,dc=com" {
+		t.Errorf("failed. Unexpected base DNs %q, %q", *sourceDetails.UsersBaseDn, *sourceDetails.GroupsBaseDn)
+	}
+	if len(sourceDetails.ServerEndpoints) != 2 || len(sourceDetails.CertChain) != 1 {
+		t.Errorf("failed. Unexpected server endpoints %v or certificate chain %v",
+			sourceDetails.ServerEndpoints, sourceDetails.CertChain)
+	}
+}
+
+func TestFindIdentitySource(t *testing.T) {
+	identitySources := []*models.VcIdentitySources{
+		nil,
+		{Name: "vsphere.local", DomainNames: []string{"vsphere.local"}},
+		{Name: "example", DomainNames: []string{"EXAMPLE.COM"}, Ldap: &models.LdapInfo{DomainName: "example.com"}},
+	}
+
+	if identitySource := findIdentitySource(identitySources, "example.com"); identitySource == nil || identitySource.Name != "example" {
+		t.Errorf("failed. Unexpected identity source %v, expected example", identitySource)
+	}
+	if identitySource := findIdentitySource(identitySources, "VSPHERE.local"); identitySource == nil || identitySource.Name != "vsphere.local" {
+		t.Errorf("failed. Unexpected identity source %v, expected vsphere.local", identitySource)
+	}
+	if identitySource := findIdentitySource(identitySources, "other.com"); identitySource != nil {
+		t.Errorf("failed. Unexpected identity source %v for an unknown domain", identitySource)
+	}
+}
@@ -0,0 +1,388 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/constants"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	validationUtils "github.com/vmware/terraform-provider-vcf/internal/validation"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/releases"
+	"github.com/vmware/vcf-sdk-go/client/system_prechecks"
+	"github.com/vmware/vcf-sdk-go/client/upgradables"
+	"github.com/vmware/vcf-sdk-go/client/upgrades"
+	"github.com/vmware/vcf-sdk-go/models"
+	"strings"
+	"time"
+)
+
+// upgradePollInterval is the interval between polls of the status of a precheck or an upgrade.
+var upgradePollInterval = 20 * time.Second
+
+func ResourceUpgrade() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceUpgradeCreate,
+		ReadContext:   resourceUpgradeRead,
+		DeleteContext: resourceUpgradeDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(24 * time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"domain_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "ID of the domain to upgrade",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"target_version": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "VCF version the domain is upgraded to, e.g. 5.1.0.0. The bundles of the version have to be downloaded, e.g. with vcf_lcm_bundle",
+				ValidateFunc: validation.NoZeroValues,
+			},
+			"parallel_upgrade": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Upgrade the components of a bundle, e.g. the clusters of the domain, in parallel, default false",
+			},
+			"upgrade": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Upgrades performed on the domain, in the order they were performed",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"bundle_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the bundle of the upgrade",
+						},
+						"task_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the task of the upgrade",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceUpgradeCreate applies the available bundles of the target version one at a time, as
+// SDDC Manager does not allow skipping bundles, running the prechecks before every upgrade. Once no
+// bundle is available, every bundle has to be applied and the domain has to be at the target version.
+func resourceUpgradeCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcfClient := meta.(*api_client.SddcManagerClient)
+	apiClient := vcfClient.ApiClient
+
+	domainId := data.Get("domain_id").(string)
+	targetVersion := data.Get("target_version").(string)
+	var performedUpgrades []map[string]interface{}
+	upgradedBundleIds := make(map[string]bool)
+	for {
+		getUpgradablesParams := upgradables.NewGetUpgradablesByDomainParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithDomainID(domainId).
+			WithTargetVersion(&targetVersion)
+		upgradablesResult, err := apiClient.Upgradables.GetUpgradablesByDomain(getUpgradablesParams)
+		if err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
+		upgradeSpec := getNextUpgradeSpec(upgradablesResult.Payload.Elements)
+		if upgradeSpec == nil {
+			if err = checkUpgradablesCompleted(upgradablesResult.Payload.Elements, domainId, targetVersion); err != nil {
+				return diag.FromErr(err)
+			}
+			break
+		}
+		bundleId := *upgradeSpec.BundleID
+		if upgradedBundleIds[bundleId] {
+			return diag.Errorf("bundle %s is still available after it has been applied to domain %s", bundleId, domainId)
+		}
+		upgradedBundleIds[bundleId] = true
+		upgradeSpec.ParallelUpgrade = data.Get("parallel_upgrade").(bool)
+
+		tflog.Info(ctx, fmt.Sprintf("Running the prechecks of bundle %s on domain %s", bundleId, domainId))
+		precheckTask, diags := runPrechecks(ctx, apiClient, domainId, bundleId)
+		if diags != nil {
+			return diags
+		}
+		if diags = convertPrecheckTaskToDiag(precheckTask); diags != nil {
+			return diags
+		}
+
+		tflog.Info(ctx, fmt.Sprintf("Upgrading domain %s with bundle %s", domainId, bundleId))
+		performUpgradeParams := upgrades.NewPerformUpgradeParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithUpgradeSpec(upgradeSpec)
+		okResponse, acceptedResponse, err := apiClient.Upgrades.PerformUpgrade(performUpgradeParams)
+		if err != nil {
+			return validationUtils.ConvertVcfErrorToDiag(err)
+		}
+		var taskId string
+		if okResponse != nil {
+			taskId = okResponse.Payload.ID
+		}
+		if acceptedResponse != nil {
+			taskId = acceptedResponse.Payload.ID
+		}
+		performedUpgrades = append(performedUpgrades, map[string]interface{}{
+			"bundle_id": bundleId,
+			"task_id":   taskId,
+		})
+		data.SetId(taskId)
+		_ = data.Set("upgrade", performedUpgrades)
+
+		if err = waitForUpgrade(ctx, vcfClient, taskId); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	releaseVersion, err := getDomainReleaseVersion(ctx, apiClient, domainId)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	if releaseVersion != targetVersion {
+		return diag.Errorf("domain %s is at version %s instead of %s, although no bundle of the version is available for it",
+			domainId, releaseVersion, targetVersion)
+	}
+	if len(performedUpgrades) == 0 {
+		tflog.Info(ctx, fmt.Sprintf("Domain %s is already at version %s", domainId, targetVersion))
+		data.SetId(fmt.Sprintf("%s:%s", domainId, targetVersion))
+	}
+
+	return resourceUpgradeRead(ctx, data, meta)
+}
+
+// resourceUpgradeRead removes the upgrade from the state if bundles of the target version are
+// available for the domain again, so that the next apply upgrades the domain to the target version.
+func resourceUpgradeRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	apiClient := meta.(*api_client.SddcManagerClient).ApiClient
+
+	domainId := data.Get("domain_id").(string)
+	targetVersion := data.Get("target_version").(string)
+	getUpgradablesParams := upgradables.NewGetUpgradablesByDomainParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDomainID(domainId).
+		WithTargetVersion(&targetVersion)
+	upgradablesResult, err := apiClient.Upgradables.GetUpgradablesByDomain(getUpgradablesParams)
+	if err != nil {
+		return validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	if upgradeSpec := getNextUpgradeSpec(upgradablesResult.Payload.Elements); upgradeSpec != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Bundle %s of version %s is available for domain %s, removing the upgrade from the state",
+			*upgradeSpec.BundleID, targetVersion, domainId))
+		data.SetId("")
+	}
+	return nil
+}
+
+// resourceUpgradeDelete only removes the upgrade from the state, a completed upgrade cannot be reverted.
+func resourceUpgradeDelete(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	data.SetId("")
+	return nil
+}
+
+// getNextUpgradeSpec returns the spec that upgrades all resources to which the first available bundle
+// applies, or nil if no bundle is available.
+func getNextUpgradeSpec(upgradableList []*models.Upgradable) *models.UpgradeSpec {
+	var upgradeSpec *models.UpgradeSpec
+	for _, upgradable := range upgradableList {
+		if upgradable == nil || upgradable.Status != "AVAILABLE" || upgradable.Resource == nil ||
+			upgradable.Resource.ResourceID == nil || upgradable.Resource.Type == nil {
+			continue
+		}
+		if upgradeSpec == nil {
+			upgradeSpec = &models.UpgradeSpec{
+				BundleID:     resource_utils.ToStringPointer(upgradable.BundleID),
+				ResourceType: resource_utils.ToStringPointer(*upgradable.Resource.Type),
+			}
+		}
+		if upgradable.BundleID != *upgradeSpec.BundleID || *upgradable.Resource.Type != *upgradeSpec.ResourceType {
+			continue
+		}
+		upgradeSpec.ResourceUpgradeSpecs = append(upgradeSpec.ResourceUpgradeSpecs, &models.ResourceUpgradeSpec{
+			ResourceID: upgradable.Resource.ResourceID,
+			UpgradeNow: true,
+		})
+	}
+	return upgradeSpec
+}
+
+// checkUpgradablesCompleted returns an error for the upgradables of the target version that are neither
+// available nor completed, e.g. PENDING while their bundle is not downloaded, IN_PROGRESS while an
+// earlier upgrade is running or FAILED after a failed upgrade.
+func checkUpgradablesCompleted(upgradableList []*models.Upgradable, domainId, targetVersion string) error {
+	var incompleteUpgradables []string
+	for _, upgradable := range upgradableList {
+		if upgradable == nil || upgradable.Status == "AVAILABLE" || upgradable.Status == "COMPLETED" {
+			continue
+		}
+		resourceId := ""
+		if upgradable.Resource != nil && upgradable.Resource.ResourceID != nil {
+			resourceId = *upgradable.Resource.ResourceID
+		}
+		incompleteUpgradables = append(incompleteUpgradables,
+			fmt.Sprintf("bundle %s of resource %s is %s", upgradable.BundleID, resourceId, upgradable.Status))
+	}
+	if len(incompleteUpgradables) > 0 {
+		return fmt.Errorf("domain %s cannot be upgraded to version %s, %s. Download the bundles, wait for "+
+			"running upgrades and resolve failed ones in SDDC Manager", domainId, targetVersion, strings.Join(incompleteUpgradables, ", "))
+	}
+	return nil
+}
+
+// getDomainReleaseVersion returns the VCF version of a domain, which SDDC Manager derives from the
+// versions of its components.
+func getDomainReleaseVersion(ctx context.Context, apiClient *client.VcfClient, domainId string) (string, error) {
+	getReleasesParams := releases.NewGetReleasesParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithDomainID(&domainId)
+	releasesResult, err := apiClient.Releases.GetReleases(getReleasesParams)
+	if err != nil {
+		return "", err
+	}
+	for _, release := range releasesResult.Payload.Elements {
+		if release != nil && release.Version != nil {
+			return *release.Version, nil
+		}
+	}
+	return "", fmt.Errorf("no release found for domain %s", domainId)
+}
+
+// runPrechecks runs the prechecks on a domain, for the bundle if bundleId is set, and returns the
+// finished precheck task.
+func runPrechecks(ctx context.Context, apiClient *client.VcfClient, domainId, bundleId string) (*models.Task, diag.Diagnostics) {
+	precheckSystemParams := system_prechecks.NewPrecheckSystemParamsWithContext(ctx).
+		WithTimeout(constants.DefaultVcfApiCallTimeout).
+		WithPrecheckSpec(&models.PrecheckSpec{
+			BundleID: bundleId,
+			Resources: []*models.Resource{
+				{
+					ResourceID: &domainId,
+					Type:       resource_utils.ToStringPointer("DOMAIN"),
+				},
+			},
+		})
+	okResponse, acceptedResponse, err := apiClient.SystemPrechecks.PrecheckSystem(precheckSystemParams)
+	if err != nil {
+		return nil, validationUtils.ConvertVcfErrorToDiag(err)
+	}
+	var precheckTask *models.Task
+	if okResponse != nil {
+		precheckTask = okResponse.Payload
+	} else {
+		precheckTask = acceptedResponse.Payload
+	}
+	for precheckTask != nil && isTaskInProgress(precheckTask.Status) {
+		select {
+		case <-ctx.Done():
+			return nil, diag.Errorf("stopped waiting for precheck task %s: %s", precheckTask.ID, ctx.Err())
+		case <-time.After(upgradePollInterval):
+		}
+		getPrecheckTaskParams := system_prechecks.NewGetPrecheckTaskParamsWithContext(ctx).
+			WithTimeout(constants.DefaultVcfApiCallTimeout).
+			WithID(precheckTask.ID)
+		getPrecheckTaskResult, err := apiClient.SystemPrechecks.GetPrecheckTask(getPrecheckTaskParams)
+		if err != nil {
+			return nil, validationUtils.ConvertVcfErrorToDiag(err)
+		}
+		precheckTask = getPrecheckTaskResult.Payload
+	}
+	if precheckTask == nil {
+		return nil, diag.Errorf("the prechecks of domain %s returned no task", domainId)
+	}
+	return precheckTask, nil
+}
+
+// convertPrecheckTaskToDiag converts a failed precheck task to an error diagnostic for every failed
+// precheck, or returns nil if the prechecks have passed.
+func convertPrecheckTaskToDiag(precheckTask *models.Task) diag.Diagnostics {
+	if !isTaskFailed(precheckTask.Status) {
+		return nil
+	}
+	var diags diag.Diagnostics
+	for _, subTask := range precheckTask.SubTasks {
+		if subTask == nil || !isTaskFailed(subTask.Status) {
+			continue
+		}
+		var errorMessages []string
+		for _, subTaskError := range subTask.Errors {
+			if subTaskError != nil {
+				errorMessages = append(errorMessages, subTaskError.Message)
+			}
+		}
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Precheck %q failed", subTask.Name),
+			Detail:   strings.Join(errorMessages, "\n"),
+		})
+	}
+	if len(diags) == 0 {
+		return diag.Errorf("precheck task %s is in state %s", precheckTask.ID, precheckTask.Status)
+	}
+	return diags
+}
+
+// waitForUpgrade polls the upgrade task and logs the progress of every component that is upgraded.
+// SDDC Manager is unreachable while an upgrade restarts its services, which GetTask retries.
+func waitForUpgrade(ctx context.Context, vcfClient *api_client.SddcManagerClient, taskId string) error {
+	reportedStatuses := make(map[string]string)
+	for {
+		task, err := vcfClient.GetTask(ctx, taskId)
+		if err != nil {
+			return err
+		}
+
+		for _, subTask := range task.SubTasks {
+			if subTask == nil || reportedStatuses[subTask.Name] == subTask.Status {
+				continue
+			}
+			reportedStatuses[subTask.Name] = subTask.Status
+			tflog.Info(ctx, fmt.Sprintf("Upgrade task %s: %q is in state %s", taskId, subTask.Name, subTask.Status))
+		}
+
+		if isTaskInProgress(task.Status) {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("stopped waiting for upgrade task %s, which keeps running in SDDC Manager: %w", taskId, ctx.Err())
+			case <-time.After(upgradePollInterval):
+			}
+			continue
+		}
+
+		if isTaskFailed(task.Status) || task.Status == "Cancelled" {
+			return fmt.Errorf("upgrade task %s is in state %s", taskId, task.Status)
+		}
+
+		return nil
+	}
+}
+
+// isTaskInProgress tells whether a task status denotes a running task. Upgrade tasks use
+// human-readable statuses, e.g. "In Progress", precheck tasks use constants, e.g. IN_PROGRESS.
+func isTaskInProgress(status string) bool {
+	switch strings.ToUpper(strings.ReplaceAll(status, " ", "_")) {
+	case "IN_PROGRESS", "PENDING":
+		return true
+	}
+	return false
+}
+
+// isTaskFailed tells whether a task status denotes a failed task, e.g. "Failed" or COMPLETED_WITH_FAILURE.
+func isTaskFailed(status string) bool {
+	return strings.Contains(strings.ToUpper(status), "FAIL")
+}
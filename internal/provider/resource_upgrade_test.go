@@ -0,0 +1,174 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package provider
+
+import (
+	"context"
+	"github.com/vmware/terraform-provider-vcf/internal/api_client"
+	"github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/vcf-sdk-go/client"
+	"github.com/vmware/vcf-sdk-go/client/releases"
+	"github.com/vmware/vcf-sdk-go/client/upgradables"
+	"github.com/vmware/vcf-sdk-go/models"
+	"strings"
+	"testing"
+)
+
+// testUpgradablesClient returns the upgradables of every domain.
+type testUpgradablesClient struct {
+	upgradables.ClientService
+	upgradables []*models.Upgradable
+}
+
+func (c *testUpgradablesClient) GetUpgradablesByDomain(_ *upgradables.GetUpgradablesByDomainParams,
+	_ ...upgradables.ClientOption) (*upgradables.GetUpgradablesByDomainOK, error) {
+	return &upgradables.GetUpgradablesByDomainOK{Payload: &models.PageOfUpgradable{Elements: c.upgradables}}, nil
+}
+
+// testReleasesClient returns the release version of every domain.
+type testReleasesClient struct {
+	releases.ClientService
+	version string
+}
+
+func (c *testReleasesClient) GetReleases(_ *releases.GetReleasesParams, _ ...releases.ClientOption) (*releases.GetReleasesOK, error) {
+	return &releases.GetReleasesOK{Payload: &models.PageOfRelease{Elements: []*models.Release{{Version: &c.version}}}}, nil
+}
+
+func TestResourceUpgradeCreate(t *testing.T) {
+	upgradablesClient := &testUpgradablesClient{upgradables: []*models.Upgradable{{
+		BundleID: "esx-bundle",
+		Status:   "PENDING",
+		Resource: &models.Resource{
+			ResourceID: resource_utils.ToStringPointer("cluster-1"),
+			Type:       resource_utils.ToStringPointer("CLUSTER"),
+		},
+	}}}
+	releasesClient := &testReleasesClient{version: "5.0.0.0"}
+	meta := &api_client.SddcManagerClient{ApiClient: &client.VcfClient{Upgradables: upgradablesClient, Releases: releasesClient}}
+
+	data := ResourceUpgrade().TestResourceData()
+	_ = data.Set("domain_id", "domain-1")
+	_ = data.Set("target_version", "5.1.0.0")
+	diags := resourceUpgradeCreate(context.Background(), data, meta)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "bundle esx-bundle of resource cluster-1 is PENDING") {
+		t.Errorf("expected an error for a pending bundle, got %v", diags)
+	}
+	if data.Id() != "" {
+		t.Errorf("expected no upgrade in the state, got ID %q", data.Id())
+	}
+
+	upgradablesClient.upgradables[0].Status = "COMPLETED"
+	if diags = resourceUpgradeCreate(context.Background(), data, meta); !diags.HasError() {
+		t.Error("expected an error for a domain that is not at the target version")
+	}
+
+	releasesClient.version = "5.1.0.0"
+	if diags = resourceUpgradeCreate(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("unexpected error %v", diags)
+	}
+	if data.Id() != "domain-1:5.1.0.0" {
+		t.Errorf("expected the upgrade of the domain at the target version in the state, got ID %q", data.Id())
+	}
+}
+
+func TestGetNextUpgradeSpec(t *testing.T) {
+	newUpgradable := func(bundleId, resourceType, resourceId, status string) *models.Upgradable {
+		return &models.Upgradable{
+			BundleID: bundleId,
+			Status:   status,
+			Resource: &models.Resource{
+				ResourceID: resource_utils.ToStringPointer(resourceId),
+				Type:       resource_utils.ToStringPointer(resourceType),
+			},
+		}
+	}
+
+	if upgradeSpec := getNextUpgradeSpec([]*models.Upgradable{
+		newUpgradable("nsx-bundle", "DOMAIN", "domain-1", "PENDING"),
+	}); upgradeSpec != nil {
+		t.Errorf("expected no upgrade without an available bundle, got %+v", upgradeSpec)
+	}
+
+	upgradeSpec := getNextUpgradeSpec([]*models.Upgradable{
+		newUpgradable("nsx-bundle", "DOMAIN", "domain-1", "SCHEDULED"),
+		newUpgradable("esx-bundle", "CLUSTER", "cluster-1", "AVAILABLE"),
+		newUpgradable("esx-bundle", "CLUSTER", "cluster-2", "AVAILABLE"),
+		newUpgradable("vcenter-bundle", "DOMAIN", "domain-1", "AVAILABLE"),
+	})
+	if upgradeSpec == nil || *upgradeSpec.BundleID != "esx-bundle" || *upgradeSpec.ResourceType != "CLUSTER" {
+		t.Fatalf("expected an upgrade of the clusters with esx-bundle, got %+v", upgradeSpec)
+	}
+	if len(upgradeSpec.ResourceUpgradeSpecs) != 2 ||
+		*upgradeSpec.ResourceUpgradeSpecs[0].ResourceID != "cluster-1" ||
+		*upgradeSpec.ResourceUpgradeSpecs[1].ResourceID != "cluster-2" ||
+		!upgradeSpec.ResourceUpgradeSpecs[0].UpgradeNow {
+		t.Errorf("expected an immediate upgrade of cluster-1 and cluster-2, got %+v", upgradeSpec.ResourceUpgradeSpecs)
+	}
+}
+
+func TestConvertPrecheckTaskToDiag(t *testing.T) {
+	if diags := convertPrecheckTaskToDiag(&models.Task{Status: "COMPLETED_WITH_SUCCESS"}); diags != nil {
+		t.Errorf("expected no diagnostics for passed prechecks, got %v", diags)
+	}
+
+	diags := convertPrecheckTaskToDiag(&models.Task{
+		ID:     "precheck-1",
+		Status: "COMPLETED_WITH_FAILURE",
+		SubTasks: []*models.SubTask{
+			{Name: "vCenter health", Status: "COMPLETED_WITH_SUCCESS"},
+			{Name: "ESXi disk space", Status: "COMPLETED_WITH_FAILURE", Errors: []*models.Error{{Message: "not enough space"}}},
+		},
+	})
+	if len(diags) != 1 || diags[0].Summary != "Precheck \"ESXi disk space\" failed" || diags[0].Detail != "not enough space" {
+		t.Errorf("unexpected diagnostics %v", diags)
+	}
+}
+
+func TestIsTaskInProgress(t *testing.T) {
+	for status, inProgress := range map[string]bool{
+		"In Progress":            true,
+		"IN_PROGRESS":            true,
+		"Pending":                true,
+		"Successful":             false,
+		"COMPLETED_WITH_FAILURE": false,
+	} {
+		if isTaskInProgress(status) != inProgress {
+			t.Errorf("expected isTaskInProgress(%q) to be %t", status, inProgress)
+		}
+	}
+}
+
+func TestResourceUpgradeRead(t *testing.T) {
+	upgradablesClient := &testUpgradablesClient{upgradables: []*models.Upgradable{{
+		BundleID: "esx-bundle",
+		Status:   "SCHEDULED",
+		Resource: &models.Resource{
+			ResourceID: resource_utils.ToStringPointer("cluster-1"),
+			Type:       resource_utils.ToStringPointer("CLUSTER"),
+		},
+	}}}
+	meta := &api_client.SddcManagerClient{ApiClient: &client.VcfClient{Upgradables: upgradablesClient}}
+
+	data := ResourceUpgrade().TestResourceData()
+	data.SetId("task-1")
+	_ = data.Set("domain_id", "domain-1")
+	_ = data.Set("target_version", "5.1.0.0")
+	if diags := resourceUpgradeRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("unexpected error %v", diags)
+	}
+	if data.Id() != "task-1" {
+		t.Errorf("expected the upgrade to be kept without available bundles, got ID %q", data.Id())
+	}
+
+	upgradablesClient.upgradables[0].Status = "AVAILABLE"
+	if diags := resourceUpgradeRead(context.Background(), data, meta); diags.HasError() {
+		t.Fatalf("unexpected error %v", diags)
+	}
+	if data.Id() != "" {
+		t.Errorf("expected the upgrade to be removed from the state with an available bundle, got ID %q", data.Id())
+	}
+}
@@ -60,7 +60,7 @@ func ResourceUser() *schema.Resource {
 				Type:        schema.TypeString,
 				Required:    true,
 				ForceNew:    true,
-				Description: "The name of the role to assign to the user",
+				Description: "The name of the role to assign to the user, e.g. ADMIN, OPERATOR, VIEWER. See the vcf_roles data source for the available roles",
 			},
 			"api_key": {
 				Type:        schema.TypeString,
@@ -100,14 +100,14 @@ func resourceUserCreate(ctx context.Context, d *schema.ResourceData, meta interf
 	if roleName, ok := d.GetOk("role_name"); ok {
 		roleNameVal := roleName.(string)
 
-		roleResult, err := client.Users.GetRoles(nil)
+		roles, err := getRoles(ctx, client.Users)
 		if err != nil {
 			log.Println("error = ", err)
 			return diag.FromErr(err)
 		}
 
 		roleFound := false
-		for _, role := range roleResult.Payload.Elements {
+		for _, role := range roles {
 			if *role.Name == roleNameVal {
 				user.Role = &models.RoleReference{ID: role.ID}
 				roleFound = true
@@ -148,15 +148,43 @@ func resourceUserRead(ctx context.Context, d *schema.ResourceData, meta interfac
 	// Check if the resource with the known id exists
 	for _, user := range ok.Payload.Elements {
 		if user.ID == id {
+			if user.Name != nil {
+				_ = d.Set("name", *user.Name)
+			}
+			_ = d.Set("domain", user.Domain)
+			if user.Type != nil {
+				_ = d.Set("type", *user.Type)
+			}
+			if user.Role != nil && user.Role.ID != nil {
+				roles, err := getRoles(ctx, client.Users)
+				if err != nil {
+					return diag.FromErr(err)
+				}
+				if roleName := getRoleName(roles, *user.Role.ID); roleName != "" {
+					_ = d.Set("role_name", roleName)
+				}
+			}
 			_ = d.Set("api_key", user.APIKey)
 			_ = d.Set("creation_timestamp", user.CreationTimestamp)
 			return nil
 		}
 	}
 
+	log.Printf("User %s not found, removing it from the state", id)
+	d.SetId("")
 	return nil
 }
 
+// getRoleName returns the name of the role with the given ID, or an empty string if there is none.
+func getRoleName(roles []*models.Role, roleId string) string {
+	for _, role := range roles {
+		if role != nil && role.ID != nil && *role.ID == roleId && role.Name != nil {
+			return *role.Name
+		}
+	}
+	return ""
+}
+
 func resourceUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.SddcManagerClient).ApiClient
 
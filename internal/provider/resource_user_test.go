@@ -35,6 +35,11 @@ func TestAccResourceVcfUser(t *testing.T) {
 					resource.TestCheckResourceAttrSet("vcf_user.serviceuser1", "creation_timestamp"),
 				),
 			},
+			{
+				ResourceName:      "vcf_user.testuser1",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
@@ -24,6 +25,12 @@ import (
 
 var dvSwitchVersions = []string{"7.0.0", "7.0.2", "7.0.3"}
 
+// bringupPollInterval is the interval between polls of the status of a bring-up.
+var bringupPollInterval = 20 * time.Second
+
+// bringupValidationPollInterval is the interval between polls of the status of a bring-up validation.
+var bringupValidationPollInterval = 10 * time.Second
+
 func ResourceVcfInstance() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVcfInstanceCreate,
@@ -33,11 +40,73 @@ func ResourceVcfInstance() *schema.Resource {
 		Timeouts: &schema.ResourceTimeout{
 			Create: schema.DefaultTimeout(5 * time.Hour),
 		},
+		CustomizeDiff: customdiff.All(
+			validateVcfInstanceNtpAndDnsServers,
+			validateVcfInstanceNsxIpAddresses,
+			validateVcfInstanceNsxDvsMtu,
+			validateVcfInstancePrimaryDatastore,
+			validateVcfInstanceSecurity,
+		),
 		Schema: resourceVcfInstanceSchema(),
 	}
 }
 
+func validateVcfInstanceNtpAndDnsServers(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if diff.NewValueKnown("ntp_servers") {
+		if _, errs := validation_utils.ValidateNtpServersSchema(diff.Get("ntp_servers"), "ntp_servers"); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	if diff.NewValueKnown("dns.0.name_server") && diff.NewValueKnown("dns.0.secondary_name_server") {
+		var dnsServers []string
+		for _, attribute := range []string{"dns.0.name_server", "dns.0.secondary_name_server"} {
+			if dnsServer := diff.Get(attribute).(string); dnsServer != "" {
+				dnsServers = append(dnsServers, dnsServer)
+			}
+		}
+		if _, errs := validation_utils.ValidateDnsServersSchema(dnsServers, "dns"); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+	return nil
+}
+
+func validateVcfInstanceNsxIpAddresses(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("nsx") || !diff.NewValueKnown("network") {
+		return nil
+	}
+	nsxSpec := sddc.GetNsxSpecFromSchema(diff.Get("nsx").([]interface{}))
+	networkSpecs := sddc.GetNetworkSpecsBindingFromSchema(diff.Get("network").([]interface{}))
+	return sddc.ValidateNsxIpsOutsideNetworkRanges(nsxSpec, networkSpecs)
+}
+
+func validateVcfInstanceNsxDvsMtu(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("dvs") {
+		return nil
+	}
+	return sddc.ValidateNsxDvsMtu(sddc.GetDvsSpecsFromSchema(diff.Get("dvs").([]interface{})))
+}
+
+func validateVcfInstancePrimaryDatastore(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("vsan") || !diff.NewValueKnown("vx_manager") {
+		return nil
+	}
+	return sddc.ValidateManagementPrimaryDatastore(diff.Get("vsan").([]interface{}), diff.Get("vx_manager").([]interface{}))
+}
+
+func validateVcfInstanceSecurity(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("security") {
+		return nil
+	}
+	return sddc.ValidateSecuritySpec(diff.Get("security").([]interface{}))
+}
+
 // TODO add support for "subscriptionLicensing" property in future releases.
+// TODO support the time zone and locale of the deployed appliances. Neither SDDCSpec nor the
+// vCenter, NSX and SDDC Manager specs in the VCF API accept these settings.
+// TODO support deploying the vCenter, NSX and NSX Edge appliances from a content library or datastore
+// location in disconnected sites. The VCF API always deploys them from the images of the depot bundles
+// and has no property referencing an alternative OVA source, neither at bring-up nor for workload domains.
 func resourceVcfInstanceSchema() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"instance_id": {
@@ -125,6 +194,14 @@ func resourceVcfInstanceSchema() map[string]*schema.Schema {
 			Optional: true,
 			Default:  "workflowconfig/workflowspec-ems.json",
 		},
+		"validate_only": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+			ForceNew: true,
+			Description: "Only validate the SDDC specification with Cloud Builder, without starting the bring-up. Failed " +
+				"validation checks are reported as errors. Setting it to false afterwards starts the bring-up",
+		},
 		"vcenter":    sddc.GetVcenterSchema(),
 		"vsan":       sddc.GetVsanSchema(),
 		"vx_manager": sddc.GetVxManagerSchema(),
@@ -206,6 +283,15 @@ func resourceVcfInstanceCreate(ctx context.Context, data *schema.ResourceData, m
 
 	sddcSpec := buildSddcSpec(data)
 
+	if data.Get("validate_only").(bool) {
+		validationId, diags := validateBringupSpec(ctx, client, sddcSpec)
+		if diags != nil {
+			return diags
+		}
+		data.SetId(validationId)
+		return nil
+	}
+
 	bringUpInfo, err := getLastBringUp(ctx, client)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
@@ -228,11 +314,21 @@ func resourceVcfInstanceCreate(ctx context.Context, data *schema.ResourceData, m
 func resourceVcfInstanceRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*api_client.CloudBuilderClient)
 
+	// a validated SDDC specification has no bring-up to read
+	if data.Get("validate_only").(bool) {
+		return nil
+	}
+
 	bringUpInfo, err := getLastBringUp(ctx, client)
 	if err != nil {
 		tflog.Error(ctx, err.Error())
 		return diag.FromErr(err)
 	}
+	if bringUpInfo == nil {
+		tflog.Warn(ctx, "No bring-up found in Cloud Builder, removing the instance from the state")
+		data.SetId("")
+		return nil
+	}
 	bringupId := bringUpInfo.ID
 
 	data.SetId(bringupId)
@@ -249,6 +345,8 @@ func resourceVcfInstanceRead(ctx context.Context, data *schema.ResourceData, met
 	_ = data.Set("sddc_manager_id", sddcManagerInfo.ID)
 	_ = data.Set("sddc_manager_version", sddcManagerInfo.Version)
 
+	// TODO flatten the resource pools of the management cluster once they can be read. Neither the
+	// bring-up API nor the cluster inventory of SDDC Manager return the resource pool layout.
 	return nil
 }
 func resourceVcfInstanceUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -260,14 +358,21 @@ func resourceVcfInstanceDelete(_ context.Context, _ *schema.ResourceData, _ inte
 	return nil
 }
 
+// invokeBringupWorkflow starts the bring-up, or resumes the last bring-up from its failed task if it
+// failed. A bring-up that is still running, e.g. after Terraform was interrupted, is waited for.
 func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec, lastBringup *models.SDDCTask) (string, diag.Diagnostics) {
 	var bringUpID string
+	if lastBringup != nil && lastBringup.Status == "IN_PROGRESS" {
+		tflog.Info(ctx, fmt.Sprintf("Bring-Up workflow with ID %s is in progress, waiting for it", lastBringup.ID))
+		return lastBringup.ID, nil
+	}
 	if lastBringup != nil && lastBringup.Status != "COMPLETED_WITH_SUCCESS" {
 		bringUpID = lastBringup.ID
-		diags := validateBringupSpec(ctx, client, sddcSpec)
+		_, diags := validateBringupSpec(ctx, client, sddcSpec)
 		if diags != nil {
 			return bringUpID, diags
 		}
+		tflog.Info(ctx, fmt.Sprintf("Resuming the failed Bring-Up workflow with ID %s", bringUpID))
 
 		retryBringupParams := sddc_api.NewRetrySDDCParamsWithContext(ctx).
 			WithTimeout(constants.DefaultVcfApiCallTimeout).WithID(bringUpID).WithSDDCSpec(sddcSpec)
@@ -282,7 +387,7 @@ func invokeBringupWorkflow(ctx context.Context, client *api_client.CloudBuilderC
 			return "", diag.FromErr(err)
 		}
 	} else {
-		diags := validateBringupSpec(ctx, client, sddcSpec)
+		_, diags := validateBringupSpec(ctx, client, sddcSpec)
 		if diags != nil {
 			return bringUpID, diags
 		}
@@ -314,7 +419,9 @@ func waitForBringupProcess(ctx context.Context, bringUpID string, client *api_cl
 		}
 
 		if task.Status == "IN_PROGRESS" {
-			time.Sleep(20 * time.Second)
+			if err = waitForBringupPoll(ctx, "bring-up "+bringUpID, bringupPollInterval); err != nil {
+				return diag.FromErr(err)
+			}
 			continue
 		}
 
@@ -329,6 +436,18 @@ func waitForBringupProcess(ctx context.Context, bringUpID string, client *api_cl
 	}
 }
 
+// waitForBringupPoll waits for the next poll of a bring-up operation. If the wait is interrupted, e.g.
+// because the create timeout has elapsed, the operation keeps running in Cloud Builder.
+func waitForBringupPoll(ctx context.Context, operation string, interval time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("stopped waiting for %s, which keeps running in Cloud Builder. Raise the create timeout "+
+			"or apply again to resume waiting: %w", operation, ctx.Err())
+	case <-time.After(interval):
+		return nil
+	}
+}
+
 func getLastBringUp(ctx context.Context, client *api_client.CloudBuilderClient) (*models.SDDCTask, error) {
 	retrieveAllSddcsResp, err := client.ApiClient.SDDC.RetrieveAllSddcs(
 		sddc_api.NewRetrieveAllSddcsParamsWithTimeout(constants.DefaultVcfApiCallTimeout).WithContext(ctx))
@@ -341,7 +460,8 @@ func getLastBringUp(ctx context.Context, client *api_client.CloudBuilderClient)
 	return nil, nil
 }
 
-func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec) diag.Diagnostics {
+// validateBringupSpec validates the SDDC specification with Cloud Builder and returns the ID of the validation.
+func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderClient, sddcSpec *models.SDDCSpec) (string, diag.Diagnostics) {
 	validateSddcSpec := sddc_api.NewValidateSDDCSpecParams().WithContext(ctx).
 		WithTimeout(constants.DefaultVcfApiCallTimeout).WithSDDCSpec(sddcSpec).WithRedo(utils.ToBoolPointer(true))
 
@@ -354,10 +474,10 @@ func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderCli
 		validationResponse = acceptedResponse.Payload
 	}
 	if err != nil {
-		return validation_utils.ConvertVcfErrorToDiag(err)
+		return "", validation_utils.ConvertVcfErrorToDiag(err)
 	}
 	if validation_utils.HasValidationFailed(validationResponse) {
-		return validation_utils.ConvertValidationResultToDiag(validationResponse)
+		return "", validation_utils.ConvertValidationResultToDiag(validationResponse)
 	}
 	validationId := validationResponse.ID
 	for {
@@ -366,22 +486,24 @@ func validateBringupSpec(ctx context.Context, client *api_client.CloudBuilderCli
 		getSddcValidationParams.SetID(validationId)
 		getValidationResponse, err := client.ApiClient.SDDC.GetSDDCValidation(getSddcValidationParams)
 		if err != nil {
-			return validation_utils.ConvertVcfErrorToDiag(err)
+			return "", validation_utils.ConvertVcfErrorToDiag(err)
 		}
 		validationResponse = getValidationResponse.Payload
 		if validation_utils.HaveValidationChecksFinished(validationResponse.ValidationChecks) {
 			break
 		}
-		time.Sleep(10 * time.Second)
+		if err = waitForBringupPoll(ctx, "validation "+validationId, bringupValidationPollInterval); err != nil {
+			return "", diag.FromErr(err)
+		}
 	}
 	if err != nil {
-		return validation_utils.ConvertVcfErrorToDiag(err)
+		return "", validation_utils.ConvertVcfErrorToDiag(err)
 	}
 	if validation_utils.HasValidationFailed(validationResponse) {
-		return validation_utils.ConvertValidationResultToDiag(validationResponse)
+		return "", validation_utils.ConvertValidationResultToDiag(validationResponse)
 	}
 
-	return nil
+	return validationId, nil
 }
 
 func getBringUp(ctx context.Context, bringupId string, client *api_client.CloudBuilderClient) (*models.SDDCTask, error) {
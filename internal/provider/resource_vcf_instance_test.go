@@ -15,8 +15,11 @@ import (
 	"github.com/vmware/terraform-provider-vcf/internal/api_client"
 	"github.com/vmware/terraform-provider-vcf/internal/constants"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
+	"github.com/vmware/terraform-provider-vcf/internal/sddc"
+	"github.com/vmware/vcf-sdk-go/models"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestAccResourceVcfSddcBasic(t *testing.T) {
@@ -92,7 +95,7 @@ func testAccCheckVcfSddcConfigBasic() string {
 	  dns {
 		domain = "vrack.vsphere.local"
 		name_server = "10.0.0.250"
-		secondary_name_server = "10.0.0.250"
+		secondary_name_server = "10.0.0.251"
 	  }
 	  network {
 		subnet = "10.0.0.0/22"
@@ -333,7 +336,7 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 			map[string]interface{}{
 				"domain":                "vsphere.local",
 				"name_server":           "10.0.0.250",
-				"secondary_name_server": "10.0.0.250",
+				"secondary_name_server": "10.0.0.251",
 			},
 		},
 		"network": []interface{}{
@@ -495,7 +498,7 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 	assert.Equal(t, *sddcSpec.DNSSpec.Domain, "vsphere.local")
 	assert.Equal(t, *sddcSpec.DNSSpec.Domain, "vsphere.local")
 	assert.Equal(t, sddcSpec.DNSSpec.Nameserver, "10.0.0.250")
-	assert.Equal(t, sddcSpec.DNSSpec.SecondaryNameserver, "10.0.0.250")
+	assert.Equal(t, sddcSpec.DNSSpec.SecondaryNameserver, "10.0.0.251")
 	assert.Equal(t, *sddcSpec.NetworkSpecs[0].VlanID, "0")
 	assert.Equal(t, sddcSpec.NetworkSpecs[0].Mtu, "8940")
 	assert.Equal(t, *sddcSpec.NetworkSpecs[0].NetworkType, "VSAN")
@@ -562,3 +565,108 @@ func TestVcfInstanceSchemaParse(t *testing.T) {
 	assert.Equal(t, sddcSpec.HostSpecs[0].IPAddressPrivate.Cidr, "")
 	assert.Equal(t, sddcSpec.HostSpecs[0].IPAddressPrivate.Gateway, "10.0.0.250")
 }
+
+func TestVcfInstanceNsxDvsMtu(t *testing.T) {
+	dvsSpecs := []*models.DvsSpec{
+		{DvsName: utils.ToStringPointer("SDDC-Dswitch-Private"), IsUsedByNSXT: true, Mtu: 8940},
+		{DvsName: utils.ToStringPointer("SDDC-Dswitch-External"), Mtu: 1500},
+	}
+	assert.NoError(t, sddc.ValidateNsxDvsMtu(dvsSpecs))
+
+	dvsSpecs[0].Mtu = 0
+	assert.NoError(t, sddc.ValidateNsxDvsMtu(dvsSpecs))
+
+	dvsSpecs[0].Mtu = 1500
+	assert.ErrorContains(t, sddc.ValidateNsxDvsMtu(dvsSpecs), "mtu 1500 of DVS SDDC-Dswitch-Private is lower than 1600")
+}
+
+func TestVcfInstanceDnsServers(t *testing.T) {
+	dnsResource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"ntp_servers": resourceVcfInstanceSchema()["ntp_servers"],
+			"dns":         resourceVcfInstanceSchema()["dns"],
+		},
+		CustomizeDiff: validateVcfInstanceNtpAndDnsServers,
+	}
+	newConfig := func(secondaryNameServer string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"ntp_servers": []interface{}{"10.0.0.250"},
+			"dns": []interface{}{
+				map[string]interface{}{
+					"domain":                "vrack.vsphere.local",
+					"name_server":           "10.0.0.250",
+					"secondary_name_server": secondaryNameServer,
+				},
+			},
+		})
+	}
+
+	_, err := dnsResource.Diff(context.Background(), nil, newConfig("10.0.0.251"), nil)
+	assert.NoError(t, err)
+
+	_, err = dnsResource.Diff(context.Background(), nil, newConfig("10.0.0.250"), nil)
+	assert.ErrorContains(t, err, "contains duplicate DNS server \"10.0.0.250\"")
+}
+
+func TestVcfInstanceNsxIpOverlap(t *testing.T) {
+	networkSpecs := []*models.SDDCNetworkSpec{
+		{
+			NetworkType:      utils.ToStringPointer("VSAN"),
+			IncludeIPAddress: []string{"10.0.4.50"},
+			IncludeIPAddressRanges: []*models.IPRange{
+				{
+					StartIPAddress: utils.ToStringPointer("10.0.4.7"),
+					EndIPAddress:   utils.ToStringPointer("10.0.4.48"),
+				},
+			},
+		},
+	}
+
+	nsxSpec := &models.SDDCNSXTSpec{
+		Vip:          utils.ToStringPointer("10.0.0.30"),
+		NSXTManagers: []*models.NSXTManagerSpec{{Hostname: "nsx-mgmt-1", IP: "10.0.0.31"}},
+	}
+	assert.NoError(t, sddc.ValidateNsxIpsOutsideNetworkRanges(nsxSpec, networkSpecs))
+
+	nsxSpec.Vip = utils.ToStringPointer("10.0.4.48")
+	assert.ErrorContains(t, sddc.ValidateNsxIpsOutsideNetworkRanges(nsxSpec, networkSpecs), "NSX VIP")
+
+	nsxSpec.Vip = utils.ToStringPointer("10.0.0.30")
+	nsxSpec.NSXTManagers[0].IP = "10.0.4.50"
+	assert.ErrorContains(t, sddc.ValidateNsxIpsOutsideNetworkRanges(nsxSpec, networkSpecs), "nsx-mgmt-1")
+}
+
+func TestVcfInstancePrimaryDatastore(t *testing.T) {
+	vsan := []interface{}{map[string]interface{}{"datastore_name": "sfo01-m01-vsan"}}
+	vxManager := []interface{}{map[string]interface{}{"vx_manager_hostname": "vxrail-1"}}
+
+	assert.NoError(t, sddc.ValidateManagementPrimaryDatastore(vsan, nil))
+	assert.NoError(t, sddc.ValidateManagementPrimaryDatastore(nil, vxManager))
+	assert.ErrorContains(t, sddc.ValidateManagementPrimaryDatastore(nil, nil), "must be vSAN")
+}
+
+func TestVcfInstanceSecurity(t *testing.T) {
+	security := func(esxiCertsMode string, certChain ...interface{}) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"esxi_certs_mode": esxiCertsMode,
+			"root_ca_certs": []interface{}{
+				map[string]interface{}{"alias": "root-ca", "cert_chain": certChain},
+			},
+		}}
+	}
+
+	assert.NoError(t, sddc.ValidateSecuritySpec(nil))
+	assert.NoError(t, sddc.ValidateSecuritySpec(security("VMCA")))
+	assert.NoError(t, sddc.ValidateSecuritySpec(security("Custom", "MIIDXTCCAkWgAwIBAgIJAJC1HiIAZAiIMA0G")))
+	assert.ErrorContains(t, sddc.ValidateSecuritySpec(security("Custom")), "root_ca_certs")
+}
+
+func TestWaitForBringupPoll(t *testing.T) {
+	assert.NoError(t, waitForBringupPoll(context.Background(), "bring-up 1", time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := waitForBringupPoll(ctx, "bring-up 1", time.Hour)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.ErrorContains(t, err, "keeps running in Cloud Builder")
+}
@@ -5,6 +5,8 @@
 
 package resource_utils
 
+import "reflect"
+
 func ToBoolPointer(object interface{}) *bool {
 	if object == nil {
 		return nil
@@ -80,3 +82,109 @@ func CalculateAddedRemovedResources(newResourcesList, oldResourcesList []interfa
 
 	return addedResources, removedResources
 }
+
+// MergeReadValue merges the value of an attribute read from the VCF API into its value in the state, so
+// that out-of-band changes show up in a plan. Attributes the VCF API does not return are kept from the
+// state. Blocks in lists are matched by their "id", "name" or "type" and keep the order of the state,
+// the blocks only read are appended and the blocks that were not read are dropped. Single blocks and
+// blocks without such an attribute are matched by their position.
+func MergeReadValue(stateValue, readValue interface{}) interface{} {
+	switch readTyped := normalizeReadValue(readValue).(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{})
+		stateMap, _ := stateValue.(map[string]interface{})
+		for key, value := range stateMap {
+			result[key] = value
+		}
+		for key, value := range readTyped {
+			result[key] = MergeReadValue(stateMap[key], value)
+		}
+		return result
+	case []interface{}:
+		stateList, _ := stateValue.([]interface{})
+		if len(stateList) == 1 && len(readTyped) == 1 {
+			return []interface{}{MergeReadValue(stateList[0], readTyped[0])}
+		}
+		readBlocksByKey := make(map[string]interface{})
+		var readKeys []string
+		for _, readEntry := range readTyped {
+			key, ok := blockKey(readEntry)
+			if !ok {
+				return mergeListByPosition(stateList, readTyped)
+			}
+			readBlocksByKey[key] = readEntry
+			readKeys = append(readKeys, key)
+		}
+		result := make([]interface{}, 0, len(readTyped))
+		merged := make(map[string]bool)
+		for _, stateEntry := range stateList {
+			key, ok := blockKey(stateEntry)
+			if readEntry, read := readBlocksByKey[key]; ok && read && !merged[key] {
+				result = append(result, MergeReadValue(stateEntry, readEntry))
+				merged[key] = true
+			}
+		}
+		for _, key := range readKeys {
+			if !merged[key] {
+				result = append(result, MergeReadValue(nil, readBlocksByKey[key]))
+				merged[key] = true
+			}
+		}
+		return result
+	default:
+		return readTyped
+	}
+}
+
+func mergeListByPosition(stateList, readList []interface{}) []interface{} {
+	result := make([]interface{}, len(readList))
+	for i, readEntry := range readList {
+		var stateEntry interface{}
+		if i < len(stateList) {
+			stateEntry = stateList[i]
+		}
+		result[i] = MergeReadValue(stateEntry, readEntry)
+	}
+	return result
+}
+
+// blockKey returns the value identifying a block in a list, its "id", "name" or "type".
+func blockKey(block interface{}) (string, bool) {
+	blockMap, ok := block.(map[string]interface{})
+	if !ok {
+		return "", false
+	}
+	for _, attribute := range []string{"id", "name", "type"} {
+		if key, ok := blockMap[attribute].(string); ok && len(key) > 0 {
+			return attribute + "=" + key, true
+		}
+	}
+	return "", false
+}
+
+// normalizeReadValue converts the typed slices and maps of flattened values, e.g. []map[string]interface{}
+// or []string, to the []interface{} and map[string]interface{} the state holds.
+func normalizeReadValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case nil, []interface{}, map[string]interface{}:
+		return value
+	case *map[string]interface{}:
+		return *typed
+	}
+	reflectValue := reflect.ValueOf(value)
+	switch reflectValue.Kind() {
+	case reflect.Slice:
+		result := make([]interface{}, reflectValue.Len())
+		for i := range result {
+			result[i] = reflectValue.Index(i).Interface()
+		}
+		return result
+	case reflect.Map:
+		result := make(map[string]interface{}, reflectValue.Len())
+		for _, key := range reflectValue.MapKeys() {
+			result[key.String()] = reflectValue.MapIndex(key).Interface()
+		}
+		return result
+	}
+	return value
+}
@@ -0,0 +1,62 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package resource_utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeReadValue(t *testing.T) {
+	stateCluster := map[string]interface{}{
+		"id":       "cluster-1",
+		"name":     "sfo-w01-cl01",
+		"evc_mode": "INTEL_CASCADELAKE",
+		"host": []interface{}{
+			map[string]interface{}{"id": "host-2", "license_key": "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX"},
+			map[string]interface{}{"id": "host-1", "license_key": "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX"},
+			map[string]interface{}{"id": "host-3", "license_key": "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX"},
+		},
+		"vsan_datastore": []interface{}{
+			map[string]interface{}{"datastore_name": "sfo-w01-cl01-ds-vsan01", "failures_to_tolerate": 1},
+		},
+	}
+	readCluster := map[string]interface{}{
+		"id":   "cluster-1",
+		"name": "sfo-w01-cl01",
+		"host": []map[string]interface{}{
+			{"id": "host-1", "host_name": "sfo01-w01-esx01.sfo.rainpole.io"},
+			{"id": "host-2", "host_name": "sfo01-w01-esx02.sfo.rainpole.io"},
+			{"id": "host-4", "host_name": "sfo01-w01-esx04.sfo.rainpole.io"},
+		},
+		"vsan_datastore": []map[string]interface{}{{"datastore_name": "sfo-w01-cl01-ds-vsan02"}},
+	}
+
+	expectedCluster := map[string]interface{}{
+		"id":       "cluster-1",
+		"name":     "sfo-w01-cl01",
+		"evc_mode": "INTEL_CASCADELAKE",
+		"host": []interface{}{
+			map[string]interface{}{"id": "host-2", "host_name": "sfo01-w01-esx02.sfo.rainpole.io",
+				"license_key": "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX"},
+			map[string]interface{}{"id": "host-1", "host_name": "sfo01-w01-esx01.sfo.rainpole.io",
+				"license_key": "XX0XX-XX0XX-XX0XX-XX0XX-XX0XX"},
+			map[string]interface{}{"id": "host-4", "host_name": "sfo01-w01-esx04.sfo.rainpole.io"},
+		},
+		"vsan_datastore": []interface{}{
+			map[string]interface{}{"datastore_name": "sfo-w01-cl01-ds-vsan02", "failures_to_tolerate": 1},
+		},
+	}
+	if mergedCluster := MergeReadValue(stateCluster, readCluster); !reflect.DeepEqual(mergedCluster, expectedCluster) {
+		t.Errorf("unexpected merged cluster %v, expected %v", mergedCluster, expectedCluster)
+	}
+
+	stateNames := []interface{}{"sfo-w01-cl01-fc01", "sfo-w01-cl01-fc02"}
+	readNames := []string{"sfo-w01-cl01-fc02"}
+	if mergedNames := MergeReadValue(stateNames, readNames); !reflect.DeepEqual(mergedNames, []interface{}{"sfo-w01-cl01-fc02"}) {
+		t.Errorf("expected the read datastore names, got %v", mergedNames)
+	}
+}
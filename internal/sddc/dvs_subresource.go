@@ -32,7 +32,7 @@ func GetDvsSchema() *schema.Schema {
 				},
 				"mtu": {
 					Type:         schema.TypeInt,
-					Description:  "DVS MTU (default value is 9000). In between 1500 and 9000",
+					Description:  "DVS MTU (default value is 9000). In between 1500 and 9000, at least 1600 for the DVS used by NSX to carry its Geneve encapsulated overlay traffic",
 					Optional:     true,
 					Default:      9000,
 					ValidateFunc: validation.IntBetween(1500, 9000),
@@ -35,6 +35,8 @@ func GetPscSchema() *schema.Schema {
 	}
 }
 
+// TODO support the SSO password policy (length, lockout and expiry). PscSpec and the SSO specs of the
+// VCF API do not accept a password policy, it has to be configured in vCenter after deployment.
 func GetPscSpecsFromSchema(rawData []interface{}) []*models.PscSpec {
 	var pscSpecsBindingsList []*models.PscSpec
 	for _, pscSpec := range rawData {
@@ -6,14 +6,17 @@
 package sddc
 
 import (
+	"fmt"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validation2 "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/vcf-sdk-go/models"
+	"sort"
 )
 
-var sharesLevelValues = []string{"custom", "high", "low", "normal"}
+var SharesLevelValues = []string{"custom", "high", "low", "normal"}
 var resourcePoolTypeValues = []string{"management", "compute", "network"}
 
 func GetSddcClusterSchema() *schema.Schema {
@@ -101,7 +104,7 @@ func getResourcePoolSchema() *schema.Schema {
 					Description:  "CPU shares level, default 'normal', possible values: \"custom\", \"high\", \"low\", \"normal\"",
 					Optional:     true,
 					Default:      "normal",
-					ValidateFunc: validation.StringInSlice(sharesLevelValues, false),
+					ValidateFunc: validation.StringInSlice(SharesLevelValues, false),
 				},
 				"cpu_shares_value": {
 					Type:        schema.TypeInt,
@@ -140,7 +143,7 @@ func getResourcePoolSchema() *schema.Schema {
 					Description:  "Memory shares level, default 'normal', possible values: \"custom\", \"high\", \"low\", \"normal\"",
 					Optional:     true,
 					Default:      "normal",
-					ValidateFunc: validation.StringInSlice(sharesLevelValues, false),
+					ValidateFunc: validation.StringInSlice(SharesLevelValues, false),
 				},
 				"memory_shares_value": {
 					Type:        schema.TypeInt,
@@ -153,6 +156,64 @@ func getResourcePoolSchema() *schema.Schema {
 	}
 }
 
+// GetComputedResourcePoolSchema returns a read-only variant of the resource_pool schema, so that
+// generated resource pools can be consumed by the resource_pool blocks of a cluster.
+func GetComputedResourcePoolSchema() *schema.Schema {
+	resourcePoolSchema := getResourcePoolSchema().Elem.(*schema.Resource).Schema
+	computedSchema := make(map[string]*schema.Schema, len(resourcePoolSchema))
+	for key, attributeSchema := range resourcePoolSchema {
+		computedSchema[key] = &schema.Schema{
+			Type:        attributeSchema.Type,
+			Description: attributeSchema.Description,
+			Computed:    true,
+		}
+	}
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: computedSchema,
+		},
+	}
+}
+
+// ValidateResourcePools applies the validations of the resource_pool schema to the provided resource pools
+// and ensures they result in valid ResourcePoolSpecs.
+func ValidateResourcePools(rawData []interface{}) error {
+	resourcePoolSchema := getResourcePoolSchema().Elem.(*schema.Resource).Schema
+	var attributeNames []string
+	for key := range resourcePoolSchema {
+		attributeNames = append(attributeNames, key)
+	}
+	sort.Strings(attributeNames)
+
+	resourcePoolNames := make(map[string]bool)
+	for i, resourcePool := range rawData {
+		data := resourcePool.(map[string]interface{})
+		for _, attributeName := range attributeNames {
+			validateFunc := resourcePoolSchema[attributeName].ValidateFunc
+			if validateFunc == nil {
+				continue
+			}
+			if _, errs := validateFunc(data[attributeName], fmt.Sprintf("resource_pool.%d.%s", i, attributeName)); len(errs) > 0 {
+				return errs[0]
+			}
+		}
+		name := data["name"].(string)
+		if resourcePoolNames[name] {
+			return fmt.Errorf("duplicate resource pool name %q", name)
+		}
+		resourcePoolNames[name] = true
+	}
+
+	for _, resourcePoolSpec := range getResourcePoolSpecsFromSchema(rawData) {
+		if err := resourcePoolSpec.Validate(strfmt.Default); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetSddcClusterSpecFromSchema(rawData []interface{}) *models.SDDCClusterSpec {
 	if len(rawData) <= 0 {
 		return nil
@@ -12,6 +12,9 @@ import (
 	"github.com/vmware/vcf-sdk-go/models"
 )
 
+// TODO support relaxing the minimum host count of the management cluster for lab deployments.
+// The minimum is enforced by the bring-up validation of Cloud Builder and SDDCSpec has no property
+// to override it or the related vSAN storage policy assumptions.
 func GetSddcHostSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
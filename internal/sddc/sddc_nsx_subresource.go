@@ -6,14 +6,19 @@
 package sddc
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/vmware/terraform-provider-vcf/internal/network"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	validation_utils "github.com/vmware/terraform-provider-vcf/internal/validation"
 	"github.com/vmware/vcf-sdk-go/models"
+	"net/netip"
 )
 
+// geneveMinMtu is the minimum MTU required to carry Geneve encapsulated overlay traffic.
+const geneveMinMtu = 1600
+
 func GetNsxSpecSchema() *schema.Schema {
 	return &schema.Schema{
 		Type:     schema.TypeList,
@@ -23,7 +28,7 @@ func GetNsxSpecSchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"vip": {
 					Type:        schema.TypeString,
-					Description: "Virtual IP address which would act as proxy/alias for NSX Managers",
+					Description: "Virtual IP address which would act as proxy/alias for NSX Managers. Must not overlap with the IP addresses included in the network specs",
 					Required:    true,
 				},
 				"vip_fqdn": {
@@ -98,7 +103,7 @@ func getNsxManagerSpecSchema() *schema.Schema {
 				},
 				"ip": {
 					Type:         schema.TypeString,
-					Description:  "NSX Manager IPv4 Address",
+					Description:  "NSX Manager IPv4 Address. Must not overlap with the IP addresses included in the network specs",
 					Optional:     true,
 					ValidateFunc: validation.IsIPAddress,
 				},
@@ -193,3 +198,71 @@ func getTransportZoneFromSchema(rawData []interface{}) *models.NSXTTransportZone
 	}
 	return transportZoneBinding
 }
+
+// ValidateNsxDvsMtu ensures that each DVS used by NSX, which backs the host switch of the transport
+// node profile and carries the Geneve encapsulated overlay traffic, has an MTU of at least 1600. The
+// VCF API has no MTU for the transport zones themselves.
+func ValidateNsxDvsMtu(dvsSpecs []*models.DvsSpec) error {
+	for _, dvsSpec := range dvsSpecs {
+		if dvsSpec.IsUsedByNSXT && dvsSpec.Mtu > 0 && dvsSpec.Mtu < geneveMinMtu {
+			return fmt.Errorf("mtu %d of DVS %s is lower than %d, the minimum MTU of the Geneve encapsulated "+
+				"overlay traffic of NSX", dvsSpec.Mtu, *dvsSpec.DvsName, geneveMinMtu)
+		}
+	}
+	return nil
+}
+
+// ValidateNsxIpsOutsideNetworkRanges ensures that neither the NSX Manager cluster VIP nor the NSX Manager
+// node IPs fall within the IP ranges handed out to the hosts by the network pools, since such conflicts
+// would otherwise only surface during bring-up.
+func ValidateNsxIpsOutsideNetworkRanges(nsxSpec *models.SDDCNSXTSpec, networkSpecs []*models.SDDCNetworkSpec) error {
+	if nsxSpec == nil {
+		return nil
+	}
+	var nsxIps, owners []string
+	if nsxSpec.Vip != nil && *nsxSpec.Vip != "" {
+		nsxIps = append(nsxIps, *nsxSpec.Vip)
+		owners = append(owners, "NSX VIP")
+	}
+	for _, nsxManager := range nsxSpec.NSXTManagers {
+		if nsxManager.IP != "" {
+			nsxIps = append(nsxIps, nsxManager.IP)
+			owners = append(owners, fmt.Sprintf("NSX Manager %s", nsxManager.Hostname))
+		}
+	}
+
+	for i, nsxIp := range nsxIps {
+		owner := owners[i]
+		address, err := netip.ParseAddr(nsxIp)
+		if err != nil {
+			return fmt.Errorf("invalid IP address %q of %s", nsxIp, owner)
+		}
+		for _, networkSpec := range networkSpecs {
+			networkType := ""
+			if networkSpec.NetworkType != nil {
+				networkType = *networkSpec.NetworkType
+			}
+			for _, includedIp := range networkSpec.IncludeIPAddress {
+				if includedAddress, err := netip.ParseAddr(includedIp); err == nil && includedAddress == address {
+					return fmt.Errorf("IP address %s of %s is included in the IP addresses of network %s",
+						nsxIp, owner, networkType)
+				}
+			}
+			for _, ipRange := range networkSpec.IncludeIPAddressRanges {
+				if ipRange.StartIPAddress == nil || ipRange.EndIPAddress == nil {
+					continue
+				}
+				start, startErr := netip.ParseAddr(*ipRange.StartIPAddress)
+				end, endErr := netip.ParseAddr(*ipRange.EndIPAddress)
+				if startErr != nil || endErr != nil {
+					continue
+				}
+				if address.Compare(start) >= 0 && address.Compare(end) <= 0 {
+					return fmt.Errorf("IP address %s of %s overlaps with the IP range %s-%s of network %s",
+						nsxIp, owner, *ipRange.StartIPAddress, *ipRange.EndIPAddress, networkType)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@
 package sddc
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
@@ -23,7 +24,7 @@ func GetSecuritySchema() *schema.Schema {
 			Schema: map[string]*schema.Schema{
 				"esxi_certs_mode": {
 					Type:         schema.TypeString,
-					Description:  "ESXi certificates mode. One among: Custom, VMCA",
+					Description:  "ESXi certificates mode. One among: Custom, VMCA. Custom requires the certificate chain of the issuing CA in root_ca_certs",
 					Optional:     true,
 					ValidateFunc: validation.StringInSlice(esxiCertsModes, false),
 				},
@@ -92,3 +93,18 @@ func getRootCaCertsBindingFromSchema(rawData []interface{}) []*models.RootCaCert
 	}
 	return rootCaCertsBindingsList
 }
+
+// ValidateSecuritySpec ensures the certificate chain of the CA issuing the ESXi certificates
+// is provided when the hosts use custom certificates.
+func ValidateSecuritySpec(rawData []interface{}) error {
+	securitySpec := GetSecuritySpecSchema(rawData)
+	if securitySpec == nil || securitySpec.EsxiCertsMode != "Custom" {
+		return nil
+	}
+	for _, rootCaCerts := range securitySpec.RootCaCerts {
+		if len(rootCaCerts.CertChain) > 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("esxi_certs_mode Custom requires root_ca_certs with the certificate chain of the issuing CA")
+}
@@ -6,6 +6,7 @@
 package sddc
 
 import (
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	utils "github.com/vmware/terraform-provider-vcf/internal/resource_utils"
 	"github.com/vmware/vcf-sdk-go/models"
@@ -13,9 +14,10 @@ import (
 
 func GetVsanSchema() *schema.Schema {
 	return &schema.Schema{
-		Type:     schema.TypeList,
-		Optional: true,
-		MaxItems: 1,
+		Type:        schema.TypeList,
+		Description: "vSAN configuration of the management cluster. vSAN is the primary datastore of the management domain and is required unless the hosts are managed by VxRail Manager",
+		Optional:    true,
+		MaxItems:    1,
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
 				"datastore_name": {
@@ -61,3 +63,12 @@ func GetVsanSpecFromSchema(rawData []interface{}) *models.VSANSpec {
 	}
 	return vsanSpecBinding
 }
+
+// ValidateManagementPrimaryDatastore ensures vSAN is configured as the primary datastore of the management domain.
+// VxRail Manager configures vSAN on its own, in which case the vsan block is not required.
+func ValidateManagementPrimaryDatastore(vsanRawData, vxManagerRawData []interface{}) error {
+	if len(vsanRawData) > 0 || len(vxManagerRawData) > 0 {
+		return nil
+	}
+	return fmt.Errorf("vsan is required, the primary datastore of the management domain must be vSAN")
+}
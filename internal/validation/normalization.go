@@ -0,0 +1,47 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package validation
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/netip"
+	"strings"
+)
+
+// SDDC Manager stores FQDNs in lower case, license keys in upper case and IP addresses in their
+// canonical form, so the values it returns can differ from the configured ones in format only.
+
+// NormalizeFqdn returns the FQDN in lower case and without the trailing dot of the root zone.
+func NormalizeFqdn(fqdn string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(fqdn)), ".")
+}
+
+// NormalizeLicenseKey returns the license key in upper case.
+func NormalizeLicenseKey(licenseKey string) string {
+	return strings.ToUpper(strings.TrimSpace(licenseKey))
+}
+
+// NormalizeIpAddress returns the canonical form of the IP address, e.g. 2001:db8::1 for
+// 2001:0db8:0:0:0:0:0:1. Values that are no IP addresses are returned as they are.
+func NormalizeIpAddress(ipAddress string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ipAddress))
+	if err != nil {
+		return ipAddress
+	}
+	return addr.String()
+}
+
+func SuppressFqdnDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return NormalizeFqdn(oldValue) == NormalizeFqdn(newValue)
+}
+
+func SuppressLicenseKeyDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return NormalizeLicenseKey(oldValue) == NormalizeLicenseKey(newValue)
+}
+
+func SuppressIpAddressDiff(_, oldValue, newValue string, _ *schema.ResourceData) bool {
+	return NormalizeIpAddress(oldValue) == NormalizeIpAddress(newValue)
+}
@@ -0,0 +1,40 @@
+/*
+ *  Copyright 2023 VMware, Inc.
+ *    SPDX-License-Identifier: MPL-2.0
+ */
+
+package validation
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"testing"
+)
+
+func TestSuppressNormalizedDiffs(t *testing.T) {
+	t.Run("Suppress normalized diffs", func(t *testing.T) {
+		var suppressTests = []struct {
+			suppressFunc   schema.SchemaDiffSuppressFunc
+			oldValue       string
+			newValue       string
+			expectedResult bool
+		}{
+			{SuppressFqdnDiff, "sfo-w01-vc01.sfo.rainpole.io", "SFO-W01-VC01.sfo.rainpole.io", true},
+			{SuppressFqdnDiff, "sfo-w01-vc01.sfo.rainpole.io", "sfo-w01-vc01.sfo.rainpole.io.", true},
+			{SuppressFqdnDiff, "sfo-w01-vc01.sfo.rainpole.io", "sfo-w01-vc02.sfo.rainpole.io", false},
+			{SuppressLicenseKeyDiff, "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE", "aaaaa-bbbbb-ccccc-ddddd-eeeee", true},
+			{SuppressLicenseKeyDiff, "AAAAA-BBBBB-CCCCC-DDDDD-EEEEE", "AAAAA-BBBBB-CCCCC-DDDDD-FFFFF", false},
+			{SuppressIpAddressDiff, "2001:db8::1", "2001:0DB8:0:0:0:0:0:1", true},
+			{SuppressIpAddressDiff, "10.0.0.250", " 10.0.0.250", true},
+			{SuppressIpAddressDiff, "10.0.0.250", "10.0.0.251", false},
+			{SuppressIpAddressDiff, "random text", "RANDOM TEXT", false},
+		}
+
+		for _, suppressTest := range suppressTests {
+			result := suppressTest.suppressFunc("", suppressTest.oldValue, suppressTest.newValue, nil)
+			if suppressTest.expectedResult != result {
+				t.Errorf("failed. Expected %t for %q and %q, got %t", suppressTest.expectedResult,
+					suppressTest.oldValue, suppressTest.newValue, result)
+			}
+		}
+	})
+}
@@ -122,6 +122,76 @@ func ValidateIPv4AddressSchema(i interface{}, k string) (_ []string, errors []er
 	}
 }
 
+// ValidateNtpServersSchema validates a list of NTP servers, each of which must be
+// an IP address or an FQDN. Empty entries and duplicates are rejected.
+// The Plugin SDK does not support ValidateFunc on lists, call it from CustomizeDiff instead.
+func ValidateNtpServersSchema(i interface{}, k string) (_ []string, errors []error) {
+	return nil, validateServerList(i, k, "NTP")
+}
+
+// ValidateDnsServersSchema validates a list of DNS servers, each of which must be
+// an IP address or an FQDN. Empty entries and duplicates are rejected.
+// The Plugin SDK does not support ValidateFunc on lists, call it from CustomizeDiff instead.
+func ValidateDnsServersSchema(i interface{}, k string) (_ []string, errors []error) {
+	return nil, validateServerList(i, k, "DNS")
+}
+
+func validateServerList(i interface{}, k, serverType string) []error {
+	var servers []string
+	switch value := i.(type) {
+	case []string:
+		servers = value
+	case []interface{}:
+		for _, server := range value {
+			serverString, ok := server.(string)
+			if !ok {
+				return []error{fmt.Errorf("expected type of %s elements to be string", k)}
+			}
+			servers = append(servers, serverString)
+		}
+	default:
+		return []error{fmt.Errorf("expected type of %s to be a list of strings", k)}
+	}
+
+	var errors []error
+	seen := make(map[string]bool)
+	for _, server := range servers {
+		server = strings.TrimSpace(server)
+		if server == "" {
+			errors = append(errors, fmt.Errorf("%s contains an empty %s server", k, serverType))
+			continue
+		}
+		if _, err := netip.ParseAddr(server); err != nil && !isValidFqdn(server) {
+			errors = append(errors, fmt.Errorf("%s server %q in %s is neither an IP address nor an FQDN", serverType, server, k))
+			continue
+		}
+		if seen[strings.ToLower(server)] {
+			errors = append(errors, fmt.Errorf("%s contains duplicate %s server %q", k, serverType, server))
+			continue
+		}
+		seen[strings.ToLower(server)] = true
+	}
+	return errors
+}
+
+func isValidFqdn(value string) bool {
+	value = strings.TrimSuffix(value, ".")
+	if len(value) == 0 || len(value) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(value, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, char := range label {
+			if char > unicode.MaxASCII || (!unicode.IsLetter(char) && !unicode.IsDigit(char) && char != '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 func ConvertVcfErrorToDiag(err interface{}) diag.Diagnostics {
 	if err == nil {
 		return nil
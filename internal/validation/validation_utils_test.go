@@ -138,6 +138,74 @@ func TestValidateIpv4Address(t *testing.T) {
 	})
 }
 
+func TestValidateNtpServersSchema(t *testing.T) {
+	t.Run("Validate NTP servers", func(t *testing.T) {
+		var ntpServersTests = []struct {
+			servers     interface{}
+			expectedErr string
+		}{
+			{[]interface{}{"10.0.0.250", "ntp.vrack.vsphere.local"}, ""},
+			{[]string{"fd00::250"}, ""},
+			{[]interface{}{""}, "contains an empty NTP server"},
+			{[]interface{}{"10.0.0.250", "10.0.0.250"}, "contains duplicate NTP server"},
+			{[]interface{}{"NTP.local", "ntp.local"}, "contains duplicate NTP server"},
+			{[]interface{}{"-ntp.local"}, "is neither an IP address nor an FQDN"},
+			{[]interface{}{"ntp_1.local"}, "is neither an IP address nor an FQDN"},
+			{"10.0.0.250", "to be a list of strings"},
+		}
+
+		for _, ntpServersTest := range ntpServersTests {
+			_, err := ValidateNtpServersSchema(ntpServersTest.servers, "ntp_servers")
+			if ntpServersTest.expectedErr == "" {
+				if len(err) != 0 {
+					t.Errorf("failed. Expected no errors for %v, got: %s", ntpServersTest.servers, err[0].Error())
+				}
+				continue
+			}
+			if len(err) == 0 {
+				t.Errorf("failed. expected one error for %v, but got zero", ntpServersTest.servers)
+				continue
+			}
+			if !strings.Contains(err[0].Error(), ntpServersTest.expectedErr) {
+				t.Errorf("failed. Unexpected error for %v : %s, expected %s", ntpServersTest.servers, err[0].Error(), ntpServersTest.expectedErr)
+			}
+		}
+	})
+}
+
+func TestValidateDnsServersSchema(t *testing.T) {
+	t.Run("Validate DNS servers", func(t *testing.T) {
+		var dnsServersTests = []struct {
+			servers     interface{}
+			expectedErr string
+		}{
+			{[]interface{}{"10.0.0.250", "10.0.0.251"}, ""},
+			{[]interface{}{"dns.vrack.vsphere.local."}, ""},
+			{[]interface{}{"10.0.0.250", " "}, "contains an empty DNS server"},
+			{[]interface{}{"10.0.0.250", "10.0.0.250"}, "contains duplicate DNS server"},
+			{[]interface{}{"dns..local"}, "is neither an IP address nor an FQDN"},
+			{[]interface{}{10}, "elements to be string"},
+		}
+
+		for _, dnsServersTest := range dnsServersTests {
+			_, err := ValidateDnsServersSchema(dnsServersTest.servers, "dns")
+			if dnsServersTest.expectedErr == "" {
+				if len(err) != 0 {
+					t.Errorf("failed. Expected no errors for %v, got: %s", dnsServersTest.servers, err[0].Error())
+				}
+				continue
+			}
+			if len(err) == 0 {
+				t.Errorf("failed. expected one error for %v, but got zero", dnsServersTest.servers)
+				continue
+			}
+			if !strings.Contains(err[0].Error(), dnsServersTest.expectedErr) {
+				t.Errorf("failed. Unexpected error for %v : %s, expected %s", dnsServersTest.servers, err[0].Error(), dnsServersTest.expectedErr)
+			}
+		}
+	})
+}
+
 func TestIsEmpty(t *testing.T) {
 	t.Run("is object empty", func(t *testing.T) {
 		var nonEmptyMap = make(map[string]interface{})
@@ -16,6 +16,9 @@ import (
 
 // VCSubresourceSchema this helper function extracts the vcenter schema, which
 // contains the parameters required to configure Vcenter in a workload domain.
+// TODO support the management portgroup the vCenter and NSX Manager appliances are deployed on.
+// VcenterSpec and NsxManagerSpec in the VCF API only accept the NetworkDetailsSpec of the appliance,
+// VCF always deploys them on the management portgroup of the management domain.
 func VCSubresourceSchema() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -24,11 +27,17 @@ func VCSubresourceSchema() *schema.Resource {
 				Computed:    true,
 				Description: "ID of the vCenter Server instance",
 			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Version of the deployed vCenter Server instance",
+			},
 			"fqdn": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Fully qualified domain name of the vCenter Server instance",
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "Fully qualified domain name of the vCenter Server instance",
+				ValidateFunc:     validation.NoZeroValues,
+				DiffSuppressFunc: validationUtils.SuppressFqdnDiff,
 			},
 			"name": {
 				Type:         schema.TypeString,
@@ -72,10 +81,11 @@ func VCSubresourceSchema() *schema.Resource {
 				},
 			},
 			"ip_address": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "IPv4 address of the vCenter virtual machine",
-				ValidateFunc: validationUtils.ValidateIPv4AddressSchema,
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "IPv4 address of the vCenter virtual machine",
+				ValidateFunc:     validationUtils.ValidateIPv4AddressSchema,
+				DiffSuppressFunc: validationUtils.SuppressIpAddressDiff,
 			},
 			"subnet_mask": {
 				Type:         schema.TypeString,
@@ -129,6 +139,8 @@ func TryConvertToVcenterSpec(object map[string]interface{}) (*models.VcenterSpec
 	if !ok {
 		vcenterStorageSize = ""
 	}
+	// TODO support custom CPU and memory sizing of the vCenter appliance. VcenterSpec and
+	// SDDCVcenterSpec in the VCF API only accept the predefined vmSize values.
 	vcenterVmSize, ok := object["vm_size"].(string)
 	if !ok {
 		vcenterVmSize = ""